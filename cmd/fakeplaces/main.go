@@ -0,0 +1,46 @@
+package main
+
+import (
+	"clean-storemap-api/src/driver/api/fakeplaces"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+// APIキーを使わずに開発するためのGoogle Maps APIの代わりのサーバ
+// .envのGOOGLE_GEOLOCATION_API_URLとGOOGLE_PLACES_API_URLをこのサーバに向けて使う
+func main() {
+	envPath := ".env"
+	if _, err := os.Stat(envPath); os.IsNotExist(err) {
+		envPath = "../../.env"
+	}
+	if err := godotenv.Load(envPath); err != nil {
+		fmt.Printf("Cannot read: %v", err)
+	}
+
+	addr := os.Getenv("FAKE_PLACES_ADDR")
+	if addr == "" {
+		addr = ":8090"
+	}
+	server := fakeplaces.NewServer(os.Getenv("GOOGLE_MAP_API_KEY"))
+	if path := os.Getenv("FAKE_PLACES_FIXTURES"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("failed to read fixtures: %s\n", err)
+			os.Exit(2)
+		}
+		fixtures, err := fakeplaces.LoadFixtures(data)
+		if err != nil {
+			fmt.Printf("failed to load fixtures: %s\n", err)
+			os.Exit(2)
+		}
+		server = fakeplaces.NewServerWithFixtures(os.Getenv("GOOGLE_MAP_API_KEY"), fixtures)
+	}
+	fmt.Printf("fake places server listening on %s\n", addr)
+	if err := http.ListenAndServe(addr, server); err != nil {
+		fmt.Printf("failed to serve: %s\n", err)
+		os.Exit(2)
+	}
+}
@@ -10,6 +10,7 @@ require (
 	github.com/labstack/echo/v4 v4.12.0
 	github.com/stretchr/testify v1.8.4
 	golang.org/x/oauth2 v0.23.0
+	golang.org/x/sync v0.7.0
 	gopkg.in/go-playground/validator.v9 v9.31.0
 	gorm.io/driver/mysql v1.5.7
 	gorm.io/gorm v1.25.10
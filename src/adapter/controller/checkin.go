@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 訪問日時はRFC3339形式。省略した場合は現在時刻とする
+type CheckinRequestBody struct {
+	VisitedAt *time.Time `json:"visitedAt"`
+	PartySize *int       `json:"partySize"`
+}
+
+type CheckinI interface {
+	GetCheckins(c echo.Context) error
+	CreateCheckin(c echo.Context) error
+}
+
+type CheckinOutputFactory func(echo.Context) port.CheckinOutputPort
+type CheckinInputFactory func(port.CheckinRepository, port.CheckinOutputPort) port.CheckinInputPort
+type CheckinRepositoryFactory func(gateway.CheckinDriver, gateway.GoogleMapDriver) port.CheckinRepository
+type CheckinDriverFactory gateway.CheckinDriver
+
+type CheckinController struct {
+	checkinDriverFactory     CheckinDriverFactory
+	googleMapDriverFactory   GoogleMapDriverFactory
+	checkinOutputFactory     CheckinOutputFactory
+	checkinInputFactory      CheckinInputFactory
+	checkinRepositoryFactory CheckinRepositoryFactory
+}
+
+func NewCheckinController(
+	checkinDriverFactory CheckinDriverFactory,
+	googleMapDriverFactory GoogleMapDriverFactory,
+	checkinOutputFactory CheckinOutputFactory,
+	checkinInputFactory CheckinInputFactory,
+	checkinRepositoryFactory CheckinRepositoryFactory,
+) CheckinI {
+	return &CheckinController{
+		checkinDriverFactory:     checkinDriverFactory,
+		googleMapDriverFactory:   googleMapDriverFactory,
+		checkinOutputFactory:     checkinOutputFactory,
+		checkinInputFactory:      checkinInputFactory,
+		checkinRepositoryFactory: checkinRepositoryFactory,
+	}
+}
+
+func (cc *CheckinController) GetCheckins(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	page, err := model.NewPageRequest(c.QueryParam("cursor"), c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return cc.newCheckinInputPort(c).GetCheckins(userId, page)
+}
+
+func (cc *CheckinController) CreateCheckin(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	storeId := c.Param("id")
+	if !placeIdRegex.MatchString(storeId) {
+		return c.JSON(http.StatusBadRequest, "id is invalid")
+	}
+	var r CheckinRequestBody
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	checkin, err := model.NewCheckin(storeId, userId, r.VisitedAt, r.PartySize, time.Now())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return cc.newCheckinInputPort(c).CreateCheckin(checkin)
+}
+
+func (cc *CheckinController) newCheckinInputPort(c echo.Context) port.CheckinInputPort {
+	checkinOutputPort := cc.checkinOutputFactory(c)
+	checkinDriver := cc.checkinDriverFactory
+	googleMapDriver := cc.googleMapDriverFactory
+	checkinRepository := cc.checkinRepositoryFactory(checkinDriver, googleMapDriver)
+	return cc.checkinInputFactory(checkinRepository, checkinOutputPort)
+}
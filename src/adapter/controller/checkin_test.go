@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"bytes"
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCheckinInputFactoryFuncObject struct {
+	mock.Mock
+}
+
+func (m *MockCheckinInputFactoryFuncObject) GetCheckins(userId string, page *model.PageRequest) error {
+	args := m.Called(userId, page)
+	return args.Error(0)
+}
+
+func (m *MockCheckinInputFactoryFuncObject) CreateCheckin(checkin *model.Checkin) error {
+	args := m.Called(checkin)
+	return args.Error(0)
+}
+
+// InputPortのモックを返すCheckinControllerを作成する
+func newCheckinController(input port.CheckinInputPort) *CheckinController {
+	return &CheckinController{
+		checkinOutputFactory: func(echo.Context) port.CheckinOutputPort {
+			return nil
+		},
+		checkinRepositoryFactory: func(gateway.CheckinDriver, gateway.GoogleMapDriver) port.CheckinRepository {
+			return nil
+		},
+		checkinInputFactory: func(port.CheckinRepository, port.CheckinOutputPort) port.CheckinInputPort {
+			return input
+		},
+	}
+}
+
+func TestCreateCheckin(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"visitedAt": "2024-05-01T12:30:00+09:00", "partySize": 3}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.SetPath("/stores/:id/checkins")
+	c.SetParamNames("id")
+	c.SetParamValues("Id001")
+	c.Set("userId", "id_1")
+
+	mockCheckinInputFactoryFuncObject := new(MockCheckinInputFactoryFuncObject)
+	mockCheckinInputFactoryFuncObject.On("CreateCheckin", mock.Anything).Return(nil)
+	cc := newCheckinController(mockCheckinInputFactoryFuncObject)
+
+	/* Act */
+	actual := cc.CreateCheckin(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockCheckinInputFactoryFuncObject.AssertCalled(t, "CreateCheckin", mock.MatchedBy(func(checkin *model.Checkin) bool {
+		return checkin.StoreId == "Id001" && checkin.UserId == "id_1" && checkin.PartySize == 3 &&
+			checkin.VisitedAt.Equal(time.Date(2024, 5, 1, 3, 30, 0, 0, time.UTC))
+	}))
+}
+
+func TestCreateCheckinWithoutVisitedAt(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.SetPath("/stores/:id/checkins")
+	c.SetParamNames("id")
+	c.SetParamValues("Id001")
+	c.Set("userId", "id_1")
+
+	mockCheckinInputFactoryFuncObject := new(MockCheckinInputFactoryFuncObject)
+	mockCheckinInputFactoryFuncObject.On("CreateCheckin", mock.Anything).Return(nil)
+	cc := newCheckinController(mockCheckinInputFactoryFuncObject)
+	before := time.Now()
+
+	/* Act */
+	actual := cc.CreateCheckin(c)
+
+	/* Assert */
+	// 訪問日時を省略した場合は現在時刻で記録し、人数は0とすること
+	assert.NoError(t, actual)
+	mockCheckinInputFactoryFuncObject.AssertCalled(t, "CreateCheckin", mock.MatchedBy(func(checkin *model.Checkin) bool {
+		return !checkin.VisitedAt.Before(before) && checkin.PartySize == 0
+	}))
+}
+
+func TestCreateCheckinWithInvalidPartySize(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"partySize": 0}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.SetPath("/stores/:id/checkins")
+	c.SetParamNames("id")
+	c.SetParamValues("Id001")
+	c.Set("userId", "id_1")
+
+	mockCheckinInputFactoryFuncObject := new(MockCheckinInputFactoryFuncObject)
+	cc := newCheckinController(mockCheckinInputFactoryFuncObject)
+
+	/* Act */
+	actual := cc.CreateCheckin(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockCheckinInputFactoryFuncObject.AssertNotCalled(t, "CreateCheckin", mock.Anything)
+}
+
+func TestCreateCheckinInFuture(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	visitedAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"visitedAt": "`+visitedAt+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.SetPath("/stores/:id/checkins")
+	c.SetParamNames("id")
+	c.SetParamValues("Id001")
+	c.Set("userId", "id_1")
+
+	mockCheckinInputFactoryFuncObject := new(MockCheckinInputFactoryFuncObject)
+	cc := newCheckinController(mockCheckinInputFactoryFuncObject)
+
+	/* Act */
+	actual := cc.CreateCheckin(c)
+
+	/* Assert */
+	// 未来の訪問は記録できないこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockCheckinInputFactoryFuncObject.AssertNotCalled(t, "CreateCheckin", mock.Anything)
+}
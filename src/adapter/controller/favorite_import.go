@@ -0,0 +1,268 @@
+package controller
+
+import (
+	model "clean-storemap-api/src/entity"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 取り込めるファイルの最大のサイズ(バイト)
+const maxImportFileSize = 5 << 20
+
+// 取り込むファイルの形式
+const (
+	importFormatTakeout = "takeout" // Googleマップの保存済みの場所(Google TakeoutのSaved Places.json)
+	importFormatCsv     = "csv"     // このアプリで書き出したCSV
+)
+
+// multipartのfileで受け取ったファイルを取り込む。形式はformatで指定し、省略した場合は拡張子で判断する
+func (sc *StoreController) ImportFavoriteStores(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, "file is required")
+	}
+	if file.Size > maxImportFileSize {
+		return c.JSON(http.StatusBadRequest, "file must be at most 5MB")
+	}
+	format, err := importFormat(c.FormValue("format"), file.Filename)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	var rows []*model.ImportRow
+	switch format {
+	case importFormatTakeout:
+		rows, err = parseTakeoutRows(src)
+	case importFormatCsv:
+		rows, err = parseCsvRows(src)
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if len(rows) > model.MaxImportRows {
+		return c.JSON(http.StatusBadRequest, "file must have at most 1000 rows, got "+strconv.Itoa(len(rows)))
+	}
+	return sc.newStoreInputPort(c).ImportFavoriteStores(userId, rows)
+}
+
+func importFormat(format string, filename string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".json", ".geojson":
+			format = importFormatTakeout
+		case ".csv":
+			format = importFormatCsv
+		}
+	}
+	if format != importFormatTakeout && format != importFormatCsv {
+		return "", errors.New("format must be takeout or csv")
+	}
+	return format, nil
+}
+
+// 1行分の値を検証して取り込む行を作成する。店舗のidが空の場合は取り込む際に店舗を探す
+func newImportRow(row int, id string, name string, regularOpeningHours string, priceLevel string, lat string, lng string, note string, tags []string, savedAt time.Time) *model.ImportRow {
+	r := &model.ImportRow{Row: row, Name: name}
+	if name == "" {
+		r.Invalid = "name is required"
+		return r
+	}
+	if id != "" && !placeIdRegex.MatchString(id) {
+		r.Invalid = "id is invalid"
+		return r
+	}
+	store, err := model.NewStore(id, name, regularOpeningHours, priceLevel, lat, lng)
+	if err != nil {
+		r.Invalid = err.Error()
+		return r
+	}
+	if store.Favorite, err = model.NewFavorite(note, tags); err != nil {
+		r.Invalid = err.Error()
+		return r
+	}
+	store.Favorite.SavedAt = savedAt
+	r.Store = store
+	return r
+}
+
+// 書き出したCSVと同じ列名のヘッダが必要。列の順番は問わず、name・latitude・longitude以外の列は省略できる
+func parseCsvRows(r io.Reader) ([]*model.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, errors.New("csv is invalid: " + err.Error())
+	}
+	columns := make(map[string]int)
+	for i, h := range header {
+		// Excelで保存したCSVの先頭に付くBOMを除く
+		h = strings.TrimPrefix(h, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"name", "latitude", "longitude"} {
+		if _, ok := columns[required]; !ok {
+			return nil, errors.New("column " + required + " is required")
+		}
+	}
+
+	rows := make([]*model.ImportRow, 0)
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("csv is invalid: " + err.Error())
+		}
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+		var savedAt time.Time
+		if v := strings.TrimSpace(value("saved_at")); v != "" {
+			if savedAt, err = time.Parse(time.RFC3339, v); err != nil {
+				rows = append(rows, &model.ImportRow{Row: n, Name: value("name"), Invalid: "saved_at is invalid"})
+				continue
+			}
+		}
+		rows = append(rows, newImportRow(
+			n,
+			strings.TrimSpace(value("id")),
+			strings.TrimSpace(value("name")),
+			value("regular_opening_hours"),
+			strings.TrimSpace(value("price_level")),
+			strings.TrimSpace(value("latitude")),
+			strings.TrimSpace(value("longitude")),
+			value("note"),
+			strings.Split(value("tags"), ","),
+			savedAt,
+		))
+	}
+	return rows, nil
+}
+
+// Google TakeoutのSaved Places.json
+type takeoutSavedPlaces struct {
+	Type     string           `json:"type"`
+	Features []takeoutFeature `json:"features"`
+}
+
+type takeoutFeature struct {
+	Geometry   *takeoutGeometry  `json:"geometry"`
+	Properties takeoutProperties `json:"properties"`
+}
+
+type takeoutGeometry struct {
+	Coordinates []float64 `json:"coordinates"` // [経度, 緯度]
+}
+
+// 項目名は書き出した時期によって異なるため、どちらの形式も読めるようにする
+type takeoutProperties struct {
+	Date          string           `json:"date"`
+	GoogleMapsUrl string           `json:"google_maps_url"`
+	Location      *takeoutLocation `json:"location"`
+	Comment       string           `json:"Comment"`
+	// 以前の形式の項目
+	Title               string                 `json:"Title"`
+	Published           string                 `json:"Published"`
+	LegacyGoogleMapsUrl string                 `json:"Google Maps URL"`
+	LegacyLocation      *takeoutLegacyLocation `json:"Location"`
+}
+
+type takeoutLocation struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+type takeoutLegacyLocation struct {
+	BusinessName string `json:"Business Name"`
+	Address      string `json:"Address"`
+}
+
+// Featureごとに1行とする。価格帯と営業時間は含まれないため空とする
+func parseTakeoutRows(r io.Reader) ([]*model.ImportRow, error) {
+	var places takeoutSavedPlaces
+	if err := json.NewDecoder(r).Decode(&places); err != nil || places.Type != "FeatureCollection" {
+		return nil, errors.New("file is not a Saved Places GeoJSON")
+	}
+	rows := make([]*model.ImportRow, 0, len(places.Features))
+	for i, f := range places.Features {
+		p := f.Properties
+		name := p.Title
+		if p.LegacyLocation != nil && p.LegacyLocation.BusinessName != "" {
+			name = p.LegacyLocation.BusinessName
+		}
+		if p.Location != nil && p.Location.Name != "" {
+			name = p.Location.Name
+		}
+		name = strings.TrimSpace(name)
+		// 位置のわからない場所は座標が0,0となっている
+		if f.Geometry == nil || len(f.Geometry.Coordinates) < 2 || (f.Geometry.Coordinates[0] == 0 && f.Geometry.Coordinates[1] == 0) {
+			rows = append(rows, &model.ImportRow{Row: i + 1, Name: name, Invalid: "location is missing"})
+			continue
+		}
+		googleMapsUrl := p.GoogleMapsUrl
+		if googleMapsUrl == "" {
+			googleMapsUrl = p.LegacyGoogleMapsUrl
+		}
+		date := p.Date
+		if date == "" {
+			date = p.Published
+		}
+		// 日時が読めない場合は取り込んだ日時に登録したものとする
+		savedAt, _ := time.Parse(time.RFC3339, date)
+		rows = append(rows, newImportRow(
+			i+1,
+			placeIdFromUrl(googleMapsUrl),
+			name,
+			"",
+			"",
+			strconv.FormatFloat(f.Geometry.Coordinates[1], 'f', -1, 64),
+			strconv.FormatFloat(f.Geometry.Coordinates[0], 'f', -1, 64),
+			p.Comment,
+			nil,
+			savedAt,
+		))
+	}
+	return rows, nil
+}
+
+// GoogleマップのURLにplace idが含まれていれば返す。cidのみの場合は空
+func placeIdFromUrl(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return ""
+	}
+	query := u.Query()
+	for _, key := range []string{"query_place_id", "place_id"} {
+		if id := query.Get(key); placeIdRegex.MatchString(id) {
+			return id
+		}
+	}
+	return ""
+}
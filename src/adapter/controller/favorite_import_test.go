@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"bytes"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fileフィールドにファイルを添付したmultipartのリクエストを作成する
+func newImportRequest(filename string, content string, format string) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	if format != "" {
+		writer.WriteField("format", format)
+	}
+	if filename != "" {
+		part, _ := writer.CreateFormFile("file", filename)
+		part.Write([]byte(content))
+	}
+	writer.Close()
+	req := httptest.NewRequest(http.MethodPost, "/user/favorite-store/import", body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	return req
+}
+
+// InputPortのモックを返すStoreControllerを作成する
+func newImportStoreController(input port.StoreInputPort) *StoreController {
+	return &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+		storeInputFactory: func(port.StoreRepository, port.StoreOutputPort) port.StoreInputPort {
+			return input
+		},
+	}
+}
+
+func TestImportFavoriteStoresFromCsv(t *testing.T) {
+	/* Arrange */
+	content := "\ufeffid,name,latitude,longitude,price_level,regular_opening_hours,saved_at,note,tags\n" +
+		"Id001,UEC cafe,35.713,139.762,PRICE_LEVEL_MODERATE,Mon: 09:00 - 18:00,2024-05-01T03:30:00Z,\"窓際, 電源あり\",\"cafe,wifi\"\n" +
+		"Id002,UEC restaurant,135.0,139.762,,,,,\n" +
+		",Hongo coffee,35.711,139.761,,,,,\n" +
+		"Id003,,35.711,139.761,,,,,\n" +
+		"Id004,UEC bar,35.711,139.761,,,yesterday,,\n"
+	c, _ := newRouter()
+	c.SetRequest(newImportRequest("favorite-stores.csv", content, ""))
+	c.Set("userId", "id_1")
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("ImportFavoriteStores", mock.Anything, mock.Anything).Return(nil)
+	sc := newImportStoreController(mockStoreInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.ImportFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	rows := mockStoreInputFactoryFuncObject.Calls[0].Arguments.Get(1).([]*model.ImportRow)
+	if assert.Len(t, rows, 5) {
+		// 書き出したCSVの値をそのまま読めること
+		assert.Equal(t, &model.Store{
+			Id:                  "Id001",
+			Name:                "UEC cafe",
+			RegularOpeningHours: "Mon: 09:00 - 18:00",
+			PriceLevel:          model.PriceLevelModerate,
+			Location:            model.Location{Lat: "35.713", Lng: "139.762"},
+			Favorite: &model.Favorite{
+				Note:    "窓際, 電源あり",
+				Tags:    []string{"cafe", "wifi"},
+				SavedAt: time.Date(2024, 5, 1, 3, 30, 0, 0, time.UTC),
+			},
+		}, rows[0].Store)
+		// NewStoreで検証に失敗した行は理由を付けて無効とすること
+		assert.Nil(t, rows[1].Store)
+		assert.Equal(t, "latitude must be between -90 and 90, got 135.0", rows[1].Invalid)
+		// idが空の行は取り込む際に店舗を探すこと
+		assert.Equal(t, "", rows[2].Store.Id)
+		assert.Equal(t, 3, rows[2].Row)
+		assert.Equal(t, "name is required", rows[3].Invalid)
+		assert.Equal(t, "saved_at is invalid", rows[4].Invalid)
+	}
+}
+
+func TestImportFavoriteStoresFromTakeout(t *testing.T) {
+	/* Arrange */
+	content := `{"type": "FeatureCollection", "features": [
+		{"geometry": {"coordinates": [139.762, 35.713], "type": "Point"},
+		 "properties": {"date": "2019-05-01T03:30:00Z", "google_maps_url": "https://www.google.com/maps/search/?api=1&query=UEC&query_place_id=ChIJ001", "location": {"address": "Chofu", "name": "UEC cafe"}, "Comment": "おすすめ"},
+		 "type": "Feature"},
+		{"geometry": {"coordinates": [139.761, 35.711], "type": "Point"},
+		 "properties": {"date": "2020-01-01T00:00:00Z", "google_maps_url": "http://maps.google.com/?cid=1234567890", "location": {"name": "Hongo coffee"}},
+		 "type": "Feature"},
+		{"geometry": {"coordinates": [139.5, 35.6], "type": "Point"},
+		 "properties": {"Title": "UEC bar", "Published": "2018-01-01T00:00:00Z", "Google Maps URL": "http://maps.google.com/?cid=1", "Location": {"Business Name": "UEC bar", "Address": "Chofu"}},
+		 "type": "Feature"},
+		{"geometry": {"coordinates": [0, 0], "type": "Point"},
+		 "properties": {"google_maps_url": "http://maps.google.com/?q=somewhere", "location": {"name": "Dropped pin"}},
+		 "type": "Feature"}
+	]}`
+	c, _ := newRouter()
+	c.SetRequest(newImportRequest("Saved Places.json", content, ""))
+	c.Set("userId", "id_1")
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("ImportFavoriteStores", mock.Anything, mock.Anything).Return(nil)
+	sc := newImportStoreController(mockStoreInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.ImportFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	rows := mockStoreInputFactoryFuncObject.Calls[0].Arguments.Get(1).([]*model.ImportRow)
+	if assert.Len(t, rows, 4) {
+		// URLにplace idがあればidとし、座標は経度・緯度の順に読むこと
+		assert.Equal(t, "ChIJ001", rows[0].Store.Id)
+		assert.Equal(t, "UEC cafe", rows[0].Store.Name)
+		assert.Equal(t, model.Location{Lat: "35.713", Lng: "139.762"}, rows[0].Store.Location)
+		assert.Equal(t, "おすすめ", rows[0].Store.Favorite.Note)
+		assert.Equal(t, time.Date(2019, 5, 1, 3, 30, 0, 0, time.UTC), rows[0].Store.Favorite.SavedAt)
+		// cidのみの場合はidを空とすること
+		assert.Equal(t, "", rows[1].Store.Id)
+		assert.Equal(t, "Hongo coffee", rows[1].Store.Name)
+		// 以前の形式の項目も読めること
+		assert.Equal(t, "UEC bar", rows[2].Store.Name)
+		assert.Equal(t, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), rows[2].Store.Favorite.SavedAt)
+		// 位置のわからない場所は無効とすること
+		assert.Equal(t, &model.ImportRow{Row: 4, Name: "Dropped pin", Invalid: "location is missing"}, rows[3])
+	}
+}
+
+func TestImportFavoriteStoresWithoutFile(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.SetRequest(newImportRequest("", "", "csv"))
+	c.Set("userId", "id_1")
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc := newImportStoreController(mockStoreInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.ImportFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "ImportFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestImportFavoriteStoresWithUnknownFormat(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.SetRequest(newImportRequest("favorites.xlsx", "id,name", ""))
+	c.Set("userId", "id_1")
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc := newImportStoreController(mockStoreInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.ImportFavoriteStores(c)
+
+	/* Assert */
+	// 拡張子から形式がわからない場合はformatの指定が必要なこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "ImportFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestImportFavoriteStoresWithoutRequiredColumn(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.SetRequest(newImportRequest("favorites.txt", "id,name,latitude\nId001,UEC cafe,35.713\n", "csv"))
+	c.Set("userId", "id_1")
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc := newImportStoreController(mockStoreInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.ImportFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"column longitude is required\"\n", rec.Body.String())
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "ImportFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestImportFavoriteStoresWithInvalidGeoJson(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.SetRequest(newImportRequest("Saved Places.json", `{"type": "Feature"}`, ""))
+	c.Set("userId", "id_1")
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc := newImportStoreController(mockStoreInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.ImportFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "ImportFavoriteStores", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FavoriteListRequestBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// 指定した項目のみを変更する
+type FavoriteListChangeRequestBody struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Position    *int    `json:"position"`
+}
+
+// リストのidはuuid
+var listIdRegex = regexp.MustCompile(`^[0-9a-f-]{36}$`)
+
+type FavoriteListI interface {
+	GetLists(c echo.Context) error
+	GetList(c echo.Context) error
+	CreateList(c echo.Context) error
+	UpdateList(c echo.Context) error
+	DeleteList(c echo.Context) error
+	AddStore(c echo.Context) error
+	RemoveStore(c echo.Context) error
+}
+
+type FavoriteListOutputFactory func(echo.Context) port.FavoriteListOutputPort
+type FavoriteListInputFactory func(port.FavoriteListRepository, port.FavoriteListOutputPort) port.FavoriteListInputPort
+type FavoriteListRepositoryFactory func(gateway.FavoriteListDriver) port.FavoriteListRepository
+type FavoriteListDriverFactory gateway.FavoriteListDriver
+
+type FavoriteListController struct {
+	favoriteListDriverFactory     FavoriteListDriverFactory
+	favoriteListOutputFactory     FavoriteListOutputFactory
+	favoriteListInputFactory      FavoriteListInputFactory
+	favoriteListRepositoryFactory FavoriteListRepositoryFactory
+}
+
+func NewFavoriteListController(
+	favoriteListDriverFactory FavoriteListDriverFactory,
+	favoriteListOutputFactory FavoriteListOutputFactory,
+	favoriteListInputFactory FavoriteListInputFactory,
+	favoriteListRepositoryFactory FavoriteListRepositoryFactory,
+) FavoriteListI {
+	return &FavoriteListController{
+		favoriteListDriverFactory:     favoriteListDriverFactory,
+		favoriteListOutputFactory:     favoriteListOutputFactory,
+		favoriteListInputFactory:      favoriteListInputFactory,
+		favoriteListRepositoryFactory: favoriteListRepositoryFactory,
+	}
+}
+
+func (fc *FavoriteListController) GetLists(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	return fc.newFavoriteListInputPort(c).GetLists(userId)
+}
+
+func (fc *FavoriteListController) GetList(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	listId := c.Param("listId")
+	if !listIdRegex.MatchString(listId) {
+		return c.JSON(http.StatusBadRequest, "listId is invalid")
+	}
+	return fc.newFavoriteListInputPort(c).GetList(listId, userId)
+}
+
+func (fc *FavoriteListController) CreateList(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	var l FavoriteListRequestBody
+	if err := c.Bind(&l); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	list, err := model.NewFavoriteList(l.Name, l.Description)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return fc.newFavoriteListInputPort(c).CreateList(list, userId)
+}
+
+func (fc *FavoriteListController) UpdateList(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	listId := c.Param("listId")
+	if !listIdRegex.MatchString(listId) {
+		return c.JSON(http.StatusBadRequest, "listId is invalid")
+	}
+	var l FavoriteListChangeRequestBody
+	if err := c.Bind(&l); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	change, err := model.NewFavoriteListChange(l.Name, l.Description, l.Position)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return fc.newFavoriteListInputPort(c).UpdateList(listId, userId, change)
+}
+
+func (fc *FavoriteListController) DeleteList(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	listId := c.Param("listId")
+	if !listIdRegex.MatchString(listId) {
+		return c.JSON(http.StatusBadRequest, "listId is invalid")
+	}
+	return fc.newFavoriteListInputPort(c).DeleteList(listId, userId)
+}
+
+// お気に入り登録と同じ形式で店舗を受け取り、同じ検証を行う
+func (fc *FavoriteListController) AddStore(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	listId := c.Param("listId")
+	if !listIdRegex.MatchString(listId) {
+		return c.JSON(http.StatusBadRequest, "listId is invalid")
+	}
+	store, status, err := bindStoreRequest(c)
+	if err != nil {
+		return c.JSON(status, err.Error())
+	}
+	return fc.newFavoriteListInputPort(c).AddStore(listId, userId, store)
+}
+
+func (fc *FavoriteListController) RemoveStore(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	listId := c.Param("listId")
+	if !listIdRegex.MatchString(listId) {
+		return c.JSON(http.StatusBadRequest, "listId is invalid")
+	}
+	storeId := c.Param("storeId")
+	if !placeIdRegex.MatchString(storeId) {
+		return c.JSON(http.StatusBadRequest, "storeId is invalid")
+	}
+	return fc.newFavoriteListInputPort(c).RemoveStore(listId, userId, storeId)
+}
+
+func (fc *FavoriteListController) newFavoriteListInputPort(c echo.Context) port.FavoriteListInputPort {
+	favoriteListOutputPort := fc.favoriteListOutputFactory(c)
+	favoriteListDriver := fc.favoriteListDriverFactory
+	favoriteListRepository := fc.favoriteListRepositoryFactory(favoriteListDriver)
+	return fc.favoriteListInputFactory(favoriteListRepository, favoriteListOutputPort)
+}
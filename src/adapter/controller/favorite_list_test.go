@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"bytes"
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFavoriteListInputFactoryFuncObject struct {
+	mock.Mock
+}
+
+func (m *MockFavoriteListInputFactoryFuncObject) GetLists(userId string) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListInputFactoryFuncObject) GetList(listId string, userId string) error {
+	args := m.Called(listId, userId)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListInputFactoryFuncObject) CreateList(list *model.FavoriteList, userId string) error {
+	args := m.Called(list, userId)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListInputFactoryFuncObject) UpdateList(listId string, userId string, change *model.FavoriteListChange) error {
+	args := m.Called(listId, userId, change)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListInputFactoryFuncObject) DeleteList(listId string, userId string) error {
+	args := m.Called(listId, userId)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListInputFactoryFuncObject) AddStore(listId string, userId string, store *model.Store) error {
+	args := m.Called(listId, userId, store)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListInputFactoryFuncObject) RemoveStore(listId string, userId string, storeId string) error {
+	args := m.Called(listId, userId, storeId)
+	return args.Error(0)
+}
+
+const testListId = "3f2b8c1e-8a4d-4f6b-9c2a-1d5e7f9a0b3c"
+
+// InputPortのモックを返すFavoriteListControllerを作成する
+func newFavoriteListController(input port.FavoriteListInputPort) *FavoriteListController {
+	return &FavoriteListController{
+		favoriteListOutputFactory: func(echo.Context) port.FavoriteListOutputPort {
+			return nil
+		},
+		favoriteListRepositoryFactory: func(gateway.FavoriteListDriver) port.FavoriteListRepository {
+			return nil
+		},
+		favoriteListInputFactory: func(port.FavoriteListRepository, port.FavoriteListOutputPort) port.FavoriteListInputPort {
+			return input
+		},
+	}
+}
+
+func TestCreateList(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodPost, "/user/lists", bytes.NewBufferString(`{"name": " Work lunch ", "description": "平日のお昼"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	mockFavoriteListInputFactoryFuncObject := new(MockFavoriteListInputFactoryFuncObject)
+	mockFavoriteListInputFactoryFuncObject.On("CreateList", mock.Anything, "id_1").Return(nil)
+	fc := newFavoriteListController(mockFavoriteListInputFactoryFuncObject)
+
+	/* Act */
+	actual := fc.CreateList(c)
+
+	/* Assert */
+	// 名前の前後の空白は取り除かれること
+	assert.NoError(t, actual)
+	mockFavoriteListInputFactoryFuncObject.AssertCalled(t, "CreateList", &model.FavoriteList{Name: "Work lunch", Description: "平日のお昼"}, "id_1")
+}
+
+func TestCreateListWithoutName(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodPost, "/user/lists", bytes.NewBufferString(`{"description": "平日のお昼"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	mockFavoriteListInputFactoryFuncObject := new(MockFavoriteListInputFactoryFuncObject)
+	fc := newFavoriteListController(mockFavoriteListInputFactoryFuncObject)
+
+	/* Act */
+	actual := fc.CreateList(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockFavoriteListInputFactoryFuncObject.AssertNotCalled(t, "CreateList", mock.Anything, mock.Anything)
+}
+
+func TestUpdateList(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(`{"position": 0}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.SetPath("/user/lists/:listId")
+	c.SetParamNames("listId")
+	c.SetParamValues(testListId)
+	c.Set("userId", "id_1")
+
+	mockFavoriteListInputFactoryFuncObject := new(MockFavoriteListInputFactoryFuncObject)
+	mockFavoriteListInputFactoryFuncObject.On("UpdateList", testListId, "id_1", mock.Anything).Return(nil)
+	fc := newFavoriteListController(mockFavoriteListInputFactoryFuncObject)
+
+	/* Act */
+	actual := fc.UpdateList(c)
+
+	/* Assert */
+	// 指定していない項目は変更しないこと
+	assert.NoError(t, actual)
+	position := 0
+	mockFavoriteListInputFactoryFuncObject.AssertCalled(t, "UpdateList", testListId, "id_1", &model.FavoriteListChange{Position: &position})
+}
+
+func TestAddStoreWithInvalidLocation(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	reqBody := `{
+		"storeId": "Id001",
+		"storeName": "UEC cafe",
+		"latitude": "135.713",
+		"longitude": "139.762"
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.SetPath("/user/lists/:listId/stores")
+	c.SetParamNames("listId")
+	c.SetParamValues(testListId)
+	c.Set("userId", "id_1")
+
+	mockFavoriteListInputFactoryFuncObject := new(MockFavoriteListInputFactoryFuncObject)
+	fc := newFavoriteListController(mockFavoriteListInputFactoryFuncObject)
+
+	/* Act */
+	actual := fc.AddStore(c)
+
+	/* Assert */
+	// お気に入り登録と同じくmodel.NewStoreで検証されること
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockFavoriteListInputFactoryFuncObject.AssertNotCalled(t, "AddStore", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveStoreWithInvalidListId(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.SetPath("/user/lists/:listId/stores/:storeId")
+	c.SetParamNames("listId", "storeId")
+	c.SetParamValues("../lists", "Id001")
+	c.Set("userId", "id_1")
+
+	mockFavoriteListInputFactoryFuncObject := new(MockFavoriteListInputFactoryFuncObject)
+	fc := newFavoriteListController(mockFavoriteListInputFactoryFuncObject)
+
+	/* Act */
+	actual := fc.RemoveStore(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockFavoriteListInputFactoryFuncObject.AssertNotCalled(t, "RemoveStore", mock.Anything, mock.Anything, mock.Anything)
+}
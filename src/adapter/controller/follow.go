@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ユーザーのidはuuid
+var userIdRegex = regexp.MustCompile(`^[0-9a-f-]{36}$`)
+
+type FollowI interface {
+	Follow(c echo.Context) error
+	Unfollow(c echo.Context) error
+	GetFollowing(c echo.Context) error
+	GetFollowers(c echo.Context) error
+	GetFeed(c echo.Context) error
+}
+
+type FollowOutputFactory func(echo.Context) port.FollowOutputPort
+type FollowInputFactory func(port.FollowRepository, port.FollowOutputPort) port.FollowInputPort
+type FollowRepositoryFactory func(gateway.FollowDriver) port.FollowRepository
+type FollowDriverFactory gateway.FollowDriver
+
+type FollowController struct {
+	followDriverFactory     FollowDriverFactory
+	followOutputFactory     FollowOutputFactory
+	followInputFactory      FollowInputFactory
+	followRepositoryFactory FollowRepositoryFactory
+}
+
+func NewFollowController(
+	followDriverFactory FollowDriverFactory,
+	followOutputFactory FollowOutputFactory,
+	followInputFactory FollowInputFactory,
+	followRepositoryFactory FollowRepositoryFactory,
+) FollowI {
+	return &FollowController{
+		followDriverFactory:     followDriverFactory,
+		followOutputFactory:     followOutputFactory,
+		followInputFactory:      followInputFactory,
+		followRepositoryFactory: followRepositoryFactory,
+	}
+}
+
+func (fc *FollowController) Follow(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	followeeId := c.Param("userId")
+	if !userIdRegex.MatchString(followeeId) {
+		return c.JSON(http.StatusBadRequest, "userId is invalid")
+	}
+	if followeeId == userId {
+		return c.JSON(http.StatusBadRequest, "cannot follow yourself")
+	}
+	return fc.newFollowInputPort(c).Follow(userId, followeeId)
+}
+
+func (fc *FollowController) Unfollow(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	followeeId := c.Param("userId")
+	if !userIdRegex.MatchString(followeeId) {
+		return c.JSON(http.StatusBadRequest, "userId is invalid")
+	}
+	return fc.newFollowInputPort(c).Unfollow(userId, followeeId)
+}
+
+func (fc *FollowController) GetFollowing(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	return fc.newFollowInputPort(c).GetFollowing(userId)
+}
+
+func (fc *FollowController) GetFollowers(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	return fc.newFollowInputPort(c).GetFollowers(userId)
+}
+
+// cursorに前のページで返したnextCursorを指定すると続きを返す
+func (fc *FollowController) GetFeed(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	page, err := model.NewFeedPageRequest(c.QueryParam("cursor"), c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return fc.newFollowInputPort(c).GetFeed(userId, page)
+}
+
+func (fc *FollowController) newFollowInputPort(c echo.Context) port.FollowInputPort {
+	followOutputPort := fc.followOutputFactory(c)
+	followDriver := fc.followDriverFactory
+	followRepository := fc.followRepositoryFactory(followDriver)
+	return fc.followInputFactory(followRepository, followOutputPort)
+}
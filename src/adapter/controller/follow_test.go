@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFollowInputFactoryFuncObject struct {
+	mock.Mock
+}
+
+func (m *MockFollowInputFactoryFuncObject) Follow(userId string, followeeId string) error {
+	args := m.Called(userId, followeeId)
+	return args.Error(0)
+}
+
+func (m *MockFollowInputFactoryFuncObject) Unfollow(userId string, followeeId string) error {
+	args := m.Called(userId, followeeId)
+	return args.Error(0)
+}
+
+func (m *MockFollowInputFactoryFuncObject) GetFollowing(userId string) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *MockFollowInputFactoryFuncObject) GetFollowers(userId string) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *MockFollowInputFactoryFuncObject) GetFeed(userId string, page *model.FeedPageRequest) error {
+	args := m.Called(userId, page)
+	return args.Error(0)
+}
+
+// InputPortのモックを返すFollowControllerを作成する
+func newFollowController(input port.FollowInputPort) *FollowController {
+	return &FollowController{
+		followOutputFactory: func(echo.Context) port.FollowOutputPort {
+			return nil
+		},
+		followRepositoryFactory: func(gateway.FollowDriver) port.FollowRepository {
+			return nil
+		},
+		followInputFactory: func(port.FollowRepository, port.FollowOutputPort) port.FollowInputPort {
+			return input
+		},
+	}
+}
+
+func TestFollow(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	c.Set("userId", "0b6e5a4c-5a1d-4f57-9d3e-2f8c1a7b9e10")
+	c.SetParamNames("userId")
+	c.SetParamValues("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+
+	mockFollowInputFactoryFuncObject := new(MockFollowInputFactoryFuncObject)
+	mockFollowInputFactoryFuncObject.On("Follow", mock.Anything, mock.Anything).Return(nil)
+	fc := newFollowController(mockFollowInputFactoryFuncObject)
+
+	/* Act */
+	actual := fc.Follow(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFollowInputFactoryFuncObject.AssertCalled(t, "Follow", "0b6e5a4c-5a1d-4f57-9d3e-2f8c1a7b9e10", "7c9e6679-7425-40de-944b-e07fc1f90ae7")
+}
+
+func TestFollowYourself(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.Set("userId", "0b6e5a4c-5a1d-4f57-9d3e-2f8c1a7b9e10")
+	c.SetParamNames("userId")
+	c.SetParamValues("0b6e5a4c-5a1d-4f57-9d3e-2f8c1a7b9e10")
+
+	mockFollowInputFactoryFuncObject := new(MockFollowInputFactoryFuncObject)
+	fc := newFollowController(mockFollowInputFactoryFuncObject)
+
+	/* Act */
+	actual := fc.Follow(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockFollowInputFactoryFuncObject.AssertNotCalled(t, "Follow", mock.Anything, mock.Anything)
+}
+
+func TestGetFeed(t *testing.T) {
+	/* Arrange */
+	cursor := &model.FeedCursor{FavoritedAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC), UserId: "user_2", StoreId: "Id001"}
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/feed?limit=5&cursor="+cursor.Encode(), nil)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	mockFollowInputFactoryFuncObject := new(MockFollowInputFactoryFuncObject)
+	mockFollowInputFactoryFuncObject.On("GetFeed", mock.Anything, mock.Anything).Return(nil)
+	fc := newFollowController(mockFollowInputFactoryFuncObject)
+
+	/* Act */
+	actual := fc.GetFeed(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFollowInputFactoryFuncObject.AssertCalled(t, "GetFeed", "id_1", &model.FeedPageRequest{Cursor: cursor, Limit: 5})
+}
+
+func TestGetFeedWithInvalidCursor(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/feed?cursor=invalid", nil)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	mockFollowInputFactoryFuncObject := new(MockFollowInputFactoryFuncObject)
+	fc := newFollowController(mockFollowInputFactoryFuncObject)
+
+	/* Act */
+	actual := fc.GetFeed(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockFollowInputFactoryFuncObject.AssertNotCalled(t, "GetFeed", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RecommendationI interface {
+	GetRecommendations(c echo.Context) error
+}
+
+type RecommendationOutputFactory func(echo.Context) port.RecommendationOutputPort
+type RecommendationInputFactory func(port.RecommendationRepository, port.RecommendationOutputPort) port.RecommendationInputPort
+type RecommendationRepositoryFactory func(gateway.RecommendationDriver) port.RecommendationRepository
+type RecommendationDriverFactory gateway.RecommendationDriver
+
+type RecommendationController struct {
+	recommendationDriverFactory     RecommendationDriverFactory
+	recommendationOutputFactory     RecommendationOutputFactory
+	recommendationInputFactory      RecommendationInputFactory
+	recommendationRepositoryFactory RecommendationRepositoryFactory
+}
+
+func NewRecommendationController(
+	recommendationDriverFactory RecommendationDriverFactory,
+	recommendationOutputFactory RecommendationOutputFactory,
+	recommendationInputFactory RecommendationInputFactory,
+	recommendationRepositoryFactory RecommendationRepositoryFactory,
+) RecommendationI {
+	return &RecommendationController{
+		recommendationDriverFactory:     recommendationDriverFactory,
+		recommendationOutputFactory:     recommendationOutputFactory,
+		recommendationInputFactory:      recommendationInputFactory,
+		recommendationRepositoryFactory: recommendationRepositoryFactory,
+	}
+}
+
+// limitで件数、demographic=trueで年代・性別・性自認の近いユーザーを重視する
+func (rc *RecommendationController) GetRecommendations(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	options, err := model.NewRecommendationOptions(c.QueryParam("limit"), c.QueryParam("demographic"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return rc.newRecommendationInputPort(c).GetRecommendations(userId, options)
+}
+
+func (rc *RecommendationController) newRecommendationInputPort(c echo.Context) port.RecommendationInputPort {
+	recommendationOutputPort := rc.recommendationOutputFactory(c)
+	recommendationDriver := rc.recommendationDriverFactory
+	recommendationRepository := rc.recommendationRepositoryFactory(recommendationDriver)
+	return rc.recommendationInputFactory(recommendationRepository, recommendationOutputPort)
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecommendationInputFactoryFuncObject struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationInputFactoryFuncObject) GetRecommendations(userId string, options *model.RecommendationOptions) error {
+	args := m.Called(userId, options)
+	return args.Error(0)
+}
+
+// InputPortのモックを返すRecommendationControllerを作成する
+func newRecommendationController(input port.RecommendationInputPort) *RecommendationController {
+	return &RecommendationController{
+		recommendationOutputFactory: func(echo.Context) port.RecommendationOutputPort {
+			return nil
+		},
+		recommendationRepositoryFactory: func(gateway.RecommendationDriver) port.RecommendationRepository {
+			return nil
+		},
+		recommendationInputFactory: func(port.RecommendationRepository, port.RecommendationOutputPort) port.RecommendationInputPort {
+			return input
+		},
+	}
+}
+
+func TestGetRecommendations(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/recommendations?limit=5&demographic=true", nil)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	mockRecommendationInputFactoryFuncObject := new(MockRecommendationInputFactoryFuncObject)
+	mockRecommendationInputFactoryFuncObject.On("GetRecommendations", mock.Anything, mock.Anything).Return(nil)
+	rc := newRecommendationController(mockRecommendationInputFactoryFuncObject)
+
+	/* Act */
+	actual := rc.GetRecommendations(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockRecommendationInputFactoryFuncObject.AssertCalled(t, "GetRecommendations", "id_1", &model.RecommendationOptions{Limit: 5, Demographic: true})
+}
+
+func TestGetRecommendationsWithDefaultOptions(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	c.Set("userId", "id_1")
+
+	mockRecommendationInputFactoryFuncObject := new(MockRecommendationInputFactoryFuncObject)
+	mockRecommendationInputFactoryFuncObject.On("GetRecommendations", mock.Anything, mock.Anything).Return(nil)
+	rc := newRecommendationController(mockRecommendationInputFactoryFuncObject)
+
+	/* Act */
+	actual := rc.GetRecommendations(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockRecommendationInputFactoryFuncObject.AssertCalled(t, "GetRecommendations", "id_1", &model.RecommendationOptions{Limit: model.DefaultRecommendationLimit})
+}
+
+func TestGetRecommendationsWithInvalidLimit(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/recommendations?limit=100", nil)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	mockRecommendationInputFactoryFuncObject := new(MockRecommendationInputFactoryFuncObject)
+	rc := newRecommendationController(mockRecommendationInputFactoryFuncObject)
+
+	/* Act */
+	actual := rc.GetRecommendations(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockRecommendationInputFactoryFuncObject.AssertNotCalled(t, "GetRecommendations", mock.Anything, mock.Anything)
+}
+
+func TestGetRecommendationsWithInvalidDemographic(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/recommendations?demographic=yes", nil)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	mockRecommendationInputFactoryFuncObject := new(MockRecommendationInputFactoryFuncObject)
+	rc := newRecommendationController(mockRecommendationInputFactoryFuncObject)
+
+	/* Act */
+	actual := rc.GetRecommendations(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockRecommendationInputFactoryFuncObject.AssertNotCalled(t, "GetRecommendations", mock.Anything, mock.Anything)
+}
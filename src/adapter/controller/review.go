@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReviewRequestBody struct {
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+}
+
+type ReviewI interface {
+	GetStoreReviews(c echo.Context) error
+	GetUserReviews(c echo.Context) error
+	CreateReview(c echo.Context) error
+	UpdateReview(c echo.Context) error
+	DeleteReview(c echo.Context) error
+}
+
+type ReviewOutputFactory func(echo.Context) port.ReviewOutputPort
+type ReviewInputFactory func(port.ReviewRepository, port.ReviewOutputPort) port.ReviewInputPort
+type ReviewRepositoryFactory func(gateway.ReviewDriver, gateway.GoogleMapDriver) port.ReviewRepository
+type ReviewDriverFactory gateway.ReviewDriver
+
+type ReviewController struct {
+	reviewDriverFactory     ReviewDriverFactory
+	googleMapDriverFactory  GoogleMapDriverFactory
+	reviewOutputFactory     ReviewOutputFactory
+	reviewInputFactory      ReviewInputFactory
+	reviewRepositoryFactory ReviewRepositoryFactory
+}
+
+func NewReviewController(
+	reviewDriverFactory ReviewDriverFactory,
+	googleMapDriverFactory GoogleMapDriverFactory,
+	reviewOutputFactory ReviewOutputFactory,
+	reviewInputFactory ReviewInputFactory,
+	reviewRepositoryFactory ReviewRepositoryFactory,
+) ReviewI {
+	return &ReviewController{
+		reviewDriverFactory:     reviewDriverFactory,
+		googleMapDriverFactory:  googleMapDriverFactory,
+		reviewOutputFactory:     reviewOutputFactory,
+		reviewInputFactory:      reviewInputFactory,
+		reviewRepositoryFactory: reviewRepositoryFactory,
+	}
+}
+
+func (rc *ReviewController) GetStoreReviews(c echo.Context) error {
+	storeId := c.Param("id")
+	if !placeIdRegex.MatchString(storeId) {
+		return c.JSON(http.StatusBadRequest, "id is invalid")
+	}
+	page, err := model.NewPageRequest(c.QueryParam("cursor"), c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return rc.newReviewInputPort(c).GetStoreReviews(storeId, page)
+}
+
+func (rc *ReviewController) GetUserReviews(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	page, err := model.NewPageRequest(c.QueryParam("cursor"), c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return rc.newReviewInputPort(c).GetUserReviews(userId, page)
+}
+
+func (rc *ReviewController) CreateReview(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	storeId := c.Param("id")
+	if !placeIdRegex.MatchString(storeId) {
+		return c.JSON(http.StatusBadRequest, "id is invalid")
+	}
+	review, err := bindReviewRequest(c, storeId, userId)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return rc.newReviewInputPort(c).CreateReview(review)
+}
+
+func (rc *ReviewController) UpdateReview(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	storeId := c.Param("storeId")
+	if !placeIdRegex.MatchString(storeId) {
+		return c.JSON(http.StatusBadRequest, "storeId is invalid")
+	}
+	review, err := bindReviewRequest(c, storeId, userId)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return rc.newReviewInputPort(c).UpdateReview(review)
+}
+
+func (rc *ReviewController) DeleteReview(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	storeId := c.Param("storeId")
+	if !placeIdRegex.MatchString(storeId) {
+		return c.JSON(http.StatusBadRequest, "storeId is invalid")
+	}
+	return rc.newReviewInputPort(c).DeleteReview(storeId, userId)
+}
+
+func bindReviewRequest(c echo.Context, storeId string, userId string) (*model.Review, error) {
+	var r ReviewRequestBody
+	if err := c.Bind(&r); err != nil {
+		return nil, err
+	}
+	return model.NewReview(storeId, userId, r.Rating, r.Text)
+}
+
+func (rc *ReviewController) newReviewInputPort(c echo.Context) port.ReviewInputPort {
+	reviewOutputPort := rc.reviewOutputFactory(c)
+	reviewDriver := rc.reviewDriverFactory
+	googleMapDriver := rc.googleMapDriverFactory
+	reviewRepository := rc.reviewRepositoryFactory(reviewDriver, googleMapDriver)
+	return rc.reviewInputFactory(reviewRepository, reviewOutputPort)
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"bytes"
+	"clean-storemap-api/src/adapter/gateway"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewInputFactoryFuncObject struct {
+	mock.Mock
+}
+
+func (m *MockReviewInputFactoryFuncObject) GetStoreReviews(storeId string, page *model.PageRequest) error {
+	args := m.Called(storeId, page)
+	return args.Error(0)
+}
+
+func (m *MockReviewInputFactoryFuncObject) GetUserReviews(userId string, page *model.PageRequest) error {
+	args := m.Called(userId, page)
+	return args.Error(0)
+}
+
+func (m *MockReviewInputFactoryFuncObject) CreateReview(review *model.Review) error {
+	args := m.Called(review)
+	return args.Error(0)
+}
+
+func (m *MockReviewInputFactoryFuncObject) UpdateReview(review *model.Review) error {
+	args := m.Called(review)
+	return args.Error(0)
+}
+
+func (m *MockReviewInputFactoryFuncObject) DeleteReview(storeId string, userId string) error {
+	args := m.Called(storeId, userId)
+	return args.Error(0)
+}
+
+// InputPortのモックを返すReviewControllerを作成する
+func newReviewController(input port.ReviewInputPort) *ReviewController {
+	return &ReviewController{
+		reviewOutputFactory: func(echo.Context) port.ReviewOutputPort {
+			return nil
+		},
+		reviewRepositoryFactory: func(gateway.ReviewDriver, gateway.GoogleMapDriver) port.ReviewRepository {
+			return nil
+		},
+		reviewInputFactory: func(port.ReviewRepository, port.ReviewOutputPort) port.ReviewInputPort {
+			return input
+		},
+	}
+}
+
+func TestCreateReview(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"rating": 4, "text": "おいしい"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.SetPath("/stores/:id/reviews")
+	c.SetParamNames("id")
+	c.SetParamValues("Id001")
+	c.Set("userId", "id_1")
+
+	mockReviewInputFactoryFuncObject := new(MockReviewInputFactoryFuncObject)
+	mockReviewInputFactoryFuncObject.On("CreateReview", mock.Anything).Return(nil)
+	rc := newReviewController(mockReviewInputFactoryFuncObject)
+
+	/* Act */
+	actual := rc.CreateReview(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockReviewInputFactoryFuncObject.AssertCalled(t, "CreateReview", &model.Review{StoreId: "Id001", UserId: "id_1", Rating: 4, Text: "おいしい"})
+}
+
+func TestCreateReviewWithInvalidRating(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"rating": 6}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.SetPath("/stores/:id/reviews")
+	c.SetParamNames("id")
+	c.SetParamValues("Id001")
+	c.Set("userId", "id_1")
+
+	mockReviewInputFactoryFuncObject := new(MockReviewInputFactoryFuncObject)
+	rc := newReviewController(mockReviewInputFactoryFuncObject)
+
+	/* Act */
+	actual := rc.CreateReview(c)
+
+	/* Assert */
+	// 1~5以外の評価は400を返すこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockReviewInputFactoryFuncObject.AssertNotCalled(t, "CreateReview", mock.Anything)
+}
+
+func TestGetStoreReviewsWithInvalidLimit(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/?limit=abc", nil)
+	c.SetRequest(req)
+	c.SetPath("/stores/:id/reviews")
+	c.SetParamNames("id")
+	c.SetParamValues("Id001")
+
+	mockReviewInputFactoryFuncObject := new(MockReviewInputFactoryFuncObject)
+	rc := newReviewController(mockReviewInputFactoryFuncObject)
+
+	/* Act */
+	actual := rc.GetStoreReviews(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockReviewInputFactoryFuncObject.AssertNotCalled(t, "GetStoreReviews", mock.Anything, mock.Anything)
+}
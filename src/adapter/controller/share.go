@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"clean-storemap-api/src/adapter/gateway"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ShareI interface {
+	CreateShareLink(c echo.Context) error
+	GetShareLinks(c echo.Context) error
+	RevokeShareLink(c echo.Context) error
+	GetSharedFavoriteStores(c echo.Context) error
+}
+
+type ShareOutputFactory func(echo.Context) port.ShareOutputPort
+type ShareInputFactory func(port.ShareRepository, port.ShareOutputPort) port.ShareInputPort
+type ShareRepositoryFactory func(gateway.ShareDriver) port.ShareRepository
+type ShareDriverFactory gateway.ShareDriver
+
+type ShareController struct {
+	shareDriverFactory     ShareDriverFactory
+	shareOutputFactory     ShareOutputFactory
+	shareInputFactory      ShareInputFactory
+	shareRepositoryFactory ShareRepositoryFactory
+}
+
+func NewShareController(
+	shareDriverFactory ShareDriverFactory,
+	shareOutputFactory ShareOutputFactory,
+	shareInputFactory ShareInputFactory,
+	shareRepositoryFactory ShareRepositoryFactory,
+) ShareI {
+	return &ShareController{
+		shareDriverFactory:     shareDriverFactory,
+		shareOutputFactory:     shareOutputFactory,
+		shareInputFactory:      shareInputFactory,
+		shareRepositoryFactory: shareRepositoryFactory,
+	}
+}
+
+func (sc *ShareController) CreateShareLink(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	return sc.newShareInputPort(c).CreateShareLink(userId)
+}
+
+func (sc *ShareController) GetShareLinks(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	return sc.newShareInputPort(c).GetShareLinks(userId)
+}
+
+func (sc *ShareController) RevokeShareLink(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	id := c.Param("id")
+	if !listIdRegex.MatchString(id) {
+		return c.JSON(http.StatusBadRequest, "id is invalid")
+	}
+	return sc.newShareInputPort(c).RevokeShareLink(id, userId)
+}
+
+// ログインせずに閲覧できる。トークンはハッシュにして照合するため形式は問わない
+func (sc *ShareController) GetSharedFavoriteStores(c echo.Context) error {
+	return sc.newShareInputPort(c).GetSharedFavoriteStores(c.Param("token"))
+}
+
+func (sc *ShareController) newShareInputPort(c echo.Context) port.ShareInputPort {
+	shareOutputPort := sc.shareOutputFactory(c)
+	shareDriver := sc.shareDriverFactory
+	shareRepository := sc.shareRepositoryFactory(shareDriver)
+	return sc.shareInputFactory(shareRepository, shareOutputPort)
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"clean-storemap-api/src/adapter/gateway"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockShareInputFactoryFuncObject struct {
+	mock.Mock
+}
+
+func (m *MockShareInputFactoryFuncObject) CreateShareLink(userId string) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *MockShareInputFactoryFuncObject) GetShareLinks(userId string) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *MockShareInputFactoryFuncObject) RevokeShareLink(id string, userId string) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
+}
+
+func (m *MockShareInputFactoryFuncObject) GetSharedFavoriteStores(token string) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+// InputPortのモックを返すShareControllerを作成する
+func newShareController(input port.ShareInputPort) *ShareController {
+	return &ShareController{
+		shareOutputFactory: func(echo.Context) port.ShareOutputPort {
+			return nil
+		},
+		shareRepositoryFactory: func(gateway.ShareDriver) port.ShareRepository {
+			return nil
+		},
+		shareInputFactory: func(port.ShareRepository, port.ShareOutputPort) port.ShareInputPort {
+			return input
+		},
+	}
+}
+
+func TestCreateShareLink(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	c.Set("userId", "id_1")
+
+	mockShareInputFactoryFuncObject := new(MockShareInputFactoryFuncObject)
+	mockShareInputFactoryFuncObject.On("CreateShareLink", mock.Anything).Return(nil)
+	sc := newShareController(mockShareInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.CreateShareLink(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockShareInputFactoryFuncObject.AssertCalled(t, "CreateShareLink", "id_1")
+}
+
+func TestRevokeShareLink(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	c.Set("userId", "id_1")
+	c.SetParamNames("id")
+	c.SetParamValues("0b6e5a4c-5a1d-4f57-9d3e-2f8c1a7b9e10")
+
+	mockShareInputFactoryFuncObject := new(MockShareInputFactoryFuncObject)
+	mockShareInputFactoryFuncObject.On("RevokeShareLink", mock.Anything, mock.Anything).Return(nil)
+	sc := newShareController(mockShareInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.RevokeShareLink(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockShareInputFactoryFuncObject.AssertCalled(t, "RevokeShareLink", "0b6e5a4c-5a1d-4f57-9d3e-2f8c1a7b9e10", "id_1")
+}
+
+func TestRevokeShareLinkWithInvalidId(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.Set("userId", "id_1")
+	c.SetParamNames("id")
+	c.SetParamValues("invalid")
+
+	mockShareInputFactoryFuncObject := new(MockShareInputFactoryFuncObject)
+	sc := newShareController(mockShareInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.RevokeShareLink(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockShareInputFactoryFuncObject.AssertNotCalled(t, "RevokeShareLink", mock.Anything, mock.Anything)
+}
+
+func TestGetSharedFavoriteStores(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	c.SetParamNames("token")
+	c.SetParamValues("token_1")
+
+	mockShareInputFactoryFuncObject := new(MockShareInputFactoryFuncObject)
+	mockShareInputFactoryFuncObject.On("GetSharedFavoriteStores", mock.Anything).Return(nil)
+	sc := newShareController(mockShareInputFactoryFuncObject)
+
+	/* Act */
+	actual := sc.GetSharedFavoriteStores(c)
+
+	/* Assert */
+	// ログインしていなくても取得できること
+	assert.NoError(t, actual)
+	mockShareInputFactoryFuncObject.AssertCalled(t, "GetSharedFavoriteStores", "token_1")
+}
@@ -4,26 +4,68 @@ import (
 	"clean-storemap-api/src/adapter/gateway"
 	model "clean-storemap-api/src/entity"
 	"clean-storemap-api/src/usecase/port"
+	"errors"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"gopkg.in/go-playground/validator.v9"
 )
 
 type StoreRequestBody struct {
-	StoreId             string `json:"storeId" validate:"required"`
-	StoreName           string `json:"storeName" validate:"required"`
-	RegularOpeningHours string `json:"regularOpeningHours"`
-	PriceLevel          string `json:"priceLevel"`
-	Latitude            string `json:"latitude" validate:"required"`
-	Longitude           string `json:"longitude" validate:"required"`
+	StoreId             string                   `json:"storeId" validate:"required"`
+	StoreName           string                   `json:"storeName" validate:"required"`
+	RegularOpeningHours string                   `json:"regularOpeningHours"`
+	OpeningHours        *OpeningHoursRequestBody `json:"openingHours"`
+	PriceLevel          string                   `json:"priceLevel"`
+	Latitude            string                   `json:"latitude" validate:"required"`
+	Longitude           string                   `json:"longitude" validate:"required"`
+	Note                string                   `json:"note"` // お気に入り登録時のみ使用する
+	Tags                []string                 `json:"tags"`
 }
 
+// お気に入りのメモとタグを置き換える
+type FavoriteRequestBody struct {
+	Note string   `json:"note"`
+	Tags []string `json:"tags"`
+}
+
+// Places APIのregularOpeningHours.periodsと同じ形式で受け取る
+type OpeningHoursRequestBody struct {
+	Periods []struct {
+		Open struct {
+			Day    int `json:"day"`
+			Hour   int `json:"hour"`
+			Minute int `json:"minute"`
+		} `json:"open"`
+		Close *struct {
+			Day    int `json:"day"`
+			Hour   int `json:"hour"`
+			Minute int `json:"minute"`
+		} `json:"close"`
+	} `json:"periods"`
+	UtcOffsetMinutes int `json:"utcOffsetMinutes"`
+}
+
+var placeIdRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
 type StoreI interface {
 	GetStores(c echo.Context) error
+	GetStoresInBoundingBox(c echo.Context) error
+	GetStoreClusters(c echo.Context) error
 	GetNearStores(c echo.Context) error
+	SearchStores(c echo.Context) error
+	GetStore(c echo.Context) error
 	GetFavoriteStores(c echo.Context) error
 	SaveFavoriteStore(c echo.Context) error
+	UpdateFavoriteStore(c echo.Context) error
+	DeleteFavoriteStore(c echo.Context) error
+	GetFavoriteTags(c echo.Context) error
+	ExportFavoriteStores(c echo.Context) error
+	ImportFavoriteStores(c echo.Context) error
 	GetTopFavoriteStores(c echo.Context) error
 }
 
@@ -39,6 +81,7 @@ type StoreController struct {
 	storeOutputFactory     StoreOutputFactory
 	storeInputFactory      StoreInputFactory
 	storeRepositoryFactory StoreRepositoryFactory
+	priceRanges            model.PriceRanges // minPrice・maxPriceを価格帯に対応させる金額の範囲(nilの場合は既定値)
 }
 
 func NewStoreController(
@@ -47,6 +90,7 @@ func NewStoreController(
 	storeOutputFactory StoreOutputFactory,
 	storeInputFactory StoreInputFactory,
 	storeRepositoryFactory StoreRepositoryFactory,
+	priceRanges model.PriceRanges,
 ) StoreI {
 	return &StoreController{
 		storeDriverFactory:     storeDriverFactory,
@@ -54,6 +98,7 @@ func NewStoreController(
 		storeOutputFactory:     storeOutputFactory,
 		storeInputFactory:      storeInputFactory,
 		storeRepositoryFactory: storeRepositoryFactory,
+		priceRanges:            priceRanges,
 	}
 }
 
@@ -61,8 +106,87 @@ func (sc *StoreController) GetStores(c echo.Context) error {
 	return sc.newStoreInputPort(c).GetStores()
 }
 
+// bboxは"西端の経度,南端の緯度,東端の経度,北端の緯度"。西端が東端より大きい場合は日付変更線をまたぐ範囲とする
+func (sc *StoreController) GetStoresInBoundingBox(c echo.Context) error {
+	if c.QueryParam("bbox") == "" {
+		return c.JSON(http.StatusBadRequest, "bbox is required")
+	}
+	box, err := model.ParseBoundingBox(c.QueryParam("bbox"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return sc.newStoreInputPort(c).GetStoresInBoundingBox(box)
+}
+
+// bboxはGetStoresInBoundingBoxと同じ形式。zoomは地図のズームレベル(0~22)で、大きいほど細かくまとめる
+func (sc *StoreController) GetStoreClusters(c echo.Context) error {
+	if c.QueryParam("bbox") == "" {
+		return c.JSON(http.StatusBadRequest, "bbox is required")
+	}
+	box, err := model.ParseBoundingBox(c.QueryParam("bbox"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	zoom, err := model.ParseZoom(c.QueryParam("zoom"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return sc.newStoreInputPort(c).GetStoreClusters(box, zoom)
+}
+
 func (sc *StoreController) GetNearStores(c echo.Context) error {
-	return sc.newStoreInputPort(c).GetNearStores()
+	// 緯度経度を省略した場合はサーバの位置情報を中心として検索する
+	area, err := model.NewSearchArea(c.QueryParam("latitude"), c.QueryParam("longitude"), c.QueryParam("radius"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	// originを省略した場合は検索の中心からの距離とする
+	var center *model.Coordinate
+	if area.HasCenter() {
+		coordinate, _ := area.Center.Coordinate()
+		center = &coordinate
+	}
+	query, err := sc.newStoreQuery(c, center)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	// 前回のレスポンスのnextCursorを渡すと続きのページを取得する
+	page, err := model.NewPageRequest(c.QueryParam("cursor"), c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return sc.newStoreInputPort(c).GetNearStores(area, query, page)
+}
+
+func (sc *StoreController) SearchStores(c echo.Context) error {
+	// 緯度経度を指定した場合はその周辺の店舗を優先して返す
+	bias, err := model.NewSearchArea(c.QueryParam("latitude"), c.QueryParam("longitude"), c.QueryParam("radius"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	search, err := model.NewStoreSearch(
+		c.QueryParam("q"),
+		bias,
+		splitQueryParam(c.QueryParam("priceLevels")),
+		splitQueryParam(c.QueryParam("types")),
+	)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	page, err := model.NewPageRequest(c.QueryParam("cursor"), c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return sc.newStoreInputPort(c).SearchStores(search, page)
+}
+
+func (sc *StoreController) GetStore(c echo.Context) error {
+	id := c.Param("id")
+	// Googleのplace idは英数字と-_のみで構成される
+	if !placeIdRegex.MatchString(id) {
+		return c.JSON(http.StatusBadRequest, "id is invalid")
+	}
+	return sc.newStoreInputPort(c).GetStore(id)
 }
 
 func (sc *StoreController) GetFavoriteStores(c echo.Context) error {
@@ -70,31 +194,189 @@ func (sc *StoreController) GetFavoriteStores(c echo.Context) error {
 	if userId == "" {
 		return c.JSON(http.StatusBadRequest, "user_id is required")
 	}
-	return sc.newStoreInputPort(c).GetFavoriteStores(userId)
+	query, err := sc.newStoreQuery(c, nil)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	query.Tag = strings.TrimSpace(c.QueryParam("tag"))
+	return sc.newStoreInputPort(c).GetFavoriteStores(userId, query)
+}
+
+// formatはcsv・gpx・kmlのいずれか(省略した場合はcsv)
+func (sc *StoreController) ExportFavoriteStores(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	format, err := model.NewExportFormat(c.QueryParam("format"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return sc.newStoreInputPort(c).ExportFavoriteStores(userId, format)
 }
 
 func (sc *StoreController) SaveFavoriteStore(c echo.Context) error {
-	var s StoreRequestBody
 	userId := c.Get("userId").(string)
 	if userId == "" {
 		return c.JSON(http.StatusBadRequest, "user_id is required")
 	}
+	store, status, err := bindStoreRequest(c)
+	if err != nil {
+		return c.JSON(status, err.Error())
+	}
+	return sc.newStoreInputPort(c).SaveFavoriteStore(store, userId)
+}
 
+func (sc *StoreController) UpdateFavoriteStore(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	storeId := c.Param("storeId")
+	if !placeIdRegex.MatchString(storeId) {
+		return c.JSON(http.StatusBadRequest, "storeId is invalid")
+	}
+	var f FavoriteRequestBody
+	if err := c.Bind(&f); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	favorite, err := model.NewFavorite(f.Note, f.Tags)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return sc.newStoreInputPort(c).UpdateFavoriteStore(storeId, userId, favorite)
+}
+
+func (sc *StoreController) DeleteFavoriteStore(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	storeId := c.Param("storeId")
+	if !placeIdRegex.MatchString(storeId) {
+		return c.JSON(http.StatusBadRequest, "storeId is invalid")
+	}
+	return sc.newStoreInputPort(c).DeleteFavoriteStore(storeId, userId)
+}
+
+// prefixで始まるタグを入力補完の候補として返す。prefixが空の場合はよく使うタグを返す
+func (sc *StoreController) GetFavoriteTags(c echo.Context) error {
+	userId := c.Get("userId").(string)
+	if userId == "" {
+		return c.JSON(http.StatusBadRequest, "user_id is required")
+	}
+	return sc.newStoreInputPort(c).GetFavoriteTags(userId, c.QueryParam("prefix"))
+}
+
+// window=day/week/month/allで集計する期間(省略時は1週間)、window=customとfrom・toで任意の期間を指定する。limitで件数を指定する。
+// age=20で20代、minSex・maxSex・minGender・maxGenderで性別と性自認の範囲のユーザーに絞り込んで集計する
+func (sc *StoreController) GetTopFavoriteStores(c echo.Context) error {
+	query, err := sc.newStoreQuery(c, nil)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	segment, err := model.NewSegment(c.QueryParam("age"), c.QueryParam("minSex"), c.QueryParam("maxSex"), c.QueryParam("minGender"), c.QueryParam("maxGender"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	ranking, err := model.NewRanking(c.QueryParam("window"), c.QueryParam("from"), c.QueryParam("to"), c.QueryParam("limit"), segment, time.Now())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	return sc.newStoreInputPort(c).GetTopFavoriteStores(query, ranking)
+}
+
+// openNow=trueで現在営業中、openAt=RFC3339形式の時刻でその時刻に営業中の店舗に絞り込む。
+// origin=緯度,経度で各店舗までの距離を返し、sort=distanceで近い順に並べる(originがなければdefaultOriginを使う)。
+// minPrice・maxPriceで一人あたりの金額(円)の範囲に価格帯が重なる店舗に絞り込む
+func (sc *StoreController) newStoreQuery(c echo.Context, defaultOrigin *model.Coordinate) (*model.StoreQuery, error) {
+	openNow := c.QueryParam("openNow")
+	openAt := c.QueryParam("openAt")
+	if openNow != "" && openAt != "" {
+		return nil, errors.New("openNow and openAt cannot be specified at the same time")
+	}
+
+	var at *time.Time
+	if openNow != "" {
+		isOpenNow, err := strconv.ParseBool(openNow)
+		if err != nil {
+			return nil, errors.New("openNow must be true or false")
+		}
+		if isOpenNow {
+			now := time.Now()
+			at = &now
+		}
+	}
+	if openAt != "" {
+		t, err := time.Parse(time.RFC3339, openAt)
+		if err != nil {
+			return nil, errors.New("openAt must be RFC3339 format")
+		}
+		at = &t
+	}
+
+	origin := defaultOrigin
+	if o := c.QueryParam("origin"); o != "" {
+		coordinate, err := model.ParseCoordinate(o)
+		if err != nil {
+			return nil, err
+		}
+		origin = coordinate
+	}
+	price, err := model.NewPriceFilter(c.QueryParam("minPrice"), c.QueryParam("maxPrice"), sc.priceRanges)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewStoreQuery(at, origin, c.QueryParam("sort"), price)
+}
+
+// StoreRequestBodyを受け取り店舗を作成する。失敗した場合は返すべきステータスコードとエラーを返す
+func bindStoreRequest(c echo.Context) (*model.Store, int, error) {
+	var s StoreRequestBody
 	if err := c.Bind(&s); err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return nil, http.StatusInternalServerError, err
 	}
 	if err := c.Validate(&s); err != nil {
-		return c.JSON(http.StatusInternalServerError, err.(validator.ValidationErrors).Error())
+		return nil, http.StatusInternalServerError, err.(validator.ValidationErrors)
 	}
 	store, err := model.NewStore(s.StoreId, s.StoreName, s.RegularOpeningHours, s.PriceLevel, s.Latitude, s.Longitude)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return nil, http.StatusInternalServerError, err
 	}
-	return sc.newStoreInputPort(c).SaveFavoriteStore(store, userId)
+	if s.OpeningHours != nil {
+		if store.OpeningHours, err = s.OpeningHours.toModel(); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	}
+	if store.Favorite, err = model.NewFavorite(s.Note, s.Tags); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return store, http.StatusOK, nil
 }
 
-func (sc *StoreController) GetTopFavoriteStores(c echo.Context) error {
-	return sc.newStoreInputPort(c).GetTopFavoriteStores()
+// カンマ区切りのクエリパラメータを分割する。空の要素は無視する
+func splitQueryParam(param string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(param, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func (oh *OpeningHoursRequestBody) toModel() (*model.OpeningHours, error) {
+	periods := make([]model.OpeningPeriod, 0)
+	for _, p := range oh.Periods {
+		period := model.OpeningPeriod{
+			Open: model.DayTime{Day: time.Weekday(p.Open.Day), Hour: p.Open.Hour, Minute: p.Open.Minute},
+		}
+		if p.Close != nil {
+			period.Close = &model.DayTime{Day: time.Weekday(p.Close.Day), Hour: p.Close.Hour, Minute: p.Close.Minute}
+		}
+		periods = append(periods, period)
+	}
+	return model.NewOpeningHours(periods, oh.UtcOffsetMinutes)
 }
 
 /* ここでpresenterにecho.Contextを渡している！起爆！！！（遅延） */
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"gopkg.in/go-playground/validator.v9"
 
@@ -39,9 +40,14 @@ type MockStoreInputFactoryFuncObject struct {
 	mock.Mock
 }
 
-func (m *MockStoreDriverFactory) GetStores() ([]*db.FavoriteStore, error) {
+func (m *MockStoreDriverFactory) GetStores() ([]*db.Store, error) {
 	args := m.Called()
-	return args.Get(0).([]*db.FavoriteStore), args.Error(1)
+	return args.Get(0).([]*db.Store), args.Error(1)
+}
+
+func (m *MockStoreDriverFactory) FindStoresInBoundingBox(filter *db.BoundingBoxFilter) ([]*db.Store, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*db.Store), args.Error(1)
 }
 
 func (m *MockStoreDriverFactory) FindFavorite(string, string) (*db.FavoriteStore, error) {
@@ -49,27 +55,102 @@ func (m *MockStoreDriverFactory) FindFavorite(string, string) (*db.FavoriteStore
 	return args.Get(0).(*db.FavoriteStore), args.Error(1)
 }
 
+func (m *MockStoreDriverFactory) FindStore(string) (*db.Store, error) {
+	args := m.Called()
+	return args.Get(0).(*db.Store), args.Error(1)
+}
+
 func (m *MockStoreDriverFactory) FindFavoriteByUser(string) ([]*db.FavoriteStore, error) {
 	args := m.Called()
 	return args.Get(0).([]*db.FavoriteStore), args.Error(1)
 }
 
-func (m *MockStoreDriverFactory) SaveStore(*db.FavoriteStore) error {
+func (m *MockStoreDriverFactory) SaveFavorite(*db.Store, *db.FavoriteStore) error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockStoreDriverFactory) GetTopStores() ([]*db.FavoriteStore, error) {
+func (m *MockStoreDriverFactory) SaveFavorites([]*db.Store, []*db.FavoriteStore) error {
 	args := m.Called()
-	return args.Get(0).([]*db.FavoriteStore), args.Error(1)
+	return args.Error(0)
+}
+
+func (m *MockStoreDriverFactory) UpdateFavorite(*db.FavoriteStore) (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreDriverFactory) DeleteFavorite(string, string) (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreDriverFactory) GetTopStores(*db.RankingFilter) ([]*db.RankedStore, error) {
+	args := m.Called()
+	return args.Get(0).([]*db.RankedStore), args.Error(1)
+}
+
+func (m *MockStoreDriverFactory) GetTopRatedStores(*db.RankingFilter) ([]*db.Store, error) {
+	args := m.Called()
+	return args.Get(0).([]*db.Store), args.Error(1)
+}
+
+func (m *MockStoreDriverFactory) GetReviewSummaries([]string) ([]*db.ReviewSummary, error) {
+	args := m.Called()
+	return args.Get(0).([]*db.ReviewSummary), args.Error(1)
+}
+
+func (m *MockStoreDriverFactory) GetVisitSummaries(string, []string) ([]*db.VisitSummary, error) {
+	args := m.Called()
+	return args.Get(0).([]*db.VisitSummary), args.Error(1)
 }
 
-func (m *MockGoogleMapDriverFactory) GetStores() ([]*api.Store, error) {
+func (m *MockGoogleMapDriverFactory) GetStores(api.Location, float64, int, bool) ([]*api.Store, error) {
 	args := m.Called()
 	return args.Get(0).([]*api.Store), args.Error(1)
 }
 
-func (m *MockStoreOutputFactoryFuncObject) OutputAllStores([]*model.Store) error {
+func (m *MockGoogleMapDriverFactory) GetCurrentLocation() (api.Location, error) {
+	args := m.Called()
+	return args.Get(0).(api.Location), args.Error(1)
+}
+
+func (m *MockGoogleMapDriverFactory) SearchStores(api.TextSearchQuery) ([]*api.Store, string, error) {
+	args := m.Called()
+	return args.Get(0).([]*api.Store), args.String(1), args.Error(2)
+}
+
+func (m *MockGoogleMapDriverFactory) GetStoreDetail(string) (*api.StoreDetail, error) {
+	args := m.Called()
+	return args.Get(0).(*api.StoreDetail), args.Error(1)
+}
+
+func (m *MockStoreOutputFactoryFuncObject) OutputAllStores([]*model.Store, *model.Cursor) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputFactoryFuncObject) OutputStoreClusters([]*model.StoreCluster) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputFactoryFuncObject) OutputFavoriteStoresExport([]*model.Store, model.ExportFormat) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputFactoryFuncObject) OutputImportResults([]*model.ImportResult) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputFactoryFuncObject) OutputStoreDetail(*model.StoreDetail) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputFactoryFuncObject) OutputStoreNotFound() error {
 	args := m.Called()
 	return args.Error(0)
 }
@@ -84,6 +165,26 @@ func (m *MockStoreOutputFactoryFuncObject) OutputAlreadyExistFavorite() error {
 	return args.Error(0)
 }
 
+func (m *MockStoreOutputFactoryFuncObject) OutputUpdateFavoriteStoreResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputFactoryFuncObject) OutputFavoriteTags([]string) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputFactoryFuncObject) OutputDeleteFavoriteStoreResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputFactoryFuncObject) OutputFavoriteNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
 func mockStoreOutputFactoryFunc(c echo.Context) port.StoreOutputPort {
 	return &MockStoreOutputFactoryFuncObject{}
 }
@@ -93,11 +194,31 @@ func (m *MockStoreRepositoryFactoryFuncObject) GetAll() ([]*model.Store, error)
 	return args.Get(0).([]*model.Store), args.Error(1)
 }
 
-func (m *MockStoreRepositoryFactoryFuncObject) GetNearStores() ([]*model.Store, error) {
-	args := m.Called()
+func (m *MockStoreRepositoryFactoryFuncObject) GetStoresInBoundingBox(box *model.BoundingBox, limit int) ([]*model.Store, error) {
+	args := m.Called(box, limit)
 	return args.Get(0).([]*model.Store), args.Error(1)
 }
 
+func (m *MockStoreRepositoryFactoryFuncObject) GetNearStores(area *model.SearchArea, page *model.PageRequest) ([]*model.Store, *model.Cursor, error) {
+	args := m.Called()
+	return args.Get(0).([]*model.Store), args.Get(1).(*model.Cursor), args.Error(2)
+}
+
+func (m *MockStoreRepositoryFactoryFuncObject) SearchStores(search *model.StoreSearch, page *model.PageRequest) ([]*model.Store, *model.Cursor, error) {
+	args := m.Called()
+	return args.Get(0).([]*model.Store), args.Get(1).(*model.Cursor), args.Error(2)
+}
+
+func (m *MockStoreRepositoryFactoryFuncObject) FindStoreSnapshot(id string) (*model.Store, error) {
+	args := m.Called()
+	return args.Get(0).(*model.Store), args.Error(1)
+}
+
+func (m *MockStoreRepositoryFactoryFuncObject) GetStoreDetail(id string) (*model.StoreDetail, error) {
+	args := m.Called()
+	return args.Get(0).(*model.StoreDetail), args.Error(1)
+}
+
 func (m *MockStoreRepositoryFactoryFuncObject) ExistFavorite(store *model.Store, userId string) (bool, error) {
 	args := m.Called(store, userId)
 	return args.Get(0).(bool), args.Error(1)
@@ -113,11 +234,36 @@ func (m *MockStoreRepositoryFactoryFuncObject) SaveFavoriteStore(store *model.St
 	return args.Error(0)
 }
 
-func (m *MockStoreRepositoryFactoryFuncObject) GetTopFavoriteStores() ([]*model.Store, error) {
+func (m *MockStoreRepositoryFactoryFuncObject) SaveFavoriteStores(stores []*model.Store, userId string) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreRepositoryFactoryFuncObject) UpdateFavoriteStore(storeId string, userId string, favorite *model.Favorite) (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreRepositoryFactoryFuncObject) DeleteFavoriteStore(storeId string, userId string) (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreRepositoryFactoryFuncObject) GetTopFavoriteStores(ranking *model.Ranking) ([]*model.Store, error) {
+	args := m.Called()
+	return args.Get(0).([]*model.Store), args.Error(1)
+}
+
+func (m *MockStoreRepositoryFactoryFuncObject) GetTopRatedStores(ranking *model.Ranking) ([]*model.Store, error) {
 	args := m.Called()
 	return args.Get(0).([]*model.Store), args.Error(1)
 }
 
+func (m *MockStoreRepositoryFactoryFuncObject) GetReviewSummaries(storeIds []string) (map[string]*model.ReviewSummary, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]*model.ReviewSummary), args.Error(1)
+}
+
 func mockStoreRepositoryFactoryFunc(storeDriver gateway.StoreDriver, googleMapDriver gateway.GoogleMapDriver) port.StoreRepository {
 	return &MockStoreRepositoryFactoryFuncObject{}
 }
@@ -127,13 +273,43 @@ func (m *MockStoreInputFactoryFuncObject) GetStores() error {
 	return args.Error(0)
 }
 
-func (m *MockStoreInputFactoryFuncObject) GetNearStores() error {
-	args := m.Called()
+func (m *MockStoreInputFactoryFuncObject) GetStoresInBoundingBox(box *model.BoundingBox) error {
+	args := m.Called(box)
 	return args.Error(0)
 }
 
-func (m *MockStoreInputFactoryFuncObject) GetFavoriteStores(userId string) error {
-	args := m.Called()
+func (m *MockStoreInputFactoryFuncObject) GetStoreClusters(box *model.BoundingBox, zoom int) error {
+	args := m.Called(box, zoom)
+	return args.Error(0)
+}
+
+func (m *MockStoreInputFactoryFuncObject) ExportFavoriteStores(userId string, format model.ExportFormat) error {
+	args := m.Called(userId, format)
+	return args.Error(0)
+}
+
+func (m *MockStoreInputFactoryFuncObject) ImportFavoriteStores(userId string, rows []*model.ImportRow) error {
+	args := m.Called(userId, rows)
+	return args.Error(0)
+}
+
+func (m *MockStoreInputFactoryFuncObject) GetNearStores(area *model.SearchArea, query *model.StoreQuery, page *model.PageRequest) error {
+	args := m.Called(area, query, page)
+	return args.Error(0)
+}
+
+func (m *MockStoreInputFactoryFuncObject) SearchStores(search *model.StoreSearch, page *model.PageRequest) error {
+	args := m.Called(search, page)
+	return args.Error(0)
+}
+
+func (m *MockStoreInputFactoryFuncObject) GetStore(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockStoreInputFactoryFuncObject) GetFavoriteStores(userId string, query *model.StoreQuery) error {
+	args := m.Called(userId, query)
 	return args.Error(0)
 }
 
@@ -142,8 +318,23 @@ func (m *MockStoreInputFactoryFuncObject) SaveFavoriteStore(store *model.Store,
 	return args.Error(0)
 }
 
-func (m *MockStoreInputFactoryFuncObject) GetTopFavoriteStores() error {
-	args := m.Called()
+func (m *MockStoreInputFactoryFuncObject) UpdateFavoriteStore(storeId string, userId string, favorite *model.Favorite) error {
+	args := m.Called(storeId, userId, favorite)
+	return args.Error(0)
+}
+
+func (m *MockStoreInputFactoryFuncObject) GetFavoriteTags(userId string, prefix string) error {
+	args := m.Called(userId, prefix)
+	return args.Error(0)
+}
+
+func (m *MockStoreInputFactoryFuncObject) DeleteFavoriteStore(storeId string, userId string) error {
+	args := m.Called(storeId, userId)
+	return args.Error(0)
+}
+
+func (m *MockStoreInputFactoryFuncObject) GetTopFavoriteStores(query *model.StoreQuery, ranking *model.Ranking) error {
+	args := m.Called(query, ranking)
 	return args.Error(0)
 }
 
@@ -175,8 +366,8 @@ func TestGetStores(t *testing.T) {
 
 	// Driverだけは実体が必要
 	mockStoreDriverFactory := new(MockStoreDriverFactory)
-	mockStoreDriverFactory.On("GetStores").Return([]*db.FavoriteStore{}, nil)
-	mockStoreDriverFactory.On("SaveStore").Return(nil)
+	mockStoreDriverFactory.On("GetStores").Return([]*db.Store{}, nil)
+	mockStoreDriverFactory.On("SaveFavorite").Return(nil)
 
 	// InputPortのGetStoresのモックを作成
 	sc := &StoreController{
@@ -204,138 +395,1053 @@ func TestGetStores(t *testing.T) {
 	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "GetStores", 1)
 }
 
-func TestGetNearStores(t *testing.T) {
+func TestGetStoresInBoundingBox(t *testing.T) {
 	/* Arrange */
-	c, rec := newRouter()
-	expected := errors.New("")
-
-	mockGoogleMapDriverFactory := new(MockGoogleMapDriverFactory)
-	mockGoogleMapDriverFactory.On("GetStores").Return(expected)
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores?bbox=139.5,35.6,139.9,35.8", nil)
+	c.SetRequest(req)
 
 	sc := &StoreController{
-		googleMapDriverFactory: mockGoogleMapDriverFactory,
 		storeOutputFactory:     mockStoreOutputFactoryFunc,
 		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
 	}
 
 	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
-	mockStoreInputFactoryFuncObject.On("GetNearStores").Return(expected)
+	mockStoreInputFactoryFuncObject.On("GetStoresInBoundingBox", mock.Anything).Return(nil)
 	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
 		return mockStoreInputFactoryFuncObject
 	}
 
 	/* Act */
-	actual := sc.GetNearStores(c)
+	actual := sc.GetStoresInBoundingBox(c)
 
 	/* Assert */
-	assert.Equal(t, expected, actual)
-	assert.Equal(t, http.StatusOK, rec.Code)
-	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "GetNearStores", 1)
+	assert.NoError(t, actual)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetStoresInBoundingBox", &model.BoundingBox{MinLng: 139.5, MinLat: 35.6, MaxLng: 139.9, MaxLat: 35.8})
 }
 
-func TestGetFavoriteStores(t *testing.T) {
+func TestGetStoresInBoundingBoxAcrossAntimeridian(t *testing.T) {
 	/* Arrange */
-	c, rec := newRouter()
-	expected := errors.New("")
-	userId := "id_1"
-	req := httptest.NewRequest(http.MethodGet, "/user/favorite-store", nil)
-	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-	c.Set("userId", userId)
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores?bbox=179.5,-17,-179.5,-16", nil)
 	c.SetRequest(req)
 
-	mockStoreDriverFactory := new(MockStoreDriverFactory)
-	mockStoreDriverFactory.On("FindStores").Return(&db.FavoriteStore{}, nil)
-
 	sc := &StoreController{
-		storeDriverFactory:     mockStoreDriverFactory,
 		storeOutputFactory:     mockStoreOutputFactoryFunc,
 		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
 	}
 
 	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
-	mockStoreInputFactoryFuncObject.On("GetFavoriteStores").Return(expected)
+	mockStoreInputFactoryFuncObject.On("GetStoresInBoundingBox", mock.Anything).Return(nil)
 	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
 		return mockStoreInputFactoryFuncObject
 	}
 
 	/* Act */
-	actual := sc.GetFavoriteStores(c)
+	actual := sc.GetStoresInBoundingBox(c)
 
 	/* Assert */
-	assert.Equal(t, expected, actual)
-	assert.Equal(t, http.StatusOK, rec.Code)
-	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "GetFavoriteStores", 1)
+	// 西端が東端より大きい範囲は日付変更線をまたぐものとして受け付けること
+	assert.NoError(t, actual)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetStoresInBoundingBox", mock.MatchedBy(func(box *model.BoundingBox) bool {
+		return box.CrossesAntimeridian() &&
+			box.Contains(model.Coordinate{Lat: -16.5, Lng: 179.9}) &&
+			box.Contains(model.Coordinate{Lat: -16.5, Lng: -179.9}) &&
+			!box.Contains(model.Coordinate{Lat: -16.5, Lng: 0})
+	}))
 }
 
-func TestFavoriteSaveStore(t *testing.T) {
+func TestGetStoresInBoundingBoxWithoutBbox(t *testing.T) {
 	/* Arrange */
-	var expected error = nil
 	c, rec := newRouter()
-	userId := "id_1"
-	reqBody := `{
-		"storeId": "Id001",
-		"storeName": "UEC cafe",
-		"regularOpeningHours": "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
-		"priceLevel": "PRICE_LEVEL_MODERATE",
-		"latitude": "35.713",
-		"longitude": "139.762"
-	}`
 
-	req := httptest.NewRequest(http.MethodPost, "/user/favorite-store", bytes.NewBufferString(reqBody))
-	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetStoresInBoundingBox(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetStoresInBoundingBox", mock.Anything)
+}
+
+func TestGetStoresInBoundingBoxWithInvalidLatitudeRange(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores?bbox=139.5,35.8,139.9,35.6", nil)
 	c.SetRequest(req)
-	c.Set("userId", userId)
 
-	mockStoreDriverFactory := new(MockStoreDriverFactory)
-	mockStoreDriverFactory.On("GetStores").Return([]*db.FavoriteStore{}, nil)
-	mockStoreDriverFactory.On("SaveStore").Return(nil)
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetStoresInBoundingBox(c)
+
+	/* Assert */
+	// 南端が北端より大きい範囲は受け付けないこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetStoresInBoundingBox", mock.Anything)
+}
+
+func TestGetStoreClusters(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/clusters?bbox=139.5,35.6,139.9,35.8&zoom=12", nil)
+	c.SetRequest(req)
 
 	sc := &StoreController{
-		storeDriverFactory:     mockStoreDriverFactory,
 		storeOutputFactory:     mockStoreOutputFactoryFunc,
 		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
 	}
 
 	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
-	mockStoreInputFactoryFuncObject.On("SaveFavoriteStore").Return(nil)
+	mockStoreInputFactoryFuncObject.On("GetStoreClusters", mock.Anything, mock.Anything).Return(nil)
 	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
 		return mockStoreInputFactoryFuncObject
 	}
 
 	/* Act */
-	actual := sc.SaveFavoriteStore(c)
+	actual := sc.GetStoreClusters(c)
 
 	/* Assert */
-	assert.Equal(t, expected, actual)
-	assert.Equal(t, http.StatusOK, rec.Code)
-	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "SaveFavoriteStore", 1)
+	assert.NoError(t, actual)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetStoreClusters", &model.BoundingBox{MinLng: 139.5, MinLat: 35.6, MaxLng: 139.9, MaxLat: 35.8}, 12)
 }
 
-func TestGetTopFavoriteStores(t *testing.T) {
+func TestGetStoreClustersWithoutZoom(t *testing.T) {
 	/* Arrange */
-	var expected error = nil
 	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/clusters?bbox=139.5,35.6,139.9,35.8", nil)
+	c.SetRequest(req)
 
-	mockStoreDriverFactory := new(MockStoreDriverFactory)
-	mockStoreDriverFactory.On("GetTopStores").Return([]*db.FavoriteStore{}, nil)
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetStoreClusters(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetStoreClusters", mock.Anything, mock.Anything)
+}
+
+func TestGetStoreClustersWithInvalidZoom(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/clusters?bbox=139.5,35.6,139.9,35.8&zoom=23", nil)
+	c.SetRequest(req)
 
 	sc := &StoreController{
-		storeDriverFactory:     mockStoreDriverFactory,
 		storeOutputFactory:     mockStoreOutputFactoryFunc,
 		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
 	}
 
 	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
-	mockStoreInputFactoryFuncObject.On("GetTopFavoriteStores").Return(nil)
 	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
 		return mockStoreInputFactoryFuncObject
 	}
 
 	/* Act */
-	actual := sc.GetTopFavoriteStores(c)
+	actual := sc.GetStoreClusters(c)
 
 	/* Assert */
-	assert.Equal(t, expected, actual)
-	assert.Equal(t, http.StatusOK, rec.Code)
-	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "GetTopFavoriteStores", 1)
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetStoreClusters", mock.Anything, mock.Anything)
+}
+
+func TestGetNearStores(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	expected := errors.New("")
+	cursor := (&model.Cursor{Offset: 5}).Encode()
+	req := httptest.NewRequest(http.MethodGet, "/stores/opening-hours?latitude=35.713&longitude=139.762&radius=300&limit=5&cursor="+cursor, nil)
+	c.SetRequest(req)
+	area := &model.SearchArea{Center: model.Location{Lat: "35.713", Lng: "139.762"}, Radius: 300.0}
+	page := &model.PageRequest{Cursor: model.Cursor{Offset: 5}, Limit: 5}
+	// originを省略した場合は検索の中心からの距離を返す
+	query := &model.StoreQuery{Origin: &model.Coordinate{Lat: 35.713, Lng: 139.762}}
+
+	mockGoogleMapDriverFactory := new(MockGoogleMapDriverFactory)
+	mockGoogleMapDriverFactory.On("GetStores").Return(expected)
+
+	sc := &StoreController{
+		googleMapDriverFactory: mockGoogleMapDriverFactory,
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetNearStores", area, query, page).Return(expected)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetNearStores(c)
+
+	/* Assert */
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "GetNearStores", 1)
+	// クエリパラメータの位置と半径、ページの指定がInputPortに渡されること
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetNearStores", area, query, page)
+}
+
+func TestGetNearStoresWithInvalidLocation(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/opening-hours?latitude=95.0&longitude=139.762", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetNearStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// 緯度が範囲外の場合は400を返しInputPortを呼ばないこと
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetNearStores", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSearchStores(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/search?q=cafe&latitude=35.713&longitude=139.762&priceLevels=PRICE_LEVEL_INEXPENSIVE,PRICE_LEVEL_MODERATE&types=cafe", nil)
+	c.SetRequest(req)
+	search := &model.StoreSearch{
+		Query:         "cafe",
+		Bias:          &model.SearchArea{Center: model.Location{Lat: "35.713", Lng: "139.762"}, Radius: model.DefaultSearchRadius},
+		PriceLevels:   []model.PriceLevel{"PRICE_LEVEL_INEXPENSIVE", "PRICE_LEVEL_MODERATE"},
+		IncludedTypes: []string{"cafe"},
+	}
+	page := &model.PageRequest{Limit: model.DefaultPageSize}
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("SearchStores", search, page).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.SearchStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	// クエリパラメータから作成した検索条件がInputPortに渡されること
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "SearchStores", search, page)
+}
+
+func TestSearchStoresWithoutQuery(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/search?q=%20", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.SearchStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// キーワードが空の場合は400を返すこと
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "SearchStores", mock.Anything, mock.Anything)
+}
+
+func TestGetStore(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.SetParamNames("id")
+	c.SetParamValues("ChIJN1t_tDeuEmsRUsoyG83frY4")
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetStore", "ChIJN1t_tDeuEmsRUsoyG83frY4").Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetStore(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetStore", "ChIJN1t_tDeuEmsRUsoyG83frY4")
+}
+
+func TestGetStoreWithInvalidId(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.SetParamNames("id")
+	c.SetParamValues("../places")
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetStore(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// place idの形式でない場合は400を返すこと
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetStore", mock.Anything)
+}
+
+func TestGetFavoriteStores(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	expected := errors.New("")
+	userId := "id_1"
+	req := httptest.NewRequest(http.MethodGet, "/user/favorite-store", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.Set("userId", userId)
+	c.SetRequest(req)
+
+	mockStoreDriverFactory := new(MockStoreDriverFactory)
+	mockStoreDriverFactory.On("FindStores").Return(&db.FavoriteStore{}, nil)
+
+	sc := &StoreController{
+		storeDriverFactory:     mockStoreDriverFactory,
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetFavoriteStores", userId, &model.StoreQuery{}).Return(expected)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetFavoriteStores(c)
+
+	/* Assert */
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "GetFavoriteStores", 1)
+}
+
+func TestFavoriteSaveStore(t *testing.T) {
+	/* Arrange */
+	var expected error = nil
+	c, rec := newRouter()
+	userId := "id_1"
+	reqBody := `{
+		"storeId": "Id001",
+		"storeName": "UEC cafe",
+		"regularOpeningHours": "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
+		"priceLevel": "PRICE_LEVEL_MODERATE",
+		"latitude": "35.713",
+		"longitude": "139.762"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/user/favorite-store", bytes.NewBufferString(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.Set("userId", userId)
+
+	mockStoreDriverFactory := new(MockStoreDriverFactory)
+	mockStoreDriverFactory.On("GetStores").Return([]*db.Store{}, nil)
+	mockStoreDriverFactory.On("SaveFavorite").Return(nil)
+
+	sc := &StoreController{
+		storeDriverFactory:     mockStoreDriverFactory,
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("SaveFavoriteStore").Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.SaveFavoriteStore(c)
+
+	/* Assert */
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "SaveFavoriteStore", 1)
+}
+
+func TestUpdateFavoriteStore(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(`{"note": "窓際の席がおすすめ", "tags": [" cafe ", "Wi-Fi", "CAFE"]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.SetPath("/user/favorite-store/:storeId")
+	c.SetParamNames("storeId")
+	c.SetParamValues("Id001")
+	c.Set("userId", "id_1")
+
+	sc := &StoreController{
+		storeDriverFactory:     new(MockStoreDriverFactory),
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("UpdateFavoriteStore", "Id001", "id_1", mock.Anything).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.UpdateFavoriteStore(c)
+
+	/* Assert */
+	// タグは前後の空白を除き、大文字小文字の違いだけの重複はまとめること
+	assert.NoError(t, actual)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "UpdateFavoriteStore", "Id001", "id_1", &model.Favorite{Note: "窓際の席がおすすめ", Tags: []string{"cafe", "Wi-Fi"}})
+}
+
+func TestGetFavoriteStoresWithTag(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/favorite-store?tag=cafe", nil)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	sc := &StoreController{
+		storeDriverFactory:     new(MockStoreDriverFactory),
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetFavoriteStores", "id_1", mock.Anything).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetFavoriteStores", "id_1", &model.StoreQuery{Tag: "cafe"})
+}
+
+func TestExportFavoriteStores(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/favorite-store/export?format=kml", nil)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("ExportFavoriteStores", mock.Anything, mock.Anything).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.ExportFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "ExportFavoriteStores", "id_1", model.ExportFormatKml)
+}
+
+func TestExportFavoriteStoresWithoutFormat(t *testing.T) {
+	/* Arrange */
+	c, _ := newRouter()
+	c.Set("userId", "id_1")
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("ExportFavoriteStores", mock.Anything, mock.Anything).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.ExportFavoriteStores(c)
+
+	/* Assert */
+	// 形式を省略した場合はCSVで書き出すこと
+	assert.NoError(t, actual)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "ExportFavoriteStores", "id_1", model.ExportFormatCsv)
+}
+
+func TestExportFavoriteStoresWithInvalidFormat(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/favorite-store/export?format=xlsx", nil)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.ExportFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "ExportFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestDeleteFavoriteStore(t *testing.T) {
+	/* Arrange */
+	var expected error = nil
+	c, rec := newRouter()
+	userId := "id_1"
+	c.SetPath("/user/favorite-store/:storeId")
+	c.SetParamNames("storeId")
+	c.SetParamValues("Id001")
+	c.Set("userId", userId)
+
+	mockStoreDriverFactory := new(MockStoreDriverFactory)
+	sc := &StoreController{
+		storeDriverFactory:     mockStoreDriverFactory,
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("DeleteFavoriteStore", "Id001", userId).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.DeleteFavoriteStore(c)
+
+	/* Assert */
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "DeleteFavoriteStore", "Id001", userId)
+}
+
+func TestDeleteFavoriteStoreWithInvalidStoreId(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.SetPath("/user/favorite-store/:storeId")
+	c.SetParamNames("storeId")
+	c.SetParamValues("Id001;drop")
+	c.Set("userId", "id_1")
+
+	mockStoreDriverFactory := new(MockStoreDriverFactory)
+	sc := &StoreController{
+		storeDriverFactory:     mockStoreDriverFactory,
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.DeleteFavoriteStore(c)
+
+	/* Assert */
+	// 不正なstoreIdはInputPortを呼ばずに400を返すこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "DeleteFavoriteStore", mock.Anything, mock.Anything)
+}
+
+func TestGetTopFavoriteStores(t *testing.T) {
+	/* Arrange */
+	var expected error = nil
+	c, rec := newRouter()
+
+	mockStoreDriverFactory := new(MockStoreDriverFactory)
+	mockStoreDriverFactory.On("GetTopStores").Return([]*db.RankedStore{}, nil)
+
+	sc := &StoreController{
+		storeDriverFactory:     mockStoreDriverFactory,
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetTopFavoriteStores", &model.StoreQuery{}, mock.Anything).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "GetTopFavoriteStores", 1)
+}
+
+func TestGetTopFavoriteStoresOpenAt(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?openAt=2024-10-05T12:00:00%2B09:00", nil)
+	c.SetRequest(req)
+	openAt := time.Date(2024, 10, 5, 12, 0, 0, 0, time.FixedZone("", 9*60*60))
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetTopFavoriteStores", mock.MatchedBy(func(query *model.StoreQuery) bool {
+		return query.OpenAt != nil && query.OpenAt.Equal(openAt)
+	}), mock.Anything).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	// openAtの時刻が絞り込み条件としてInputPortに渡されること
+	mockStoreInputFactoryFuncObject.AssertNumberOfCalls(t, "GetTopFavoriteStores", 1)
+}
+
+func TestGetTopFavoriteStoresWithOpenNowAndOpenAt(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?openNow=true&openAt=2024-10-05T12:00:00%2B09:00", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// openNowとopenAtを同時に指定した場合は400を返すこと
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestGetFavoriteStoresSortByDistance(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/favorite-store?origin=35.713,139.762&sort=distance", nil)
+	c.SetRequest(req)
+	c.Set("userId", "Id001")
+	query := &model.StoreQuery{Origin: &model.Coordinate{Lat: 35.713, Lng: 139.762}, Sort: model.SortByDistance}
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetFavoriteStores", "Id001", query).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	// originと並び順がInputPortに渡されること
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetFavoriteStores", "Id001", query)
+}
+
+func TestGetTopFavoriteStoresSortByDistanceWithoutOrigin(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?sort=distance", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// 起点がわからないため距離順にできず400を返すこと
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestGetTopFavoriteStoresWithInvalidOrigin(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?origin=135.713,139.762", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// 緯度が範囲外の場合は400を返すこと
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestFavoriteSaveStoreWithInvalidPriceLevel(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	reqBody := `{
+		"storeId": "Id001",
+		"storeName": "UEC cafe",
+		"priceLevel": "PRICE_LEVEL_CHEAP",
+		"latitude": "35.713",
+		"longitude": "139.762"
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/user/favorite-store", bytes.NewBufferString(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.SetRequest(req)
+	c.Set("userId", "id_1")
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.SaveFavoriteStore(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// 未知の価格帯は保存しないこと
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "SaveFavoriteStore")
+}
+
+func TestGetFavoriteStoresWithPriceRange(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/user/favorite-store?minPrice=1000&maxPrice=3000", nil)
+	c.SetRequest(req)
+	c.Set("userId", "Id001")
+	ranges := model.PriceRanges{model.PriceLevelModerate: {Min: 1500, Max: 2500}}
+	query := &model.StoreQuery{Price: &model.PriceFilter{Min: 1000, Max: 3000, Ranges: ranges}}
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+		priceRanges:            ranges,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetFavoriteStores", "Id001", query).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	// 金額の範囲と設定された価格帯の範囲がInputPortに渡されること
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetFavoriteStores", "Id001", query)
+}
+
+func TestGetTopFavoriteStoresWithInvalidPriceRange(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?minPrice=3000&maxPrice=1000", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// minPriceがmaxPriceより大きい場合は400を返すこと
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestGetTopFavoriteStoresWithWindow(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?window=custom&from=2024-04-01T00:00:00%2B09:00&to=2024-04-30T23:59:59%2B09:00&limit=20", nil)
+	c.SetRequest(req)
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.FixedZone("", 9*60*60))
+	to := time.Date(2024, 4, 30, 23, 59, 59, 0, time.FixedZone("", 9*60*60))
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetTopFavoriteStores", mock.Anything, mock.Anything).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	// 指定した期間と件数がInputPortに渡されること
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetTopFavoriteStores", mock.Anything, mock.MatchedBy(func(ranking *model.Ranking) bool {
+		return ranking.Since.Equal(from) && ranking.Until.Equal(to) && ranking.Limit == 20
+	}))
+}
+
+func TestGetTopFavoriteStoresWithInvalidWindow(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?window=year", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestGetTopFavoriteStoresWithRangeAndWindow(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?window=week&from=2024-04-01T00:00:00Z", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	// from・toはwindow=custom以外と同時に指定できないこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestGetTopFavoriteStoresWithSegment(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?age=20&minGender=0.5&maxGender=1", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	mockStoreInputFactoryFuncObject.On("GetTopFavoriteStores", mock.Anything, mock.Anything).Return(nil)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	// 指定した属性がInputPortに渡され、指定しない項目では絞り込まないこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	age := 20
+	minGender := float32(0.5)
+	maxGender := float32(1)
+	mockStoreInputFactoryFuncObject.AssertCalled(t, "GetTopFavoriteStores", mock.Anything, mock.MatchedBy(func(ranking *model.Ranking) bool {
+		return assert.ObjectsAreEqual(&model.Segment{Age: &age, MinGender: &minGender, MaxGender: &maxGender}, ranking.Segment)
+	}))
+}
+
+func TestGetTopFavoriteStoresWithInvalidAge(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?age=25", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	// 年代でない年齢は400を返すこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestGetTopFavoriteStoresWithInvalidGenderRange(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	req := httptest.NewRequest(http.MethodGet, "/stores/favorite-ranking?minGender=0.5&maxGender=-0.5", nil)
+	c.SetRequest(req)
+
+	sc := &StoreController{
+		storeOutputFactory:     mockStoreOutputFactoryFunc,
+		storeRepositoryFactory: mockStoreRepositoryFactoryFunc,
+	}
+
+	mockStoreInputFactoryFuncObject := new(MockStoreInputFactoryFuncObject)
+	sc.storeInputFactory = func(repository port.StoreRepository, output port.StoreOutputPort) port.StoreInputPort {
+		return mockStoreInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := sc.GetTopFavoriteStores(c)
+
+	/* Assert */
+	// 最小が最大より大きい範囲は400を返すこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockStoreInputFactoryFuncObject.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything, mock.Anything)
 }
@@ -107,6 +107,19 @@ func (uc *UserController) UpdateUser(c echo.Context) error {
 		updateData["gender"] = requestBody["gender"]
 	}
 
+	// favoriteVisibility
+	if visibility, ok := requestBody["favoriteVisibility"]; ok {
+		v, ok := visibility.(string)
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "favoriteVisibility must be a string")
+		}
+		favoriteVisibility, err := model.NewVisibility(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		updateData["favorite_visibility"] = string(favoriteVisibility)
+	}
+
 	return uc.newUserInputPort(c).UpdateUser(id, updateData)
 }
 
@@ -205,6 +205,64 @@ func TestUpdateUser(t *testing.T) {
 	mockUserInputFactoryFuncObject.AssertNumberOfCalls(t, "UpdateUser", 1)
 }
 
+func TestUpdateUserWithFavoriteVisibility(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	reqBody := `{"favoriteVisibility":"followers"}`
+	req := httptest.NewRequest(http.MethodPut, "/user", bytes.NewBufferString(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.Set("userId", "id_1")
+	c.SetRequest(req)
+
+	uc := &UserController{
+		userDriverFactory:     new(MockUserDriverFactory),
+		userOutputFactory:     mockUserOutputFactoryFunc,
+		userRepositoryFactory: mockUserRepositoryFactoryFunc,
+	}
+	mockUserInputFactoryFuncObject := new(MockUserInputFactoryFuncObject)
+	mockUserInputFactoryFuncObject.On("UpdateUser").Return(nil)
+	uc.userInputFactory = func(repository port.UserRepository, output port.UserOutputPort) port.UserInputPort {
+		return mockUserInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := uc.UpdateUser(c)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUserInputFactoryFuncObject.AssertNumberOfCalls(t, "UpdateUser", 1)
+}
+
+func TestUpdateUserWithInvalidFavoriteVisibility(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	reqBody := `{"favoriteVisibility":"friends"}`
+	req := httptest.NewRequest(http.MethodPut, "/user", bytes.NewBufferString(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.Set("userId", "id_1")
+	c.SetRequest(req)
+
+	uc := &UserController{
+		userDriverFactory:     new(MockUserDriverFactory),
+		userOutputFactory:     mockUserOutputFactoryFunc,
+		userRepositoryFactory: mockUserRepositoryFactoryFunc,
+	}
+	mockUserInputFactoryFuncObject := new(MockUserInputFactoryFuncObject)
+	uc.userInputFactory = func(repository port.UserRepository, output port.UserOutputPort) port.UserInputPort {
+		return mockUserInputFactoryFuncObject
+	}
+
+	/* Act */
+	actual := uc.UpdateUser(c)
+
+	/* Assert */
+	// 公開範囲以外の値は更新しないこと
+	assert.NoError(t, actual)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUserInputFactoryFuncObject.AssertNotCalled(t, "UpdateUser")
+}
+
 func TestLoginUser(t *testing.T) {
 	/* Arrange */
 	c, rec := newRouter()
@@ -0,0 +1,78 @@
+package gateway
+
+import (
+	api "clean-storemap-api/src/driver/api"
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+)
+
+type CheckinGateway struct {
+	checkinDriver   CheckinDriver
+	googleMapDriver GoogleMapDriver
+}
+
+type CheckinDriver interface {
+	FindByUser(userId string, offset int, limit int) ([]*db.Checkin, error)
+	FindStore(storeId string) (*db.Store, error)
+	Create(store *db.Store, checkin *db.Checkin) error
+}
+
+func NewCheckinRepository(checkinDriver CheckinDriver, googleMapDriver GoogleMapDriver) port.CheckinRepository {
+	return &CheckinGateway{
+		checkinDriver:   checkinDriver,
+		googleMapDriver: googleMapDriver,
+	}
+}
+
+// 1件多く取得し、取得できた場合のみ続きのページがあるものとする
+func (cg *CheckinGateway) GetCheckins(userId string, page *model.PageRequest) ([]*model.Checkin, *model.Cursor, error) {
+	dbCheckins, err := cg.checkinDriver.FindByUser(userId, page.Cursor.Offset, page.Limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	var next *model.Cursor
+	if len(dbCheckins) > page.Limit {
+		dbCheckins = dbCheckins[:page.Limit]
+		next = &model.Cursor{Offset: page.Cursor.Offset + page.Limit}
+	}
+	checkins := make([]*model.Checkin, 0)
+	for _, v := range dbCheckins {
+		checkins = append(checkins, &model.Checkin{
+			StoreId:   v.StoreId,
+			StoreName: v.Store.Name,
+			UserId:    v.UserId,
+			VisitedAt: v.VisitedAt,
+			PartySize: v.PartySize,
+		})
+	}
+	return checkins, next, nil
+}
+
+// 保存済みの店舗はそのまま使い、未知の店舗はPlaces APIから取得する
+func (cg *CheckinGateway) FindStore(storeId string) (*model.Store, error) {
+	dbStore, err := cg.checkinDriver.FindStore(storeId)
+	if err != nil {
+		return nil, err
+	}
+	if dbStore != nil {
+		return toModelStore(dbStore), nil
+	}
+	detail, err := cg.googleMapDriver.GetStoreDetail(storeId)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil {
+		return nil, nil
+	}
+	return toModelStores([]*api.Store{&detail.Store})[0], nil
+}
+
+func (cg *CheckinGateway) CreateCheckin(store *model.Store, checkin *model.Checkin) error {
+	return cg.checkinDriver.Create(toDbStore(store), &db.Checkin{
+		UserId:    checkin.UserId,
+		StoreId:   checkin.StoreId,
+		VisitedAt: checkin.VisitedAt,
+		PartySize: checkin.PartySize,
+	})
+}
@@ -0,0 +1,96 @@
+package gateway
+
+import (
+	api "clean-storemap-api/src/driver/api"
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCheckinDriver struct {
+	mock.Mock
+}
+
+func (m *MockCheckinDriver) FindByUser(userId string, offset int, limit int) ([]*db.Checkin, error) {
+	args := m.Called(userId, offset, limit)
+	return args.Get(0).([]*db.Checkin), args.Error(1)
+}
+
+func (m *MockCheckinDriver) FindStore(storeId string) (*db.Store, error) {
+	args := m.Called(storeId)
+	return args.Get(0).(*db.Store), args.Error(1)
+}
+
+func (m *MockCheckinDriver) Create(store *db.Store, checkin *db.Checkin) error {
+	args := m.Called(store, checkin)
+	return args.Error(0)
+}
+
+func TestGetCheckins(t *testing.T) {
+	/* Arrange */
+	visitedAt := time.Date(2024, 5, 1, 3, 30, 0, 0, time.UTC)
+	mockCheckinDriver := new(MockCheckinDriver)
+	mockCheckinDriver.On("FindByUser", "user_1", 0, 2).Return([]*db.Checkin{
+		{Id: 2, UserId: "user_1", StoreId: "Id001", Store: db.Store{Name: "UEC cafe"}, VisitedAt: visitedAt, PartySize: 2},
+		{Id: 1, UserId: "user_1", StoreId: "Id002", Store: db.Store{Name: "UEC restaurant"}, VisitedAt: visitedAt.AddDate(0, 0, -1)},
+	}, nil)
+	cg := &CheckinGateway{checkinDriver: mockCheckinDriver}
+
+	/* Act */
+	checkins, next, err := cg.GetCheckins("user_1", &model.PageRequest{Limit: 1})
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.Checkin{
+		{StoreId: "Id001", StoreName: "UEC cafe", UserId: "user_1", VisitedAt: visitedAt, PartySize: 2},
+	}, checkins)
+	assert.Equal(t, &model.Cursor{Offset: 1}, next)
+}
+
+func TestFindStoreForCheckinFromPlaces(t *testing.T) {
+	/* Arrange */
+	mockCheckinDriver := new(MockCheckinDriver)
+	mockCheckinDriver.On("FindStore", "Id003").Return((*db.Store)(nil), nil)
+	mockGoogleMapRepository := new(MockGoogleMapRepository)
+	mockGoogleMapRepository.On("GetStoreDetail", "Id003").Return(&api.StoreDetail{
+		Store: api.Store{
+			Id:       "Id003",
+			Name:     "Hongo coffee",
+			Location: api.Location{Lat: 35.711, Lng: 139.761},
+			OpeningPeriods: []api.Period{
+				{Open: api.Point{Day: 1, Hour: 11}, Close: &api.Point{Day: 1, Hour: 14}},
+			},
+			UtcOffsetMinutes: 540,
+		},
+	}, nil)
+	cg := &CheckinGateway{checkinDriver: mockCheckinDriver, googleMapDriver: mockGoogleMapRepository}
+
+	/* Act */
+	store, err := cg.FindStore("Id003")
+
+	/* Assert */
+	// 保存されていない店舗はPlaces APIから営業時間と合わせて取得すること
+	assert.NoError(t, err)
+	assert.Equal(t, "Hongo coffee", store.Name)
+	assert.NotNil(t, store.OpeningHours)
+}
+
+func TestFindStoreForCheckinNotFound(t *testing.T) {
+	/* Arrange */
+	mockCheckinDriver := new(MockCheckinDriver)
+	mockCheckinDriver.On("FindStore", "Id999").Return((*db.Store)(nil), nil)
+	mockGoogleMapRepository := new(MockGoogleMapRepository)
+	mockGoogleMapRepository.On("GetStoreDetail", "Id999").Return((*api.StoreDetail)(nil), nil)
+	cg := &CheckinGateway{checkinDriver: mockCheckinDriver, googleMapDriver: mockGoogleMapRepository}
+
+	/* Act */
+	store, err := cg.FindStore("Id999")
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+}
@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+
+	"github.com/google/uuid"
+)
+
+type FavoriteListGateway struct {
+	favoriteListDriver FavoriteListDriver
+}
+
+type FavoriteListDriver interface {
+	FindListsByUser(userId string) ([]*db.FavoriteList, error)
+	FindList(listId string, userId string) (*db.FavoriteList, error)
+	CreateList(list *db.FavoriteList) error
+	UpdateList(list *db.FavoriteList) error
+	ReorderLists(lists []*db.FavoriteList) error
+	DeleteList(listId string, userId string) error
+	AddStore(store *db.Store, listStore *db.FavoriteListStore) error
+	DeleteStore(listId string, storeId string) (bool, error)
+}
+
+func NewFavoriteListRepository(favoriteListDriver FavoriteListDriver) port.FavoriteListRepository {
+	return &FavoriteListGateway{
+		favoriteListDriver: favoriteListDriver,
+	}
+}
+
+func (fg *FavoriteListGateway) GetLists(userId string) ([]*model.FavoriteList, error) {
+	dbLists, err := fg.favoriteListDriver.FindListsByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+	lists := make([]*model.FavoriteList, 0)
+	for _, v := range dbLists {
+		lists = append(lists, toModelFavoriteList(v))
+	}
+	return lists, nil
+}
+
+func (fg *FavoriteListGateway) FindList(listId string, userId string) (*model.FavoriteList, error) {
+	v, err := fg.favoriteListDriver.FindList(listId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return toModelFavoriteList(v), nil
+}
+
+func (fg *FavoriteListGateway) CreateList(list *model.FavoriteList, userId string) error {
+	dbList := &db.FavoriteList{
+		Id:          uuid.New().String(),
+		UserId:      userId,
+		Name:        list.Name,
+		Description: list.Description,
+	}
+	if err := fg.favoriteListDriver.CreateList(dbList); err != nil {
+		return err
+	}
+	list.Id = dbList.Id
+	list.Position = dbList.Position
+	return nil
+}
+
+func (fg *FavoriteListGateway) UpdateList(list *model.FavoriteList, userId string) error {
+	return fg.favoriteListDriver.UpdateList(&db.FavoriteList{
+		Id:          list.Id,
+		UserId:      userId,
+		Name:        list.Name,
+		Description: list.Description,
+	})
+}
+
+func (fg *FavoriteListGateway) ReorderLists(userId string, lists []*model.FavoriteList) error {
+	dbLists := make([]*db.FavoriteList, 0)
+	for _, l := range lists {
+		dbLists = append(dbLists, &db.FavoriteList{Id: l.Id, UserId: userId, Position: l.Position})
+	}
+	return fg.favoriteListDriver.ReorderLists(dbLists)
+}
+
+func (fg *FavoriteListGateway) DeleteList(listId string, userId string) error {
+	return fg.favoriteListDriver.DeleteList(listId, userId)
+}
+
+func (fg *FavoriteListGateway) AddStore(listId string, store *model.Store) error {
+	listStore := &db.FavoriteListStore{
+		FavoriteListId: listId,
+		StoreId:        store.Id,
+	}
+	return fg.favoriteListDriver.AddStore(toDbStore(store), listStore)
+}
+
+func (fg *FavoriteListGateway) RemoveStore(listId string, storeId string) (bool, error) {
+	return fg.favoriteListDriver.DeleteStore(listId, storeId)
+}
+
+func toModelFavoriteList(v *db.FavoriteList) *model.FavoriteList {
+	stores := make([]*model.Store, 0)
+	for _, s := range v.Stores {
+		stores = append(stores, toModelStore(&s.Store))
+	}
+	return &model.FavoriteList{
+		Id:          v.Id,
+		Name:        v.Name,
+		Description: v.Description,
+		Position:    v.Position,
+		Stores:      stores,
+	}
+}
@@ -0,0 +1,130 @@
+package gateway
+
+import (
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFavoriteListDriver struct {
+	mock.Mock
+}
+
+func (m *MockFavoriteListDriver) FindListsByUser(userId string) ([]*db.FavoriteList, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*db.FavoriteList), args.Error(1)
+}
+
+func (m *MockFavoriteListDriver) FindList(listId string, userId string) (*db.FavoriteList, error) {
+	args := m.Called(listId, userId)
+	return args.Get(0).(*db.FavoriteList), args.Error(1)
+}
+
+func (m *MockFavoriteListDriver) CreateList(list *db.FavoriteList) error {
+	args := m.Called(list)
+	// DBで末尾の位置が設定される
+	list.Position = 2
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListDriver) UpdateList(list *db.FavoriteList) error {
+	args := m.Called(list)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListDriver) ReorderLists(lists []*db.FavoriteList) error {
+	args := m.Called(lists)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListDriver) DeleteList(listId string, userId string) error {
+	args := m.Called(listId, userId)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListDriver) AddStore(store *db.Store, listStore *db.FavoriteListStore) error {
+	args := m.Called(store, listStore)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListDriver) DeleteStore(listId string, storeId string) (bool, error) {
+	args := m.Called(listId, storeId)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestGetLists(t *testing.T) {
+	/* Arrange */
+	dbStores, _ := makeDummyDbStores()
+	mockFavoriteListDriver := new(MockFavoriteListDriver)
+	mockFavoriteListDriver.On("FindListsByUser", "user_1").Return([]*db.FavoriteList{
+		{
+			Id:          "list_1",
+			UserId:      "user_1",
+			Name:        "Work lunch",
+			Description: "平日のお昼",
+			Position:    0,
+			Stores: []db.FavoriteListStore{
+				{FavoriteListId: "list_1", StoreId: "Id002", Store: *dbStores[1]},
+				{FavoriteListId: "list_1", StoreId: "Id001", Store: *dbStores[0]},
+			},
+		},
+	}, nil)
+	fg := &FavoriteListGateway{favoriteListDriver: mockFavoriteListDriver}
+
+	/* Act */
+	lists, err := fg.GetLists("user_1")
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Len(t, lists, 1)
+	assert.Equal(t, "Work lunch", lists[0].Name)
+	assert.Equal(t, "平日のお昼", lists[0].Description)
+	// 追加した順に店舗が返ること
+	assert.Equal(t, "Id002", lists[0].Stores[0].Id)
+	assert.Equal(t, model.PriceLevelInexpensive, lists[0].Stores[0].PriceLevel)
+	assert.Equal(t, "Id001", lists[0].Stores[1].Id)
+}
+
+func TestCreateList(t *testing.T) {
+	/* Arrange */
+	mockFavoriteListDriver := new(MockFavoriteListDriver)
+	mockFavoriteListDriver.On("CreateList", mock.MatchedBy(func(l *db.FavoriteList) bool {
+		return l.Id != "" && l.UserId == "user_1" && l.Name == "Date night"
+	})).Return(nil)
+	fg := &FavoriteListGateway{favoriteListDriver: mockFavoriteListDriver}
+	list := &model.FavoriteList{Name: "Date night"}
+
+	/* Act */
+	err := fg.CreateList(list, "user_1")
+
+	/* Assert */
+	// 採番されたidとDBで決まった位置が設定されること
+	assert.NoError(t, err)
+	assert.NotEmpty(t, list.Id)
+	assert.Equal(t, 2, list.Position)
+}
+
+func TestAddStoreToList(t *testing.T) {
+	/* Arrange */
+	mockFavoriteListDriver := new(MockFavoriteListDriver)
+	mockFavoriteListDriver.On("AddStore", mock.MatchedBy(func(s *db.Store) bool {
+		return s.Id == "Id001" && s.Name == "UEC cafe" && s.PriceLevel == "PRICE_LEVEL_MODERATE"
+	}), &db.FavoriteListStore{FavoriteListId: "list_1", StoreId: "Id001"}).Return(nil)
+	fg := &FavoriteListGateway{favoriteListDriver: mockFavoriteListDriver}
+	store := &model.Store{
+		Id:         "Id001",
+		Name:       "UEC cafe",
+		PriceLevel: model.PriceLevelModerate,
+		Location:   model.Location{Lat: "35.713", Lng: "139.762"},
+	}
+
+	/* Act */
+	err := fg.AddStore("list_1", store)
+
+	/* Assert */
+	assert.NoError(t, err)
+	mockFavoriteListDriver.AssertNumberOfCalls(t, "AddStore", 1)
+}
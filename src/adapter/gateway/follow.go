@@ -0,0 +1,106 @@
+package gateway
+
+import (
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+)
+
+type FollowGateway struct {
+	followDriver FollowDriver
+}
+
+type FollowDriver interface {
+	ExistUser(userId string) (bool, error)
+	ExistFollow(followerId string, followeeId string) (bool, error)
+	CreateFollow(follow *db.Follow) error
+	DeleteFollow(followerId string, followeeId string) (bool, error)
+	FindFollowing(userId string) ([]*db.User, error)
+	FindFollowers(userId string) ([]*db.User, error)
+	FindFeed(filter *db.FeedFilter) ([]*db.FavoriteStore, error)
+}
+
+func NewFollowRepository(followDriver FollowDriver) port.FollowRepository {
+	return &FollowGateway{
+		followDriver: followDriver,
+	}
+}
+
+func (fg *FollowGateway) ExistUser(userId string) (bool, error) {
+	return fg.followDriver.ExistUser(userId)
+}
+
+func (fg *FollowGateway) ExistFollow(userId string, followeeId string) (bool, error) {
+	return fg.followDriver.ExistFollow(userId, followeeId)
+}
+
+func (fg *FollowGateway) Follow(userId string, followeeId string) error {
+	return fg.followDriver.CreateFollow(&db.Follow{FollowerId: userId, FolloweeId: followeeId})
+}
+
+func (fg *FollowGateway) Unfollow(userId string, followeeId string) (bool, error) {
+	return fg.followDriver.DeleteFollow(userId, followeeId)
+}
+
+func (fg *FollowGateway) GetFollowing(userId string) ([]*model.User, error) {
+	dbUsers, err := fg.followDriver.FindFollowing(userId)
+	if err != nil {
+		return nil, err
+	}
+	return toModelPublicUsers(dbUsers), nil
+}
+
+func (fg *FollowGateway) GetFollowers(userId string) ([]*model.User, error) {
+	dbUsers, err := fg.followDriver.FindFollowers(userId)
+	if err != nil {
+		return nil, err
+	}
+	return toModelPublicUsers(dbUsers), nil
+}
+
+// 続きがあるか判定するため1件多く取得する
+func (fg *FollowGateway) GetFeed(userId string, page *model.FeedPageRequest) ([]*model.FeedItem, *model.FeedCursor, error) {
+	visibilities := make([]string, 0, len(model.FollowerVisibilities))
+	for _, v := range model.FollowerVisibilities {
+		visibilities = append(visibilities, string(v))
+	}
+	filter := &db.FeedFilter{
+		UserId:       userId,
+		Visibilities: visibilities,
+		Limit:        page.Limit + 1,
+	}
+	if page.Cursor != nil {
+		filter.Before = &db.FeedPosition{
+			CreatedAt: page.Cursor.FavoritedAt,
+			UserId:    page.Cursor.UserId,
+			StoreId:   page.Cursor.StoreId,
+		}
+	}
+	favorites, err := fg.followDriver.FindFeed(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	items := make([]*model.FeedItem, 0)
+	for _, v := range favorites[:min(page.Limit, len(favorites))] {
+		items = append(items, &model.FeedItem{
+			User:        model.User{Id: v.User.Id, Name: v.User.Name},
+			Store:       toModelStore(&v.Store),
+			FavoritedAt: v.CreatedAt,
+		})
+	}
+	var next *model.FeedCursor
+	if len(favorites) > page.Limit {
+		last := items[len(items)-1]
+		next = &model.FeedCursor{FavoritedAt: last.FavoritedAt, UserId: last.User.Id, StoreId: last.Store.Id}
+	}
+	return items, next, nil
+}
+
+// 他のユーザーに見せてよいidと名前のみを設定する
+func toModelPublicUsers(dbUsers []*db.User) []*model.User {
+	users := make([]*model.User, 0)
+	for _, v := range dbUsers {
+		users = append(users, &model.User{Id: v.Id, Name: v.Name})
+	}
+	return users
+}
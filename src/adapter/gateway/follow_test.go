@@ -0,0 +1,119 @@
+package gateway
+
+import (
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFollowDriver struct {
+	mock.Mock
+}
+
+func (m *MockFollowDriver) ExistUser(userId string) (bool, error) {
+	args := m.Called(userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowDriver) ExistFollow(followerId string, followeeId string) (bool, error) {
+	args := m.Called(followerId, followeeId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowDriver) CreateFollow(follow *db.Follow) error {
+	args := m.Called(follow)
+	return args.Error(0)
+}
+
+func (m *MockFollowDriver) DeleteFollow(followerId string, followeeId string) (bool, error) {
+	args := m.Called(followerId, followeeId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowDriver) FindFollowing(userId string) ([]*db.User, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*db.User), args.Error(1)
+}
+
+func (m *MockFollowDriver) FindFollowers(userId string) ([]*db.User, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*db.User), args.Error(1)
+}
+
+func (m *MockFollowDriver) FindFeed(filter *db.FeedFilter) ([]*db.FavoriteStore, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*db.FavoriteStore), args.Error(1)
+}
+
+func TestGetFollowing(t *testing.T) {
+	/* Arrange */
+	mockFollowDriver := new(MockFollowDriver)
+	mockFollowDriver.On("FindFollowing", "user_1").Return([]*db.User{
+		{Id: "user_2", Name: "Bob", Email: "bob@example.com", Age: 20, Sex: 1, Gender: 1},
+	}, nil)
+	fg := &FollowGateway{followDriver: mockFollowDriver}
+
+	/* Act */
+	users, err := fg.GetFollowing("user_1")
+
+	/* Assert */
+	// メールアドレスや年齢などを含めないこと
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.User{{Id: "user_2", Name: "Bob"}}, users)
+}
+
+func TestGetFeedForFollow(t *testing.T) {
+	/* Arrange */
+	dbStores, _ := makeDummyDbStores()
+	newer := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	older := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockFollowDriver := new(MockFollowDriver)
+	mockFollowDriver.On("FindFeed", mock.Anything).Return([]*db.FavoriteStore{
+		{UserId: "user_2", User: db.User{Id: "user_2", Name: "Bob", Email: "bob@example.com"}, StoreId: "Id001", Store: *dbStores[0], Note: "memo", CreatedAt: newer},
+		{UserId: "user_3", User: db.User{Id: "user_3", Name: "Carol"}, StoreId: "Id001", Store: *dbStores[0], CreatedAt: older},
+	}, nil)
+	fg := &FollowGateway{followDriver: mockFollowDriver}
+
+	/* Act */
+	items, next, err := fg.GetFeed("user_1", &model.FeedPageRequest{Limit: 1})
+
+	/* Assert */
+	assert.NoError(t, err)
+	// 続きがあるか判定するため1件多く取得し、フォロワーに公開しているお気に入りのみを対象とすること
+	mockFollowDriver.AssertCalled(t, "FindFeed", &db.FeedFilter{UserId: "user_1", Visibilities: []string{"public", "followers"}, Limit: 2})
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, model.User{Id: "user_2", Name: "Bob"}, items[0].User)
+		assert.Equal(t, "Id001", items[0].Store.Id)
+		assert.Nil(t, items[0].Store.Favorite)
+		assert.Equal(t, newer, items[0].FavoritedAt)
+	}
+	// 返した最後の項目の位置を次のカーソルとすること
+	assert.Equal(t, &model.FeedCursor{FavoritedAt: newer, UserId: "user_2", StoreId: "Id001"}, next)
+}
+
+func TestGetFeedWithCursor(t *testing.T) {
+	/* Arrange */
+	before := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	mockFollowDriver := new(MockFollowDriver)
+	mockFollowDriver.On("FindFeed", mock.Anything).Return([]*db.FavoriteStore{}, nil)
+	fg := &FollowGateway{followDriver: mockFollowDriver}
+
+	/* Act */
+	items, next, err := fg.GetFeed("user_1", &model.FeedPageRequest{
+		Cursor: &model.FeedCursor{FavoritedAt: before, UserId: "user_2", StoreId: "Id001"},
+		Limit:  10,
+	})
+
+	/* Assert */
+	// カーソルの位置より古い項目から取得し、続きがない場合はカーソルを返さないこと
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+	assert.Nil(t, next)
+	mockFollowDriver.AssertCalled(t, "FindFeed", mock.MatchedBy(func(filter *db.FeedFilter) bool {
+		return assert.ObjectsAreEqual(&db.FeedPosition{CreatedAt: before, UserId: "user_2", StoreId: "Id001"}, filter.Before)
+	}))
+}
@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+)
+
+type RecommendationGateway struct {
+	recommendationDriver RecommendationDriver
+}
+
+type RecommendationDriver interface {
+	FindSimilarUserFavorites(userId string) ([]*db.FavoriteStore, error)
+	FindStores(storeIds []string) ([]*db.Store, error)
+	GetTopStores(filter *db.RankingFilter) ([]*db.RankedStore, error)
+}
+
+func NewRecommendationRepository(recommendationDriver RecommendationDriver) port.RecommendationRepository {
+	return &RecommendationGateway{
+		recommendationDriver: recommendationDriver,
+	}
+}
+
+// お気に入りをユーザーごとにまとめる
+func (rg *RecommendationGateway) GetSimilarUserFavorites(userId string) ([]*model.UserFavorites, error) {
+	dbFavorites, err := rg.recommendationDriver.FindSimilarUserFavorites(userId)
+	if err != nil {
+		return nil, err
+	}
+	favorites := make([]*model.UserFavorites, 0)
+	byUser := make(map[string]*model.UserFavorites)
+	for _, v := range dbFavorites {
+		f, ok := byUser[v.UserId]
+		if !ok {
+			f = &model.UserFavorites{
+				User: model.User{
+					Id:     v.UserId,
+					Age:    v.User.Age,
+					Sex:    v.User.Sex,
+					Gender: v.User.Gender,
+				},
+				StoreIds: make([]string, 0),
+			}
+			byUser[v.UserId] = f
+			favorites = append(favorites, f)
+		}
+		f.StoreIds = append(f.StoreIds, v.StoreId)
+	}
+	return favorites, nil
+}
+
+func (rg *RecommendationGateway) GetStores(storeIds []string) ([]*model.Store, error) {
+	dbStores, err := rg.recommendationDriver.FindStores(storeIds)
+	if err != nil {
+		return nil, err
+	}
+	storesById := make(map[string]*db.Store)
+	for _, v := range dbStores {
+		storesById[v.Id] = v
+	}
+	stores := make([]*model.Store, 0)
+	for _, id := range storeIds {
+		if v, ok := storesById[id]; ok {
+			stores = append(stores, toModelStore(v))
+		}
+	}
+	return stores, nil
+}
+
+func (rg *RecommendationGateway) GetTopFavoriteStores(ranking *model.Ranking) ([]*model.Store, error) {
+	dbStores, err := rg.recommendationDriver.GetTopStores(toDbRankingFilter(ranking))
+	if err != nil {
+		return nil, err
+	}
+	return toModelRankedStores(dbStores), nil
+}
@@ -0,0 +1,72 @@
+package gateway
+
+import (
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecommendationDriver struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationDriver) FindSimilarUserFavorites(userId string) ([]*db.FavoriteStore, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*db.FavoriteStore), args.Error(1)
+}
+
+func (m *MockRecommendationDriver) FindStores(storeIds []string) ([]*db.Store, error) {
+	args := m.Called(storeIds)
+	return args.Get(0).([]*db.Store), args.Error(1)
+}
+
+func (m *MockRecommendationDriver) GetTopStores(filter *db.RankingFilter) ([]*db.RankedStore, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*db.RankedStore), args.Error(1)
+}
+
+func TestGetSimilarUserFavorites(t *testing.T) {
+	/* Arrange */
+	mockRecommendationDriver := new(MockRecommendationDriver)
+	mockRecommendationDriver.On("FindSimilarUserFavorites", "user_1").Return([]*db.FavoriteStore{
+		{UserId: "user_1", User: db.User{Id: "user_1", Age: 20, Sex: -1, Gender: -1}, StoreId: "Id001"},
+		{UserId: "user_2", User: db.User{Id: "user_2", Age: 30, Sex: 1, Gender: 0.5}, StoreId: "Id001"},
+		{UserId: "user_2", User: db.User{Id: "user_2", Age: 30, Sex: 1, Gender: 0.5}, StoreId: "Id002"},
+	}, nil)
+	rg := &RecommendationGateway{recommendationDriver: mockRecommendationDriver}
+
+	/* Act */
+	favorites, err := rg.GetSimilarUserFavorites("user_1")
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.UserFavorites{
+		{User: model.User{Id: "user_1", Age: 20, Sex: -1, Gender: -1}, StoreIds: []string{"Id001"}},
+		{User: model.User{Id: "user_2", Age: 30, Sex: 1, Gender: 0.5}, StoreIds: []string{"Id001", "Id002"}},
+	}, favorites)
+}
+
+func TestGetStoresForRecommendation(t *testing.T) {
+	/* Arrange */
+	mockRecommendationDriver := new(MockRecommendationDriver)
+	mockRecommendationDriver.On("FindStores", []string{"Id002", "Id003", "Id001"}).Return([]*db.Store{
+		{Id: "Id001", Name: "UEC cafe", Latitude: "35.6", Longitude: "139.5"},
+		{Id: "Id002", Name: "UEC restaurant", Latitude: "35.7", Longitude: "139.6"},
+	}, nil)
+	rg := &RecommendationGateway{recommendationDriver: mockRecommendationDriver}
+
+	/* Act */
+	stores, err := rg.GetStores([]string{"Id002", "Id003", "Id001"})
+
+	/* Assert */
+	// 指定した順に並び、保存されていない店舗は含まないこと
+	assert.NoError(t, err)
+	if assert.Len(t, stores, 2) {
+		assert.Equal(t, "Id002", stores[0].Id)
+		assert.Equal(t, "Id001", stores[1].Id)
+		assert.Equal(t, model.Location{Lat: "35.6", Lng: "139.5"}, stores[1].Location)
+	}
+}
@@ -0,0 +1,133 @@
+package gateway
+
+import (
+	api "clean-storemap-api/src/driver/api"
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+)
+
+type ReviewGateway struct {
+	reviewDriver    ReviewDriver
+	googleMapDriver GoogleMapDriver
+}
+
+type ReviewDriver interface {
+	FindByStore(storeId string, offset int, limit int) ([]*db.Review, error)
+	FindByUser(userId string, offset int, limit int) ([]*db.Review, error)
+	Find(storeId string, userId string) (*db.Review, error)
+	GetSummary(storeId string) (*db.ReviewSummary, error)
+	FindStore(storeId string) (*db.Store, error)
+	SaveStore(store *db.Store) error
+	Create(review *db.Review) error
+	Update(review *db.Review) (bool, error)
+	Delete(storeId string, userId string) (bool, error)
+}
+
+func NewReviewRepository(reviewDriver ReviewDriver, googleMapDriver GoogleMapDriver) port.ReviewRepository {
+	return &ReviewGateway{
+		reviewDriver:    reviewDriver,
+		googleMapDriver: googleMapDriver,
+	}
+}
+
+// 1件多く取得し、取得できた場合のみ続きのページがあるものとする
+func (rg *ReviewGateway) GetStoreReviews(storeId string, page *model.PageRequest) ([]*model.Review, *model.Cursor, error) {
+	dbReviews, err := rg.reviewDriver.FindByStore(storeId, page.Cursor.Offset, page.Limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviews, next := toModelReviews(dbReviews, page)
+	return reviews, next, nil
+}
+
+func (rg *ReviewGateway) GetUserReviews(userId string, page *model.PageRequest) ([]*model.Review, *model.Cursor, error) {
+	dbReviews, err := rg.reviewDriver.FindByUser(userId, page.Cursor.Offset, page.Limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviews, next := toModelReviews(dbReviews, page)
+	return reviews, next, nil
+}
+
+func (rg *ReviewGateway) GetReviewSummary(storeId string) (*model.ReviewSummary, error) {
+	v, err := rg.reviewDriver.GetSummary(storeId)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ReviewSummary{Average: v.Average, Count: v.Count}, nil
+}
+
+func (rg *ReviewGateway) ExistReview(storeId string, userId string) (bool, error) {
+	review, err := rg.reviewDriver.Find(storeId, userId)
+	if err != nil {
+		return false, err
+	}
+	return review != nil, nil
+}
+
+// お気に入り登録などで保存済みの店舗はそのまま使い、未知の店舗はPlaces APIから取得して保存する
+func (rg *ReviewGateway) SaveStore(storeId string) (bool, error) {
+	dbStore, err := rg.reviewDriver.FindStore(storeId)
+	if err != nil {
+		return false, err
+	}
+	if dbStore != nil {
+		return true, nil
+	}
+	detail, err := rg.googleMapDriver.GetStoreDetail(storeId)
+	if err != nil {
+		return false, err
+	}
+	if detail == nil {
+		return false, nil
+	}
+	store := toModelStores([]*api.Store{&detail.Store})[0]
+	if err := rg.reviewDriver.SaveStore(toDbStore(store)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (rg *ReviewGateway) CreateReview(review *model.Review) error {
+	return rg.reviewDriver.Create(toDbReview(review))
+}
+
+func (rg *ReviewGateway) UpdateReview(review *model.Review) (bool, error) {
+	return rg.reviewDriver.Update(toDbReview(review))
+}
+
+func (rg *ReviewGateway) DeleteReview(storeId string, userId string) (bool, error) {
+	return rg.reviewDriver.Delete(storeId, userId)
+}
+
+func toDbReview(review *model.Review) *db.Review {
+	return &db.Review{
+		StoreId: review.StoreId,
+		UserId:  review.UserId,
+		Rating:  review.Rating,
+		Text:    review.Text,
+	}
+}
+
+func toModelReviews(dbReviews []*db.Review, page *model.PageRequest) ([]*model.Review, *model.Cursor) {
+	var next *model.Cursor
+	if len(dbReviews) > page.Limit {
+		dbReviews = dbReviews[:page.Limit]
+		next = &model.Cursor{Offset: page.Cursor.Offset + page.Limit}
+	}
+	reviews := make([]*model.Review, 0)
+	for _, v := range dbReviews {
+		reviews = append(reviews, &model.Review{
+			StoreId:   v.StoreId,
+			StoreName: v.Store.Name,
+			UserId:    v.UserId,
+			UserName:  v.User.Name,
+			Rating:    v.Rating,
+			Text:      v.Text,
+			CreatedAt: v.CreatedAt,
+			UpdatedAt: v.UpdatedAt,
+		})
+	}
+	return reviews, next
+}
@@ -0,0 +1,140 @@
+package gateway
+
+import (
+	api "clean-storemap-api/src/driver/api"
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewDriver struct {
+	mock.Mock
+}
+
+func (m *MockReviewDriver) FindByStore(storeId string, offset int, limit int) ([]*db.Review, error) {
+	args := m.Called(storeId, offset, limit)
+	return args.Get(0).([]*db.Review), args.Error(1)
+}
+
+func (m *MockReviewDriver) FindByUser(userId string, offset int, limit int) ([]*db.Review, error) {
+	args := m.Called(userId, offset, limit)
+	return args.Get(0).([]*db.Review), args.Error(1)
+}
+
+func (m *MockReviewDriver) Find(storeId string, userId string) (*db.Review, error) {
+	args := m.Called(storeId, userId)
+	return args.Get(0).(*db.Review), args.Error(1)
+}
+
+func (m *MockReviewDriver) GetSummary(storeId string) (*db.ReviewSummary, error) {
+	args := m.Called(storeId)
+	return args.Get(0).(*db.ReviewSummary), args.Error(1)
+}
+
+func (m *MockReviewDriver) FindStore(storeId string) (*db.Store, error) {
+	args := m.Called(storeId)
+	return args.Get(0).(*db.Store), args.Error(1)
+}
+
+func (m *MockReviewDriver) SaveStore(store *db.Store) error {
+	args := m.Called(store)
+	return args.Error(0)
+}
+
+func (m *MockReviewDriver) Create(review *db.Review) error {
+	args := m.Called(review)
+	return args.Error(0)
+}
+
+func (m *MockReviewDriver) Update(review *db.Review) (bool, error) {
+	args := m.Called(review)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReviewDriver) Delete(storeId string, userId string) (bool, error) {
+	args := m.Called(storeId, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestGetStoreReviews(t *testing.T) {
+	/* Arrange */
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	dbReviews := []*db.Review{
+		{StoreId: "Id001", UserId: "user_1", User: db.User{Name: "太郎"}, Rating: 5, Text: "おいしい", CreatedAt: createdAt},
+		{StoreId: "Id001", UserId: "user_2", User: db.User{Name: "花子"}, Rating: 3, CreatedAt: createdAt},
+		{StoreId: "Id001", UserId: "user_3", User: db.User{Name: "次郎"}, Rating: 4, CreatedAt: createdAt},
+	}
+	mockReviewDriver := new(MockReviewDriver)
+	// 続きのページがあるか判断するため1件多く取得すること
+	mockReviewDriver.On("FindByStore", "Id001", 2, 3).Return(dbReviews, nil)
+	rg := &ReviewGateway{reviewDriver: mockReviewDriver}
+
+	/* Act */
+	reviews, next, err := rg.GetStoreReviews("Id001", &model.PageRequest{Cursor: model.Cursor{Offset: 2}, Limit: 2})
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 2)
+	assert.Equal(t, &model.Review{StoreId: "Id001", UserId: "user_1", UserName: "太郎", Rating: 5, Text: "おいしい", CreatedAt: createdAt}, reviews[0])
+	assert.Equal(t, &model.Cursor{Offset: 4}, next)
+}
+
+func TestGetStoreReviewsLastPage(t *testing.T) {
+	/* Arrange */
+	mockReviewDriver := new(MockReviewDriver)
+	mockReviewDriver.On("FindByStore", "Id001", 0, 11).Return([]*db.Review{{StoreId: "Id001", UserId: "user_1", Rating: 5}}, nil)
+	rg := &ReviewGateway{reviewDriver: mockReviewDriver}
+
+	/* Act */
+	reviews, next, err := rg.GetStoreReviews("Id001", &model.PageRequest{Limit: 10})
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 1)
+	assert.Nil(t, next)
+}
+
+func TestSaveStoreFromPlaces(t *testing.T) {
+	/* Arrange */
+	mockReviewDriver := new(MockReviewDriver)
+	mockReviewDriver.On("FindStore", "Id003").Return((*db.Store)(nil), nil)
+	mockReviewDriver.On("SaveStore", mock.MatchedBy(func(s *db.Store) bool {
+		return s.Id == "Id003" && s.Name == "Hongo coffee" && s.Latitude == "35.711000"
+	})).Return(nil)
+	mockGoogleMapRepository := new(MockGoogleMapRepository)
+	mockGoogleMapRepository.On("GetStoreDetail", "Id003").Return(&api.StoreDetail{
+		Store: api.Store{Id: "Id003", Name: "Hongo coffee", Location: api.Location{Lat: 35.711, Lng: 139.761}},
+	}, nil)
+	rg := &ReviewGateway{reviewDriver: mockReviewDriver, googleMapDriver: mockGoogleMapRepository}
+
+	/* Act */
+	found, err := rg.SaveStore("Id003")
+
+	/* Assert */
+	// 保存されていない店舗はPlaces APIから取得して保存すること
+	assert.NoError(t, err)
+	assert.True(t, found)
+	mockReviewDriver.AssertNumberOfCalls(t, "SaveStore", 1)
+}
+
+func TestSaveStoreAlreadySaved(t *testing.T) {
+	/* Arrange */
+	dbStores, _ := makeDummyDbStores()
+	mockReviewDriver := new(MockReviewDriver)
+	mockReviewDriver.On("FindStore", "Id001").Return(dbStores[0], nil)
+	mockGoogleMapRepository := new(MockGoogleMapRepository)
+	rg := &ReviewGateway{reviewDriver: mockReviewDriver, googleMapDriver: mockGoogleMapRepository}
+
+	/* Act */
+	found, err := rg.SaveStore("Id001")
+
+	/* Assert */
+	// 保存済みの店舗はPlaces APIを呼ばないこと
+	assert.NoError(t, err)
+	assert.True(t, found)
+	mockGoogleMapRepository.AssertNotCalled(t, "GetStoreDetail", mock.Anything)
+}
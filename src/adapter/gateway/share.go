@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+
+	"github.com/google/uuid"
+)
+
+type ShareGateway struct {
+	shareDriver ShareDriver
+}
+
+type ShareDriver interface {
+	CreateLink(link *db.ShareLink) error
+	FindLinksByUser(userId string) ([]*db.ShareLink, error)
+	DeleteLink(id string, userId string) (bool, error)
+	FindLinkByTokenHash(tokenHash string) (*db.ShareLink, error)
+	FindFavoriteByUser(userId string) ([]*db.FavoriteStore, error)
+}
+
+func NewShareRepository(shareDriver ShareDriver) port.ShareRepository {
+	return &ShareGateway{
+		shareDriver: shareDriver,
+	}
+}
+
+// トークンそのものは保存せずハッシュのみを保存する
+func (sg *ShareGateway) CreateShareLink(link *model.ShareLink, userId string) error {
+	dbLink := &db.ShareLink{
+		Id:        uuid.New().String(),
+		UserId:    userId,
+		TokenHash: model.HashShareToken(link.Token),
+	}
+	if err := sg.shareDriver.CreateLink(dbLink); err != nil {
+		return err
+	}
+	link.Id = dbLink.Id
+	link.CreatedAt = dbLink.CreatedAt
+	return nil
+}
+
+func (sg *ShareGateway) GetShareLinks(userId string) ([]*model.ShareLink, error) {
+	dbLinks, err := sg.shareDriver.FindLinksByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*model.ShareLink, 0)
+	for _, v := range dbLinks {
+		links = append(links, &model.ShareLink{Id: v.Id, CreatedAt: v.CreatedAt})
+	}
+	return links, nil
+}
+
+func (sg *ShareGateway) DeleteShareLink(id string, userId string) (bool, error) {
+	return sg.shareDriver.DeleteLink(id, userId)
+}
+
+func (sg *ShareGateway) FindSharedUserId(token string) (string, error) {
+	v, err := sg.shareDriver.FindLinkByTokenHash(model.HashShareToken(token))
+	if err != nil {
+		return "", err
+	}
+	if v == nil {
+		return "", nil
+	}
+	return v.UserId, nil
+}
+
+func (sg *ShareGateway) GetFavoriteStores(userId string) ([]*model.Store, error) {
+	favorites, err := sg.shareDriver.FindFavoriteByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+	stores := make([]*model.Store, 0)
+	for _, v := range favorites {
+		stores = append(stores, toModelStore(&v.Store))
+	}
+	return stores, nil
+}
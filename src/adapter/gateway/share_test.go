@@ -0,0 +1,107 @@
+package gateway
+
+import (
+	db "clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockShareDriver struct {
+	mock.Mock
+}
+
+func (m *MockShareDriver) CreateLink(link *db.ShareLink) error {
+	args := m.Called(link)
+	return args.Error(0)
+}
+
+func (m *MockShareDriver) FindLinksByUser(userId string) ([]*db.ShareLink, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*db.ShareLink), args.Error(1)
+}
+
+func (m *MockShareDriver) DeleteLink(id string, userId string) (bool, error) {
+	args := m.Called(id, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockShareDriver) FindLinkByTokenHash(tokenHash string) (*db.ShareLink, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(*db.ShareLink), args.Error(1)
+}
+
+func (m *MockShareDriver) FindFavoriteByUser(userId string) ([]*db.FavoriteStore, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*db.FavoriteStore), args.Error(1)
+}
+
+func TestCreateShareLink(t *testing.T) {
+	/* Arrange */
+	mockShareDriver := new(MockShareDriver)
+	mockShareDriver.On("CreateLink", mock.Anything).Return(nil)
+	sg := &ShareGateway{shareDriver: mockShareDriver}
+	link := &model.ShareLink{Token: "token_1"}
+
+	/* Act */
+	err := sg.CreateShareLink(link, "user_1")
+
+	/* Assert */
+	// トークンそのものではなくハッシュを保存すること
+	assert.NoError(t, err)
+	assert.Len(t, link.Id, 36)
+	mockShareDriver.AssertCalled(t, "CreateLink", mock.MatchedBy(func(v *db.ShareLink) bool {
+		return v.Id == link.Id && v.UserId == "user_1" && v.TokenHash == model.HashShareToken("token_1") && v.TokenHash != "token_1"
+	}))
+}
+
+func TestFindSharedUserId(t *testing.T) {
+	/* Arrange */
+	mockShareDriver := new(MockShareDriver)
+	mockShareDriver.On("FindLinkByTokenHash", model.HashShareToken("token_1")).Return(&db.ShareLink{Id: "link_1", UserId: "user_1"}, nil)
+	sg := &ShareGateway{shareDriver: mockShareDriver}
+
+	/* Act */
+	userId, err := sg.FindSharedUserId("token_1")
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Equal(t, "user_1", userId)
+}
+
+func TestFindSharedUserIdNotFound(t *testing.T) {
+	/* Arrange */
+	mockShareDriver := new(MockShareDriver)
+	mockShareDriver.On("FindLinkByTokenHash", mock.Anything).Return((*db.ShareLink)(nil), nil)
+	sg := &ShareGateway{shareDriver: mockShareDriver}
+
+	/* Act */
+	userId, err := sg.FindSharedUserId("token_1")
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Equal(t, "", userId)
+}
+
+func TestGetFavoriteStoresForShare(t *testing.T) {
+	/* Arrange */
+	dbStores, _ := makeDummyDbStores()
+	mockShareDriver := new(MockShareDriver)
+	mockShareDriver.On("FindFavoriteByUser", "user_1").Return([]*db.FavoriteStore{
+		{UserId: "user_1", StoreId: "Id001", Store: *dbStores[0], Note: "memo", Tags: "[\"date\"]"},
+	}, nil)
+	sg := &ShareGateway{shareDriver: mockShareDriver}
+
+	/* Act */
+	stores, err := sg.GetFavoriteStores("user_1")
+
+	/* Assert */
+	// メモやタグを含めないこと
+	assert.NoError(t, err)
+	if assert.Len(t, stores, 1) {
+		assert.Equal(t, "Id001", stores[0].Id)
+		assert.Nil(t, stores[0].Favorite)
+	}
+}
@@ -5,10 +5,11 @@ import (
 	db "clean-storemap-api/src/driver/db"
 	model "clean-storemap-api/src/entity"
 	"clean-storemap-api/src/usecase/port"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
-
-	"github.com/google/uuid"
+	"time"
 )
 
 // Dbの要素を構造体として渡す必要がある。
@@ -18,15 +19,26 @@ type StoreGateway struct {
 }
 
 type StoreDriver interface {
-	GetStores() ([]*db.FavoriteStore, error)
+	GetStores() ([]*db.Store, error)
+	FindStoresInBoundingBox(filter *db.BoundingBoxFilter) ([]*db.Store, error)
 	FindFavorite(storeId string, userId string) (*db.FavoriteStore, error)
+	FindStore(storeId string) (*db.Store, error)
 	FindFavoriteByUser(userId string) ([]*db.FavoriteStore, error)
-	SaveStore(*db.FavoriteStore) error
-	GetTopStores() ([]*db.FavoriteStore, error)
+	SaveFavorite(store *db.Store, favorite *db.FavoriteStore) error
+	SaveFavorites(stores []*db.Store, favorites []*db.FavoriteStore) error
+	UpdateFavorite(favorite *db.FavoriteStore) (bool, error)
+	DeleteFavorite(storeId string, userId string) (bool, error)
+	GetTopStores(filter *db.RankingFilter) ([]*db.RankedStore, error)
+	GetTopRatedStores(filter *db.RankingFilter) ([]*db.Store, error)
+	GetReviewSummaries(storeIds []string) ([]*db.ReviewSummary, error)
+	GetVisitSummaries(userId string, storeIds []string) ([]*db.VisitSummary, error)
 }
 
 type GoogleMapDriver interface {
-	GetStores() ([]*api.Store, error)
+	GetStores(location api.Location, radius float64, maxResultCount int, rankByDistance bool) ([]*api.Store, error)
+	GetCurrentLocation() (api.Location, error)
+	SearchStores(query api.TextSearchQuery) ([]*api.Store, string, error)
+	GetStoreDetail(id string) (*api.StoreDetail, error)
 }
 
 func NewStoreRepository(storeDriver StoreDriver, googleMapDriver GoogleMapDriver) port.StoreRepository {
@@ -43,39 +55,140 @@ func (sg *StoreGateway) GetAll() ([]*model.Store, error) {
 	}
 	stores := make([]*model.Store, 0)
 	for _, v := range dbStores {
-		stores = append(stores, &model.Store{
-			Id:                  v.Id,
-			Name:                v.StoreName,
-			RegularOpeningHours: v.RegularOpeningHours,
-			PriceLevel:          v.PriceLevel,
-			Location: model.Location{
-				Lat: v.Latitude,
-				Lng: v.Longitude,
-			},
-		})
+		stores = append(stores, toModelStore(v))
 	}
 	return stores, nil
 }
 
-func (sg *StoreGateway) GetNearStores() ([]*model.Store, error) {
-	apiStores, err := sg.googleMapDriver.GetStores()
+func (sg *StoreGateway) GetStoresInBoundingBox(box *model.BoundingBox, limit int) ([]*model.Store, error) {
+	dbStores, err := sg.storeDriver.FindStoresInBoundingBox(&db.BoundingBoxFilter{
+		MinLng: box.MinLng,
+		MinLat: box.MinLat,
+		MaxLng: box.MaxLng,
+		MaxLat: box.MaxLat,
+		Limit:  limit,
+	})
 	if err != nil {
 		return nil, err
 	}
+	stores := make([]*model.Store, 0)
+	for _, v := range dbStores {
+		stores = append(stores, toModelStore(v))
+	}
+	return stores, nil
+}
+
+// searchNearbyはページトークンを返さないため、先頭から必要な件数まで取得してoffsetの位置から切り出す
+func (sg *StoreGateway) GetNearStores(area *model.SearchArea, page *model.PageRequest) ([]*model.Store, *model.Cursor, error) {
+	location, err := sg.searchCenter(area)
+	if err != nil {
+		return nil, nil, err
+	}
+	offset := page.Cursor.Offset
+	count := min(offset+page.Limit, api.MaxResultCount)
+	apiStores, err := sg.googleMapDriver.GetStores(location, area.Radius, count, area.RankByDistance)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 要求した件数が全て返ってきた場合のみ続きが存在する可能性がある
+	var next *model.Cursor
+	if len(apiStores) == count && count < api.MaxResultCount {
+		next = &model.Cursor{Offset: count}
+	}
+	return toModelStores(apiStores[min(offset, len(apiStores)):]), next, nil
+}
+
+// searchTextはページトークンを返すため、カーソルにはページトークンを入れる
+func (sg *StoreGateway) SearchStores(search *model.StoreSearch, page *model.PageRequest) ([]*model.Store, *model.Cursor, error) {
+	query := api.TextSearchQuery{
+		TextQuery:     search.Query,
+		PriceLevels:   toApiPriceLevels(search.PriceLevels),
+		IncludedTypes: search.IncludedTypes,
+		PageSize:      page.Limit,
+		PageToken:     page.Cursor.PageToken,
+	}
+	if search.Bias != nil {
+		center, err := sg.searchCenter(search.Bias)
+		if err != nil {
+			return nil, nil, err
+		}
+		query.Bias = &api.Circle{Center: center, Radius: search.Bias.Radius}
+	}
+	apiStores, nextPageToken, err := sg.googleMapDriver.SearchStores(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	var next *model.Cursor
+	if nextPageToken != "" {
+		next = &model.Cursor{PageToken: nextPageToken}
+	}
+	return toModelStores(apiStores), next, nil
+}
+
+func (sg *StoreGateway) FindStoreSnapshot(id string) (*model.Store, error) {
+	v, err := sg.storeDriver.FindStore(id)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return toModelStore(v), nil
+}
+
+func (sg *StoreGateway) GetStoreDetail(id string) (*model.StoreDetail, error) {
+	v, err := sg.googleMapDriver.GetStoreDetail(id)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return &model.StoreDetail{
+		Store:           *toModelStores([]*api.Store{&v.Store})[0],
+		Address:         v.Address,
+		PhoneNumber:     v.PhoneNumber,
+		WebsiteUri:      v.WebsiteUri,
+		Rating:          v.Rating,
+		UserRatingCount: v.UserRatingCount,
+		Types:           v.Types,
+		BusinessStatus:  v.BusinessStatus,
+	}, nil
+}
+
+func toModelStores(apiStores []*api.Store) []*model.Store {
 	stores := make([]*model.Store, 0)
 	for _, v := range apiStores {
 		stores = append(stores, &model.Store{
 			Id:                  v.Id,
 			Name:                v.Name,
 			RegularOpeningHours: strings.Join(v.RegularOpeningHours, ", "),
-			PriceLevel:          v.PriceLevel,
+			PriceLevel:          toPriceLevel(v.PriceLevel),
 			Location: model.Location{
 				Lat: fmt.Sprintf("%f", v.Location.Lat),
 				Lng: fmt.Sprintf("%f", v.Location.Lng),
 			},
+			OpeningHours: toOpeningHours(v.OpeningPeriods, v.UtcOffsetMinutes),
 		})
 	}
-	return stores, nil
+	return stores
+}
+
+// 検索の中心が指定されていない場合に限りサーバの位置情報で代用する
+func (sg *StoreGateway) searchCenter(area *model.SearchArea) (api.Location, error) {
+	if !area.HasCenter() {
+		return sg.googleMapDriver.GetCurrentLocation()
+	}
+	// model.Location.Validateで検証済みのためエラーにはならない
+	lat, err := strconv.ParseFloat(area.Center.Lat, 64)
+	if err != nil {
+		return api.Location{}, err
+	}
+	lng, err := strconv.ParseFloat(area.Center.Lng, 64)
+	if err != nil {
+		return api.Location{}, err
+	}
+	return api.Location{Lat: lat, Lng: lng}, nil
 }
 
 func (sg *StoreGateway) ExistFavorite(store *model.Store, userId string) (bool, error) {
@@ -90,39 +203,42 @@ func (sg *StoreGateway) ExistFavorite(store *model.Store, userId string) (bool,
 }
 
 func (sg *StoreGateway) GetFavoriteStores(userId string) ([]*model.Store, error) {
-	dbStores, err := sg.storeDriver.FindFavoriteByUser(userId)
+	favorites, err := sg.storeDriver.FindFavoriteByUser(userId)
 	if err != nil {
 		return nil, err
 	}
 	stores := make([]*model.Store, 0)
-	for _, v := range dbStores {
-		stores = append(stores, &model.Store{
-			Id:                  v.StoreId,
-			Name:                v.StoreName,
-			RegularOpeningHours: v.RegularOpeningHours,
-			PriceLevel:          v.PriceLevel,
-			Location: model.Location{
-				Lat: v.Latitude,
-				Lng: v.Longitude,
-			},
-		})
+	ids := make([]string, 0)
+	for _, v := range favorites {
+		store := toModelStore(&v.Store)
+		store.Favorite = &model.Favorite{Note: v.Note, Tags: decodeTags(v.Tags), SavedAt: v.CreatedAt}
+		stores = append(stores, store)
+		ids = append(ids, v.StoreId)
+	}
+	if len(ids) == 0 {
+		return stores, nil
+	}
+	// 訪問の回数と最後に訪れた日時をお気に入りごとに設定する
+	visits, err := sg.storeDriver.GetVisitSummaries(userId, ids)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make(map[string]*db.VisitSummary)
+	for _, v := range visits {
+		summaries[v.StoreId] = v
+	}
+	for _, store := range stores {
+		if v, ok := summaries[store.Id]; ok {
+			lastVisitedAt := v.LastVisitedAt
+			store.Favorite.VisitCount = v.Count
+			store.Favorite.LastVisitedAt = &lastVisitedAt
+		}
 	}
 	return stores, nil
 }
 
 func (sg *StoreGateway) SaveFavoriteStore(store *model.Store, userId string) error {
-	dbStore := &db.FavoriteStore{
-		Id:                  uuid.New().String(),
-		UserId:              userId,
-		StoreId:             store.Id,
-		StoreName:           store.Name,
-		RegularOpeningHours: store.RegularOpeningHours,
-		PriceLevel:          store.PriceLevel,
-		Latitude:            store.Location.Lat,
-		Longitude:           store.Location.Lng,
-	}
-
-	err := sg.storeDriver.SaveStore(dbStore)
+	err := sg.storeDriver.SaveFavorite(toDbStore(store), toDbFavorite(store, userId))
 	if err != nil {
 		return err
 	}
@@ -130,24 +246,252 @@ func (sg *StoreGateway) SaveFavoriteStore(store *model.Store, userId string) err
 	return nil
 }
 
-func (sg *StoreGateway) GetTopFavoriteStores() ([]*model.Store, error) {
-	dbStores, err := sg.storeDriver.GetTopStores()
+func (sg *StoreGateway) SaveFavoriteStores(stores []*model.Store, userId string) error {
+	dbStores := make([]*db.Store, 0, len(stores))
+	favorites := make([]*db.FavoriteStore, 0, len(stores))
+	for _, s := range stores {
+		dbStores = append(dbStores, toDbStore(s))
+		favorites = append(favorites, toDbFavorite(s, userId))
+	}
+	return sg.storeDriver.SaveFavorites(dbStores, favorites)
+}
+
+// 元の登録日時がわかる場合は、その日時に登録したものとして保存する
+func toDbFavorite(store *model.Store, userId string) *db.FavoriteStore {
+	favorite := &db.FavoriteStore{
+		UserId:  userId,
+		StoreId: store.Id,
+	}
+	if store.Favorite != nil {
+		favorite.Note = store.Favorite.Note
+		favorite.Tags = encodeTags(store.Favorite.Tags)
+		favorite.CreatedAt = store.Favorite.SavedAt
+	}
+	return favorite
+}
+
+func (sg *StoreGateway) UpdateFavoriteStore(storeId string, userId string, favorite *model.Favorite) (bool, error) {
+	return sg.storeDriver.UpdateFavorite(&db.FavoriteStore{
+		UserId:  userId,
+		StoreId: storeId,
+		Note:    favorite.Note,
+		Tags:    encodeTags(favorite.Tags),
+	})
+}
+
+func (sg *StoreGateway) DeleteFavoriteStore(storeId string, userId string) (bool, error) {
+	return sg.storeDriver.DeleteFavorite(storeId, userId)
+}
+
+func (sg *StoreGateway) GetTopFavoriteStores(ranking *model.Ranking) ([]*model.Store, error) {
+	dbStores, err := sg.storeDriver.GetTopStores(toDbRankingFilter(ranking))
+	if err != nil {
+		return nil, err
+	}
+	return toModelRankedStores(dbStores), nil
+}
+
+func (sg *StoreGateway) GetTopRatedStores(ranking *model.Ranking) ([]*model.Store, error) {
+	dbStores, err := sg.storeDriver.GetTopRatedStores(toDbRankingFilter(ranking))
 	if err != nil {
 		return nil, err
 	}
 	stores := make([]*model.Store, 0)
 	for _, v := range dbStores {
-		stores = append(stores, &model.Store{
-			Id:                  v.StoreId,
-			Name:                v.StoreName,
-			RegularOpeningHours: v.RegularOpeningHours,
-			PriceLevel:          v.PriceLevel,
-			Location: model.Location{
-				Lat: v.Latitude,
-				Lng: v.Longitude,
-			},
-		})
+		stores = append(stores, toModelStore(v))
 	}
-
 	return stores, nil
 }
+
+func (sg *StoreGateway) GetReviewSummaries(storeIds []string) (map[string]*model.ReviewSummary, error) {
+	dbSummaries, err := sg.storeDriver.GetReviewSummaries(storeIds)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make(map[string]*model.ReviewSummary)
+	for _, v := range dbSummaries {
+		summaries[v.StoreId] = &model.ReviewSummary{Average: v.Average, Count: v.Count}
+	}
+	return summaries, nil
+}
+
+func toModelStore(v *db.Store) *model.Store {
+	return &model.Store{
+		Id:                  v.Id,
+		Name:                v.Name,
+		RegularOpeningHours: v.RegularOpeningHours,
+		PriceLevel:          toPriceLevel(v.PriceLevel),
+		Location: model.Location{
+			Lat: v.Latitude,
+			Lng: v.Longitude,
+		},
+		OpeningHours: decodeOpeningHours(v.OpeningPeriods, v.UtcOffsetMinutes),
+	}
+}
+
+// 集計したお気に入り登録数を店舗ごとに設定する
+func toModelRankedStores(dbStores []*db.RankedStore) []*model.Store {
+	stores := make([]*model.Store, 0)
+	for _, v := range dbStores {
+		store := toModelStore(&v.Store)
+		favoriteCount := v.FavoriteCount
+		store.FavoriteCount = &favoriteCount
+		stores = append(stores, store)
+	}
+	return stores
+}
+
+func toDbRankingFilter(ranking *model.Ranking) *db.RankingFilter {
+	filter := &db.RankingFilter{
+		Since:    ranking.Since,
+		Until:    ranking.Until,
+		Limit:    ranking.Limit,
+		MinUsers: ranking.MinUsers(),
+	}
+	if s := ranking.Segment; s != nil {
+		filter.Age = s.Age
+		filter.MinSex = s.MinSex
+		filter.MaxSex = s.MaxSex
+		filter.MinGender = s.MinGender
+		filter.MaxGender = s.MaxGender
+	}
+	return filter
+}
+
+func toDbStore(store *model.Store) *db.Store {
+	dbStore := &db.Store{
+		Id:                  store.Id,
+		Name:                store.Name,
+		RegularOpeningHours: store.RegularOpeningHours,
+		OpeningPeriods:      encodeOpeningPeriods(store.OpeningHours),
+		PriceLevel:          string(store.PriceLevel),
+		Latitude:            store.Location.Lat,
+		Longitude:           store.Location.Lng,
+	}
+	if store.OpeningHours != nil {
+		dbStore.UtcOffsetMinutes = store.OpeningHours.UtcOffsetMinutes
+	}
+	// 範囲検索用の数値の緯度経度。NewStoreで検証済みのため通常はエラーにならない
+	if c, err := store.Location.Coordinate(); err == nil {
+		dbStore.Lat = &c.Lat
+		dbStore.Lng = &c.Lng
+	}
+	return dbStore
+}
+
+// DBに営業時間の区間をJSONとして保存する際の形式
+type dayTimeJson struct {
+	Day    int `json:"day"`
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
+}
+
+type openingPeriodJson struct {
+	Open  dayTimeJson  `json:"open"`
+	Close *dayTimeJson `json:"close,omitempty"`
+}
+
+// Places APIやDBに保存された未知の価格帯は不明なものとして扱う
+func toPriceLevel(s string) model.PriceLevel {
+	level, err := model.ParsePriceLevel(s)
+	if err != nil {
+		return model.PriceLevelUnknown
+	}
+	return level
+}
+
+func toApiPriceLevels(levels []model.PriceLevel) []string {
+	priceLevels := make([]string, 0)
+	for _, level := range levels {
+		priceLevels = append(priceLevels, string(level))
+	}
+	return priceLevels
+}
+
+// Places APIの営業時間を変換する。区間が取得できなかった場合は営業時間が不明としてnilを返す
+func toOpeningHours(periods []api.Period, utcOffsetMinutes int) *model.OpeningHours {
+	modelPeriods := make([]model.OpeningPeriod, 0)
+	for _, p := range periods {
+		period := model.OpeningPeriod{
+			Open: model.DayTime{Day: time.Weekday(p.Open.Day), Hour: p.Open.Hour, Minute: p.Open.Minute},
+		}
+		if p.Close != nil {
+			period.Close = &model.DayTime{Day: time.Weekday(p.Close.Day), Hour: p.Close.Hour, Minute: p.Close.Minute}
+		}
+		modelPeriods = append(modelPeriods, period)
+	}
+	openingHours, err := model.NewOpeningHours(modelPeriods, utcOffsetMinutes)
+	if err != nil {
+		return nil
+	}
+	return openingHours
+}
+
+func encodeOpeningPeriods(openingHours *model.OpeningHours) string {
+	if openingHours == nil {
+		return ""
+	}
+	periods := make([]openingPeriodJson, 0)
+	for _, p := range openingHours.Periods {
+		period := openingPeriodJson{
+			Open: dayTimeJson{Day: int(p.Open.Day), Hour: p.Open.Hour, Minute: p.Open.Minute},
+		}
+		if p.Close != nil {
+			period.Close = &dayTimeJson{Day: int(p.Close.Day), Hour: p.Close.Hour, Minute: p.Close.Minute}
+		}
+		periods = append(periods, period)
+	}
+	encoded, err := json.Marshal(periods)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// タグがない場合は空文字として保存する
+func encodeTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	encoded, err := json.Marshal(tags)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+func decodeTags(encoded string) []string {
+	tags := make([]string, 0)
+	if encoded == "" {
+		return tags
+	}
+	if err := json.Unmarshal([]byte(encoded), &tags); err != nil {
+		return make([]string, 0)
+	}
+	return tags
+}
+
+func decodeOpeningHours(encoded string, utcOffsetMinutes int) *model.OpeningHours {
+	if encoded == "" {
+		return nil
+	}
+	var periods []openingPeriodJson
+	if err := json.Unmarshal([]byte(encoded), &periods); err != nil {
+		return nil
+	}
+	modelPeriods := make([]model.OpeningPeriod, 0)
+	for _, p := range periods {
+		period := model.OpeningPeriod{
+			Open: model.DayTime{Day: time.Weekday(p.Open.Day), Hour: p.Open.Hour, Minute: p.Open.Minute},
+		}
+		if p.Close != nil {
+			period.Close = &model.DayTime{Day: time.Weekday(p.Close.Day), Hour: p.Close.Hour, Minute: p.Close.Minute}
+		}
+		modelPeriods = append(modelPeriods, period)
+	}
+	openingHours, err := model.NewOpeningHours(modelPeriods, utcOffsetMinutes)
+	if err != nil {
+		return nil
+	}
+	return openingHours
+}
@@ -5,38 +5,25 @@ import (
 	db "clean-storemap-api/src/driver/db"
 	model "clean-storemap-api/src/entity"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-func makeDummyDbStores() ([]*db.FavoriteStore, error) {
-	dummyStores := make([]*db.FavoriteStore, 0)
-	dummyStores = append(dummyStores, &db.FavoriteStore{
-		Id:                  "id_1",
-		UserId:              "Id001",
-		StoreId:             "Id001",
-		StoreName:           "UEC cafe",
+func makeDummyDbStores() ([]*db.Store, error) {
+	dummyStores := make([]*db.Store, 0)
+	dummyStores = append(dummyStores, &db.Store{
+		Id:                  "Id001",
+		Name:                "UEC cafe",
 		RegularOpeningHours: "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
 		PriceLevel:          "PRICE_LEVEL_MODERATE",
 		Latitude:            "35.713",
 		Longitude:           "139.762",
 	})
-	dummyStores = append(dummyStores, &db.FavoriteStore{
-		Id:                  "id_2",
-		UserId:              "Id001",
-		StoreId:             "Id002",
-		StoreName:           "UEC restaurant",
-		RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
-		PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
-		Latitude:            "35.714",
-		Longitude:           "139.763",
-	})
-	dummyStores = append(dummyStores, &db.FavoriteStore{
-		Id:                  "id_2",
-		UserId:              "Id002",
-		StoreId:             "Id002",
-		StoreName:           "UEC restaurant",
+	dummyStores = append(dummyStores, &db.Store{
+		Id:                  "Id002",
+		Name:                "UEC restaurant",
 		RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
 		PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
 		Latitude:            "35.714",
@@ -46,28 +33,16 @@ func makeDummyDbStores() ([]*db.FavoriteStore, error) {
 }
 
 func makeDummyDbStoresByUser() ([]*db.FavoriteStore, error) {
-	dummyStores := make([]*db.FavoriteStore, 0)
-	dummyStores = append(dummyStores, &db.FavoriteStore{
-		Id:                  "id_1",
-		UserId:              "Id001",
-		StoreId:             "Id001",
-		StoreName:           "UEC cafe",
-		RegularOpeningHours: "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
-		PriceLevel:          "PRICE_LEVEL_MODERATE",
-		Latitude:            "35.713",
-		Longitude:           "139.762",
-	})
-	dummyStores = append(dummyStores, &db.FavoriteStore{
-		Id:                  "id_2",
-		UserId:              "Id001",
-		StoreId:             "Id002",
-		StoreName:           "UEC restaurant",
-		RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
-		PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
-		Latitude:            "35.714",
-		Longitude:           "139.763",
-	})
-	return dummyStores, nil
+	dbStores, _ := makeDummyDbStores()
+	dummyFavorites := make([]*db.FavoriteStore, 0)
+	for _, store := range dbStores {
+		dummyFavorites = append(dummyFavorites, &db.FavoriteStore{
+			UserId:  "Id001",
+			StoreId: store.Id,
+			Store:   *store,
+		})
+	}
+	return dummyFavorites, nil
 }
 
 func makeDummyApiStores() ([]*api.Store, error) {
@@ -76,8 +51,13 @@ func makeDummyApiStores() ([]*api.Store, error) {
 		Id:                  "Id001",
 		Name:                "UEC cafe",
 		RegularOpeningHours: []string{"Sat: 06:00 - 22:00", "Sun: 06:00 - 22:00"},
-		PriceLevel:          "PRICE_LEVEL_MODERATE",
-		Location:            api.Location{Lat: 35.713, Lng: 139.762},
+		OpeningPeriods: []api.Period{
+			{Open: api.Point{Day: 6, Hour: 6}, Close: &api.Point{Day: 6, Hour: 22}},
+			{Open: api.Point{Day: 0, Hour: 6}, Close: &api.Point{Day: 0, Hour: 22}},
+		},
+		UtcOffsetMinutes: 540,
+		PriceLevel:       "PRICE_LEVEL_MODERATE",
+		Location:         api.Location{Lat: 35.713, Lng: 139.762},
 	})
 	dummyStores = append(dummyStores, &api.Store{
 		Id:                  "Id002",
@@ -93,9 +73,14 @@ type MockStoreRepository struct {
 	mock.Mock
 }
 
-func (m *MockStoreRepository) GetStores() ([]*db.FavoriteStore, error) {
+func (m *MockStoreRepository) GetStores() ([]*db.Store, error) {
 	args := m.Called()
-	return args.Get(0).([]*db.FavoriteStore), args.Error(1)
+	return args.Get(0).([]*db.Store), args.Error(1)
+}
+
+func (m *MockStoreRepository) FindStoresInBoundingBox(filter *db.BoundingBoxFilter) ([]*db.Store, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*db.Store), args.Error(1)
 }
 
 func (m *MockStoreRepository) FindFavorite(storeId string, userId string) (*db.FavoriteStore, error) {
@@ -103,30 +88,80 @@ func (m *MockStoreRepository) FindFavorite(storeId string, userId string) (*db.F
 	return args.Get(0).(*db.FavoriteStore), args.Error(1)
 }
 
+func (m *MockStoreRepository) FindStore(storeId string) (*db.Store, error) {
+	args := m.Called(storeId)
+	return args.Get(0).(*db.Store), args.Error(1)
+}
+
 func (m *MockStoreRepository) FindFavoriteByUser(userId string) ([]*db.FavoriteStore, error) {
 	args := m.Called(userId)
 	return args.Get(0).([]*db.FavoriteStore), args.Error(1)
 }
 
-func (m *MockStoreRepository) SaveStore(dbStore *db.FavoriteStore) error {
-	args := m.Called(dbStore)
+func (m *MockStoreRepository) SaveFavorite(dbStore *db.Store, favorite *db.FavoriteStore) error {
+	args := m.Called(dbStore, favorite)
 	return args.Error(0)
 }
 
-func (m *MockStoreRepository) GetTopStores() ([]*db.FavoriteStore, error) {
-	args := m.Called()
-	return args.Get(0).([]*db.FavoriteStore), args.Error(1)
+func (m *MockStoreRepository) SaveFavorites(dbStores []*db.Store, favorites []*db.FavoriteStore) error {
+	args := m.Called(dbStores, favorites)
+	return args.Error(0)
+}
+
+func (m *MockStoreRepository) UpdateFavorite(favorite *db.FavoriteStore) (bool, error) {
+	args := m.Called(favorite)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreRepository) DeleteFavorite(storeId string, userId string) (bool, error) {
+	args := m.Called(storeId, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetTopStores(filter *db.RankingFilter) ([]*db.RankedStore, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*db.RankedStore), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetTopRatedStores(filter *db.RankingFilter) ([]*db.Store, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*db.Store), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetReviewSummaries(storeIds []string) ([]*db.ReviewSummary, error) {
+	args := m.Called(storeIds)
+	return args.Get(0).([]*db.ReviewSummary), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetVisitSummaries(userId string, storeIds []string) ([]*db.VisitSummary, error) {
+	args := m.Called(userId, storeIds)
+	return args.Get(0).([]*db.VisitSummary), args.Error(1)
 }
 
 type MockGoogleMapRepository struct {
 	mock.Mock
 }
 
-func (m *MockGoogleMapRepository) GetStores() ([]*api.Store, error) {
-	args := m.Called()
+func (m *MockGoogleMapRepository) GetStores(location api.Location, radius float64, maxResultCount int, rankByDistance bool) ([]*api.Store, error) {
+	args := m.Called(location, radius, maxResultCount, rankByDistance)
 	return args.Get(0).([]*api.Store), args.Error(1)
 }
 
+func (m *MockGoogleMapRepository) GetCurrentLocation() (api.Location, error) {
+	args := m.Called()
+	return args.Get(0).(api.Location), args.Error(1)
+}
+
+func (m *MockGoogleMapRepository) SearchStores(query api.TextSearchQuery) ([]*api.Store, string, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*api.Store), args.String(1), args.Error(2)
+}
+
+func (m *MockGoogleMapRepository) GetStoreDetail(id string) (*api.StoreDetail, error) {
+	args := m.Called(id)
+	return args.Get(0).(*api.StoreDetail), args.Error(1)
+}
+
 func TestGetAll(t *testing.T) {
 	/* Arrange */
 	mockStoreRepository := new(MockStoreRepository)
@@ -136,7 +171,7 @@ func TestGetAll(t *testing.T) {
 	stores = append(
 		stores,
 		&model.Store{
-			Id:                  "id_1",
+			Id:                  "Id001",
 			Name:                "UEC cafe",
 			RegularOpeningHours: "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
 			PriceLevel:          "PRICE_LEVEL_MODERATE",
@@ -146,17 +181,7 @@ func TestGetAll(t *testing.T) {
 	stores = append(
 		stores,
 		&model.Store{
-			Id:                  "id_2",
-			Name:                "UEC restaurant",
-			RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
-			PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
-			Location:            model.Location{Lat: "35.714", Lng: "139.763"},
-		},
-	)
-	stores = append(
-		stores,
-		&model.Store{
-			Id:                  "id_2",
+			Id:                  "Id002",
 			Name:                "UEC restaurant",
 			RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
 			PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
@@ -169,16 +194,40 @@ func TestGetAll(t *testing.T) {
 	actual, _ := sg.GetAll()
 
 	/* Assert */
-	// 返り値が正しいこと
+	// 店舗のidで重複なく返ること
 	assert.Equal(t, expected, actual)
 	// storeDriver.GetStores()が1回呼ばれること
 	mockStoreRepository.AssertNumberOfCalls(t, "GetStores", 1)
 }
 
+func TestGetStoresInBoundingBox(t *testing.T) {
+	/* Arrange */
+	lat := 35.7
+	lng := 139.6
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("FindStoresInBoundingBox", mock.Anything).Return([]*db.Store{
+		{Id: "Id001", Name: "UEC cafe", Latitude: "35.7", Longitude: "139.6", Lat: &lat, Lng: &lng},
+	}, nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+
+	/* Act */
+	stores, err := sg.GetStoresInBoundingBox(&model.BoundingBox{MinLng: 179.5, MinLat: -17, MaxLng: -179.5, MaxLat: -16}, model.MaxViewportStores)
+
+	/* Assert */
+	// 範囲と件数の上限をそのままDriverに渡すこと
+	assert.NoError(t, err)
+	mockStoreRepository.AssertCalled(t, "FindStoresInBoundingBox", &db.BoundingBoxFilter{MinLng: 179.5, MinLat: -17, MaxLng: -179.5, MaxLat: -16, Limit: model.MaxViewportStores})
+	if assert.Len(t, stores, 1) {
+		assert.Equal(t, model.Location{Lat: "35.7", Lng: "139.6"}, stores[0].Location)
+	}
+}
+
 func TestGetNearStores(t *testing.T) {
 	/* Arrange */
+	area := &model.SearchArea{Center: model.Location{Lat: "35.713", Lng: "139.762"}, Radius: 300.0}
 	mockGoogleMapRepository := new(MockGoogleMapRepository)
-	mockGoogleMapRepository.On("GetStores").Return(makeDummyApiStores())
+	page := &model.PageRequest{Limit: 2}
+	mockGoogleMapRepository.On("GetStores", api.Location{Lat: 35.713, Lng: 139.762}, 300.0, 2, false).Return(makeDummyApiStores())
 	sg := &StoreGateway{googleMapDriver: mockGoogleMapRepository}
 	stores := make([]*model.Store, 0)
 	stores = append(
@@ -189,6 +238,13 @@ func TestGetNearStores(t *testing.T) {
 			RegularOpeningHours: "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
 			PriceLevel:          "PRICE_LEVEL_MODERATE",
 			Location:            model.Location{Lat: "35.713000", Lng: "139.762000"},
+			OpeningHours: &model.OpeningHours{
+				Periods: []model.OpeningPeriod{
+					{Open: model.DayTime{Day: time.Saturday, Hour: 6}, Close: &model.DayTime{Day: time.Saturday, Hour: 22}},
+					{Open: model.DayTime{Day: time.Sunday, Hour: 6}, Close: &model.DayTime{Day: time.Sunday, Hour: 22}},
+				},
+				UtcOffsetMinutes: 540,
+			},
 		},
 		&model.Store{
 			Id:                  "Id002",
@@ -201,11 +257,150 @@ func TestGetNearStores(t *testing.T) {
 	expected := stores
 
 	/* Act */
-	actual, _ := sg.GetNearStores()
+	actual, next, _ := sg.GetNearStores(area, page)
 
 	/* Assert */
 	assert.Equal(t, expected, actual)
+	// 要求した件数が全て返ってきた場合は続きのカーソルを返すこと
+	assert.Equal(t, &model.Cursor{Offset: 2}, next)
 	mockGoogleMapRepository.AssertNumberOfCalls(t, "GetStores", 1)
+	// 中心が指定されている場合はサーバの位置情報を取得しないこと
+	mockGoogleMapRepository.AssertNotCalled(t, "GetCurrentLocation")
+}
+
+func TestGetNearStoresWithoutCenter(t *testing.T) {
+	/* Arrange */
+	area := &model.SearchArea{Radius: 500.0}
+	serverLocation := api.Location{Lat: 35.6, Lng: 139.7}
+	mockGoogleMapRepository := new(MockGoogleMapRepository)
+	mockGoogleMapRepository.On("GetCurrentLocation").Return(serverLocation, nil)
+	mockGoogleMapRepository.On("GetStores", serverLocation, 500.0, 10, false).Return(makeDummyApiStores())
+	sg := &StoreGateway{googleMapDriver: mockGoogleMapRepository}
+
+	/* Act */
+	actual, _, err := sg.GetNearStores(area, &model.PageRequest{Limit: 10})
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Len(t, actual, 2)
+	// 中心が指定されていない場合はサーバの位置情報で代用すること
+	mockGoogleMapRepository.AssertNumberOfCalls(t, "GetCurrentLocation", 1)
+	mockGoogleMapRepository.AssertCalled(t, "GetStores", serverLocation, 500.0, 10, false)
+}
+
+func TestGetNearStoresWithCursor(t *testing.T) {
+	/* Arrange */
+	area := &model.SearchArea{Center: model.Location{Lat: "35.713", Lng: "139.762"}, Radius: 500.0}
+	page := &model.PageRequest{Cursor: model.Cursor{Offset: 1}, Limit: 10}
+	mockGoogleMapRepository := new(MockGoogleMapRepository)
+	mockGoogleMapRepository.On("GetStores", api.Location{Lat: 35.713, Lng: 139.762}, 500.0, 11, false).Return(makeDummyApiStores())
+	sg := &StoreGateway{googleMapDriver: mockGoogleMapRepository}
+
+	/* Act */
+	actual, next, err := sg.GetNearStores(area, page)
+
+	/* Assert */
+	assert.NoError(t, err)
+	// offsetの分を読み飛ばした店舗が返ること
+	if assert.Len(t, actual, 1) {
+		assert.Equal(t, "Id002", actual[0].Id)
+	}
+	// 要求した件数より少なければ続きのカーソルを返さないこと
+	assert.Nil(t, next)
+}
+
+func TestSearchStores(t *testing.T) {
+	/* Arrange */
+	search := &model.StoreSearch{
+		Query:         "cafe",
+		Bias:          &model.SearchArea{Center: model.Location{Lat: "35.713", Lng: "139.762"}, Radius: 1000.0},
+		PriceLevels:   []model.PriceLevel{"PRICE_LEVEL_MODERATE"},
+		IncludedTypes: []string{"cafe"},
+	}
+	page := &model.PageRequest{Cursor: model.Cursor{PageToken: "token1"}, Limit: 5}
+	apiStores, _ := makeDummyApiStores()
+	mockGoogleMapRepository := new(MockGoogleMapRepository)
+	mockGoogleMapRepository.On("SearchStores", api.TextSearchQuery{
+		TextQuery:     "cafe",
+		Bias:          &api.Circle{Center: api.Location{Lat: 35.713, Lng: 139.762}, Radius: 1000.0},
+		PriceLevels:   []string{"PRICE_LEVEL_MODERATE"},
+		IncludedTypes: []string{"cafe"},
+		PageSize:      5,
+		PageToken:     "token1",
+	}).Return(apiStores, "token2", nil)
+	sg := &StoreGateway{googleMapDriver: mockGoogleMapRepository}
+
+	/* Act */
+	actual, next, err := sg.SearchStores(search, page)
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Len(t, actual, 2)
+	// 上流のページトークンがカーソルとして返ること
+	assert.Equal(t, &model.Cursor{PageToken: "token2"}, next)
+	mockGoogleMapRepository.AssertNumberOfCalls(t, "SearchStores", 1)
+	mockGoogleMapRepository.AssertNotCalled(t, "GetCurrentLocation")
+}
+
+func TestFindStoreSnapshot(t *testing.T) {
+	/* Arrange */
+	dbStores, _ := makeDummyDbStores()
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("FindStore", "Id002").Return(dbStores[1], nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+	expected := &model.Store{
+		Id:                  "Id002",
+		Name:                "UEC restaurant",
+		RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
+		PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
+		Location:            model.Location{Lat: "35.714", Lng: "139.763"},
+	}
+
+	/* Act */
+	actual, err := sg.FindStoreSnapshot("Id002")
+
+	/* Assert */
+	assert.NoError(t, err)
+	// お気に入りのidではなく店舗のidが返ること
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetStoreDetail(t *testing.T) {
+	/* Arrange */
+	apiStores, _ := makeDummyApiStores()
+	mockGoogleMapRepository := new(MockGoogleMapRepository)
+	mockGoogleMapRepository.On("GetStoreDetail", "Id002").Return(&api.StoreDetail{
+		Store:           *apiStores[1],
+		Address:         "東京都調布市調布ヶ丘1-5-1",
+		PhoneNumber:     "042-443-5000",
+		WebsiteUri:      "https://example.com",
+		Rating:          4.2,
+		UserRatingCount: 120,
+		BusinessStatus:  "OPERATIONAL",
+	}, nil)
+	sg := &StoreGateway{googleMapDriver: mockGoogleMapRepository}
+	expected := &model.StoreDetail{
+		Store: model.Store{
+			Id:                  "Id002",
+			Name:                "UEC restaurant",
+			RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
+			PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
+			Location:            model.Location{Lat: "35.714000", Lng: "139.763000"},
+		},
+		Address:         "東京都調布市調布ヶ丘1-5-1",
+		PhoneNumber:     "042-443-5000",
+		WebsiteUri:      "https://example.com",
+		Rating:          4.2,
+		UserRatingCount: 120,
+		BusinessStatus:  "OPERATIONAL",
+	}
+
+	/* Act */
+	actual, err := sg.GetStoreDetail("Id002")
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
 }
 
 func TestGetFavoriteStores(t *testing.T) {
@@ -213,6 +408,7 @@ func TestGetFavoriteStores(t *testing.T) {
 	userId := "Id001"
 	mockStoreRepository := new(MockStoreRepository)
 	mockStoreRepository.On("FindFavoriteByUser", userId).Return(makeDummyDbStoresByUser())
+	mockStoreRepository.On("GetVisitSummaries", userId, []string{"Id001", "Id002"}).Return([]*db.VisitSummary{}, nil)
 	sg := &StoreGateway{storeDriver: mockStoreRepository}
 	stores := make([]*model.Store, 0)
 	stores = append(
@@ -223,6 +419,7 @@ func TestGetFavoriteStores(t *testing.T) {
 			RegularOpeningHours: "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
 			PriceLevel:          "PRICE_LEVEL_MODERATE",
 			Location:            model.Location{Lat: "35.713", Lng: "139.762"},
+			Favorite:            &model.Favorite{Note: "", Tags: []string{}},
 		},
 	)
 	stores = append(
@@ -233,6 +430,7 @@ func TestGetFavoriteStores(t *testing.T) {
 			RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
 			PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
 			Location:            model.Location{Lat: "35.714", Lng: "139.763"},
+			Favorite:            &model.Favorite{Note: "", Tags: []string{}},
 		},
 	)
 	expected := stores
@@ -249,16 +447,18 @@ func TestSaveFavoriteStore(t *testing.T) {
 	/* Arrange */
 	var expected error = nil
 	mockStoreRepository := new(MockStoreRepository)
-	mockStoreRepository.On("SaveStore", mock.MatchedBy(func(dbStore *db.FavoriteStore) bool {
-		// UUIDはテストで完全一致が不可能なため、dbStore.id以外のフィールドを検証
-		return dbStore.UserId == "Id001" &&
-			dbStore.StoreId == "Id001" &&
-			dbStore.StoreName == "UEC cafe" &&
+	mockStoreRepository.On("SaveFavorite", mock.MatchedBy(func(dbStore *db.Store) bool {
+		return dbStore.Id == "Id001" &&
+			dbStore.Name == "UEC cafe" &&
 			dbStore.RegularOpeningHours == "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00" &&
 			dbStore.PriceLevel == "PRICE_LEVEL_MODERATE" &&
 			dbStore.Latitude == "35.713" &&
-			dbStore.Longitude == "139.762"
-	})).Return(nil)
+			dbStore.Longitude == "139.762" &&
+			*dbStore.Lat == 35.713 &&
+			*dbStore.Lng == 139.762 &&
+			dbStore.OpeningPeriods == `[{"open":{"day":6,"hour":6,"minute":0},"close":{"day":6,"hour":22,"minute":0}}]` &&
+			dbStore.UtcOffsetMinutes == 540
+	}), &db.FavoriteStore{UserId: "Id001", StoreId: "Id001"}).Return(nil)
 
 	sg := &StoreGateway{storeDriver: mockStoreRepository}
 	store := &model.Store{
@@ -267,6 +467,12 @@ func TestSaveFavoriteStore(t *testing.T) {
 		RegularOpeningHours: "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
 		PriceLevel:          "PRICE_LEVEL_MODERATE",
 		Location:            model.Location{Lat: "35.713", Lng: "139.762"},
+		OpeningHours: &model.OpeningHours{
+			Periods: []model.OpeningPeriod{
+				{Open: model.DayTime{Day: time.Saturday, Hour: 6}, Close: &model.DayTime{Day: time.Saturday, Hour: 22}},
+			},
+			UtcOffsetMinutes: 540,
+		},
 	}
 	userId := "Id001"
 
@@ -275,14 +481,102 @@ func TestSaveFavoriteStore(t *testing.T) {
 
 	/* Assert */
 	assert.Equal(t, expected, actual)
-	mockStoreRepository.AssertNumberOfCalls(t, "SaveStore", 1)
+	mockStoreRepository.AssertNumberOfCalls(t, "SaveFavorite", 1)
+}
+
+func TestSaveFavoriteStores(t *testing.T) {
+	/* Arrange */
+	savedAt := time.Date(2019, 5, 1, 3, 30, 0, 0, time.UTC)
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("SaveFavorites", mock.Anything, mock.Anything).Return(nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+	stores := []*model.Store{
+		{Id: "Id001", Name: "UEC cafe", Location: model.Location{Lat: "35.713", Lng: "139.762"}, Favorite: &model.Favorite{Note: "note", Tags: []string{"cafe"}, SavedAt: savedAt}},
+		{Id: "Id002", Name: "UEC restaurant", Location: model.Location{Lat: "35.714", Lng: "139.763"}, Favorite: &model.Favorite{}},
+	}
+
+	/* Act */
+	err := sg.SaveFavoriteStores(stores, "user_1")
+
+	/* Assert */
+	// 元の登録日時がわかる場合はその日時で登録すること
+	assert.NoError(t, err)
+	mockStoreRepository.AssertCalled(t, "SaveFavorites", mock.MatchedBy(func(dbStores []*db.Store) bool {
+		return len(dbStores) == 2 && dbStores[0].Id == "Id001" && dbStores[1].Id == "Id002"
+	}), []*db.FavoriteStore{
+		{UserId: "user_1", StoreId: "Id001", Note: "note", Tags: `["cafe"]`, CreatedAt: savedAt},
+		{UserId: "user_1", StoreId: "Id002"},
+	})
+}
+
+func TestGetFavoriteStoresWithNoteAndTags(t *testing.T) {
+	/* Arrange */
+	dbStores, _ := makeDummyDbStores()
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("FindFavoriteByUser", "user_1").Return([]*db.FavoriteStore{
+		{UserId: "user_1", StoreId: "Id001", Store: *dbStores[0], Note: "窓際の席がおすすめ", Tags: `["cafe","Wi-Fi"]`},
+		{UserId: "user_1", StoreId: "Id002", Store: *dbStores[1]},
+	}, nil)
+	mockStoreRepository.On("GetVisitSummaries", "user_1", mock.Anything).Return([]*db.VisitSummary{}, nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+
+	/* Act */
+	stores, err := sg.GetFavoriteStores("user_1")
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Favorite{Note: "窓際の席がおすすめ", Tags: []string{"cafe", "Wi-Fi"}}, stores[0].Favorite)
+	// タグがない場合は空の配列となること
+	assert.Equal(t, &model.Favorite{Note: "", Tags: []string{}}, stores[1].Favorite)
+}
+
+func TestUpdateFavoriteStore(t *testing.T) {
+	/* Arrange */
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("UpdateFavorite", &db.FavoriteStore{
+		UserId:  "user_1",
+		StoreId: "Id001",
+		Note:    "窓際の席がおすすめ",
+		Tags:    `["cafe","Wi-Fi"]`,
+	}).Return(true, nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+
+	/* Act */
+	updated, err := sg.UpdateFavoriteStore("Id001", "user_1", &model.Favorite{Note: "窓際の席がおすすめ", Tags: []string{"cafe", "Wi-Fi"}})
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.True(t, updated)
+}
+
+func TestDeleteFavoriteStore(t *testing.T) {
+	/* Arrange */
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("DeleteFavorite", "Id001", "user_1").Return(true, nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+
+	/* Act */
+	deleted, err := sg.DeleteFavoriteStore("Id001", "user_1")
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	mockStoreRepository.AssertCalled(t, "DeleteFavorite", "Id001", "user_1")
 }
 
 func TestGetTopFavoriteStores(t *testing.T) {
 	/* Arrange */
+	since := time.Date(2024, 4, 24, 12, 0, 0, 0, time.UTC)
+	ranking := &model.Ranking{Since: &since, Limit: 2}
+	dbStores, _ := makeDummyDbStores()
 	mockStoreRepository := new(MockStoreRepository)
-	mockStoreRepository.On("GetTopStores").Return(makeDummyDbStores())
+	mockStoreRepository.On("GetTopStores", &db.RankingFilter{Since: &since, Limit: 2, MinUsers: 1}).Return([]*db.RankedStore{
+		{Store: *dbStores[0], FavoriteCount: 5},
+		{Store: *dbStores[1], FavoriteCount: 3},
+	}, nil)
 	sg := &StoreGateway{storeDriver: mockStoreRepository}
+	firstCount := 5
+	secondCount := 3
 	stores := make([]*model.Store, 0)
 	stores = append(
 		stores,
@@ -292,6 +586,7 @@ func TestGetTopFavoriteStores(t *testing.T) {
 			RegularOpeningHours: "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
 			PriceLevel:          "PRICE_LEVEL_MODERATE",
 			Location:            model.Location{Lat: "35.713", Lng: "139.762"},
+			FavoriteCount:       &firstCount,
 		},
 	)
 	stores = append(
@@ -302,24 +597,114 @@ func TestGetTopFavoriteStores(t *testing.T) {
 			RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
 			PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
 			Location:            model.Location{Lat: "35.714", Lng: "139.763"},
-		},
-	)
-	stores = append(
-		stores,
-		&model.Store{
-			Id:                  "Id002",
-			Name:                "UEC restaurant",
-			RegularOpeningHours: "Sat: 11:00 - 20:00, Sun: 11:00 - 20:00",
-			PriceLevel:          "PRICE_LEVEL_INEXPENSIVE",
-			Location:            model.Location{Lat: "35.714", Lng: "139.763"},
+			FavoriteCount:       &secondCount,
 		},
 	)
 	expected := stores
 
 	/* Act */
-	actual, _ := sg.GetTopFavoriteStores()
+	actual, _ := sg.GetTopFavoriteStores(ranking)
 
 	/* Assert */
+	// 集計したお気に入り登録数が店舗ごとに設定されること
 	assert.Equal(t, expected, actual)
 	mockStoreRepository.AssertNumberOfCalls(t, "GetTopStores", 1)
 }
+
+func TestGetReviewSummaries(t *testing.T) {
+	/* Arrange */
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetReviewSummaries", []string{"Id001", "Id002"}).Return([]*db.ReviewSummary{
+		{StoreId: "Id001", Average: 4.5, Count: 2},
+	}, nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+
+	/* Act */
+	summaries, err := sg.GetReviewSummaries([]string{"Id001", "Id002"})
+
+	/* Assert */
+	// レビューのない店舗は含まないこと
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*model.ReviewSummary{"Id001": {Average: 4.5, Count: 2}}, summaries)
+}
+
+func TestGetFavoriteStoresWithVisits(t *testing.T) {
+	/* Arrange */
+	dbStores, _ := makeDummyDbStores()
+	lastVisitedAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("FindFavoriteByUser", "user_1").Return([]*db.FavoriteStore{
+		{UserId: "user_1", StoreId: "Id001", Store: *dbStores[0]},
+		{UserId: "user_1", StoreId: "Id002", Store: *dbStores[1]},
+	}, nil)
+	mockStoreRepository.On("GetVisitSummaries", "user_1", []string{"Id001", "Id002"}).Return([]*db.VisitSummary{
+		{StoreId: "Id002", Count: 3, LastVisitedAt: lastVisitedAt},
+	}, nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+
+	/* Act */
+	stores, err := sg.GetFavoriteStores("user_1")
+
+	/* Assert */
+	assert.NoError(t, err)
+	// 訪れたことのない店舗は回数0で最後の訪問日時がないこと
+	assert.Equal(t, 0, stores[0].Favorite.VisitCount)
+	assert.Nil(t, stores[0].Favorite.LastVisitedAt)
+	assert.Equal(t, 3, stores[1].Favorite.VisitCount)
+	assert.Equal(t, &lastVisitedAt, stores[1].Favorite.LastVisitedAt)
+}
+
+func TestGetFavoriteStoresWithSavedAt(t *testing.T) {
+	/* Arrange */
+	dbStores, _ := makeDummyDbStores()
+	savedAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("FindFavoriteByUser", "user_1").Return([]*db.FavoriteStore{
+		{UserId: "user_1", StoreId: "Id001", Store: *dbStores[0], CreatedAt: savedAt},
+	}, nil)
+	mockStoreRepository.On("GetVisitSummaries", "user_1", []string{"Id001"}).Return([]*db.VisitSummary{}, nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+
+	/* Act */
+	stores, err := sg.GetFavoriteStores("user_1")
+
+	/* Assert */
+	// お気に入りを登録した日時を設定すること
+	assert.NoError(t, err)
+	assert.Equal(t, savedAt, stores[0].Favorite.SavedAt)
+}
+
+func TestGetFavoriteStoresEmpty(t *testing.T) {
+	/* Arrange */
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("FindFavoriteByUser", "user_1").Return([]*db.FavoriteStore{}, nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+
+	/* Act */
+	stores, err := sg.GetFavoriteStores("user_1")
+
+	/* Assert */
+	// お気に入りがない場合は訪問の集計をしないこと
+	assert.NoError(t, err)
+	assert.Empty(t, stores)
+	mockStoreRepository.AssertNotCalled(t, "GetVisitSummaries", mock.Anything, mock.Anything)
+}
+
+func TestGetTopFavoriteStoresWithSegment(t *testing.T) {
+	/* Arrange */
+	age := 20
+	minSex := float32(0.5)
+	ranking := &model.Ranking{Limit: 10, Segment: &model.Segment{Age: &age, MinSex: &minSex}}
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetTopStores", mock.Anything).Return([]*db.RankedStore{}, nil)
+	sg := &StoreGateway{storeDriver: mockStoreRepository}
+
+	/* Act */
+	stores, err := sg.GetTopFavoriteStores(ranking)
+
+	/* Assert */
+	// 属性で絞り込む場合は少人数にしか登録されていない店舗を除くこと
+	assert.NoError(t, err)
+	assert.Empty(t, stores)
+	mockStoreRepository.AssertCalled(t, "GetTopStores", &db.RankingFilter{Limit: 10, Age: &age, MinSex: &minSex, MinUsers: model.MinSegmentUsers})
+}
@@ -0,0 +1,65 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CheckinPresenter struct {
+	c echo.Context
+}
+
+func NewCheckinOutputPort(c echo.Context) port.CheckinOutputPort {
+	return &CheckinPresenter{c: c}
+}
+
+type CheckinOutputJson struct {
+	Checkins   []checkinForPresenter `json:"checkins"`
+	NextCursor string                `json:"nextCursor,omitempty"` // 続きのページがない場合は出力しない
+}
+
+type checkinForPresenter struct {
+	StoreId   string    `json:"storeId"`
+	StoreName string    `json:"storeName"`
+	VisitedAt time.Time `json:"visitedAt"`
+	PartySize *int      `json:"partySize,omitempty"` // 指定されなかった場合は出力しない
+}
+
+func (cp *CheckinPresenter) OutputCheckins(checkins []*model.Checkin, next *model.Cursor) error {
+	json_checkins := make([]checkinForPresenter, 0)
+	for _, v := range checkins {
+		checkin := checkinForPresenter{
+			StoreId:   v.StoreId,
+			StoreName: v.StoreName,
+			VisitedAt: v.VisitedAt,
+		}
+		if v.PartySize > 0 {
+			partySize := v.PartySize
+			checkin.PartySize = &partySize
+		}
+		json_checkins = append(json_checkins, checkin)
+	}
+	output_json := &CheckinOutputJson{Checkins: json_checkins}
+	if next != nil {
+		output_json.NextCursor = next.Encode()
+	}
+	return cp.c.JSON(http.StatusOK, output_json)
+}
+
+func (cp *CheckinPresenter) OutputCreateCheckinResult() error {
+	return cp.c.JSON(http.StatusOK, map[string]interface{}{})
+}
+
+func (cp *CheckinPresenter) OutputStoreNotFound() error {
+	errMsg := "Store not found"
+	return cp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
+
+func (cp *CheckinPresenter) OutputStoreClosed() error {
+	errMsg := "Store is closed at the visited time"
+	return cp.c.JSON(http.StatusBadRequest, map[string]interface{}{"error": errMsg})
+}
@@ -0,0 +1,46 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputCheckins(t *testing.T) {
+	/* Arrange */
+	expected := "{\"checkins\":[{\"storeId\":\"Id001\",\"storeName\":\"UEC cafe\",\"visitedAt\":\"2024-05-01T03:30:00Z\",\"partySize\":2},{\"storeId\":\"Id002\",\"storeName\":\"UEC restaurant\",\"visitedAt\":\"2024-04-30T03:30:00Z\"}]}\n"
+	checkins := []*model.Checkin{
+		{StoreId: "Id001", StoreName: "UEC cafe", UserId: "user_1", VisitedAt: time.Date(2024, 5, 1, 3, 30, 0, 0, time.UTC), PartySize: 2},
+		{StoreId: "Id002", StoreName: "UEC restaurant", UserId: "user_1", VisitedAt: time.Date(2024, 4, 30, 3, 30, 0, 0, time.UTC)},
+	}
+	c, rec := newRouter()
+	cp := &CheckinPresenter{c: c}
+
+	/* Act */
+	actual := cp.OutputCheckins(checkins, nil)
+
+	/* Assert */
+	// 人数を指定しなかった訪問はpartySizeを出力しないこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputStoreClosed(t *testing.T) {
+	/* Arrange */
+	expected := "{\"error\":\"Store is closed at the visited time\"}\n"
+	c, rec := newRouter()
+	cp := &CheckinPresenter{c: c}
+
+	/* Act */
+	actual := cp.OutputStoreClosed()
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
@@ -0,0 +1,234 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// お気に入りを書き出す際のファイル名(拡張子を除く)
+const favoriteExportFileName = "favorite-stores"
+
+// お気に入りを書き出す形式ごとの出力方法
+type favoriteExportFormat interface {
+	contentType() string
+	extension() string
+	write(w io.Writer, stores []*model.Store) error
+}
+
+var favoriteExportFormats = map[model.ExportFormat]favoriteExportFormat{
+	model.ExportFormatCsv: csvFavoriteExportFormat{},
+	model.ExportFormatGpx: gpxFavoriteExportFormat{},
+	model.ExportFormatKml: kmlFavoriteExportFormat{},
+}
+
+// 添付ファイルとしてダウンロードされるよう、ヘッダを送ってから1件ずつ書き出す
+func (sp *StorePresenter) OutputFavoriteStoresExport(stores []*model.Store, format model.ExportFormat) error {
+	f := favoriteExportFormats[format]
+	header := sp.c.Response().Header()
+	header.Set(echo.HeaderContentType, f.contentType())
+	header.Set(echo.HeaderContentDisposition, `attachment; filename="`+favoriteExportFileName+"."+f.extension()+`"`)
+	sp.c.Response().WriteHeader(http.StatusOK)
+	return f.write(sp.c.Response(), stores)
+}
+
+// 取り込みにも使えるよう、店舗のidとタグも含める
+var favoriteCsvHeader = []string{"id", "name", "latitude", "longitude", "price_level", "regular_opening_hours", "saved_at", "note", "tags"}
+
+type csvFavoriteExportFormat struct{}
+
+func (csvFavoriteExportFormat) contentType() string {
+	return "text/csv; charset=UTF-8"
+}
+
+func (csvFavoriteExportFormat) extension() string {
+	return "csv"
+}
+
+func (csvFavoriteExportFormat) write(w io.Writer, stores []*model.Store) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(favoriteCsvHeader); err != nil {
+		return err
+	}
+	for _, s := range stores {
+		note, tags := favoriteNoteAndTags(s)
+		err := cw.Write([]string{
+			s.Id,
+			s.Name,
+			s.Location.Lat,
+			s.Location.Lng,
+			string(s.PriceLevel),
+			s.RegularOpeningHours,
+			formatSavedAt(s),
+			note,
+			strings.Join(tags, ","),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// GPX 1.1のウェイポイント。子要素はスキーマの定める順に並べる
+type gpxWaypoint struct {
+	XMLName xml.Name `xml:"wpt"`
+	Lat     string   `xml:"lat,attr"`
+	Lon     string   `xml:"lon,attr"`
+	Time    string   `xml:"time,omitempty"`
+	Name    string   `xml:"name"`
+	Cmt     string   `xml:"cmt,omitempty"`  // 営業時間
+	Desc    string   `xml:"desc,omitempty"` // メモ
+	Type    string   `xml:"type,omitempty"` // 価格帯
+}
+
+type gpxFavoriteExportFormat struct{}
+
+func (gpxFavoriteExportFormat) contentType() string {
+	return "application/gpx+xml"
+}
+
+func (gpxFavoriteExportFormat) extension() string {
+	return "gpx"
+}
+
+// 位置の不正な店舗はウェイポイントにできないため含めない
+func (gpxFavoriteExportFormat) write(w io.Writer, stores []*model.Store) error {
+	root := xml.StartElement{
+		Name: xml.Name{Local: "gpx"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "version"}, Value: "1.1"},
+			{Name: xml.Name{Local: "creator"}, Value: "clean-storemap-api"},
+			{Name: xml.Name{Local: "xmlns"}, Value: "http://www.topografix.com/GPX/1/1"},
+		},
+	}
+	return writeXmlDocument(w, []xml.StartElement{root}, stores, func(s *model.Store, c model.Coordinate) interface{} {
+		note, _ := favoriteNoteAndTags(s)
+		return &gpxWaypoint{
+			Lat:  formatCoordinate(c.Lat),
+			Lon:  formatCoordinate(c.Lng),
+			Time: formatSavedAt(s),
+			Name: s.Name,
+			Cmt:  s.RegularOpeningHours,
+			Desc: note,
+			Type: string(s.PriceLevel),
+		}
+	})
+}
+
+// KML 2.2のプレースマーク。メモ以外の項目はExtendedDataに入れる
+type kmlPlacemark struct {
+	XMLName      xml.Name      `xml:"Placemark"`
+	Name         string        `xml:"name"`
+	Description  string        `xml:"description,omitempty"` // メモ
+	TimeStamp    *kmlTimeStamp `xml:"TimeStamp,omitempty"`
+	ExtendedData []kmlData     `xml:"ExtendedData>Data"`
+	Coordinates  string        `xml:"Point>coordinates"`
+}
+
+type kmlTimeStamp struct {
+	When string `xml:"when"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlFavoriteExportFormat struct{}
+
+func (kmlFavoriteExportFormat) contentType() string {
+	return "application/vnd.google-earth.kml+xml"
+}
+
+func (kmlFavoriteExportFormat) extension() string {
+	return "kml"
+}
+
+// 位置の不正な店舗はプレースマークにできないため含めない
+func (kmlFavoriteExportFormat) write(w io.Writer, stores []*model.Store) error {
+	root := xml.StartElement{
+		Name: xml.Name{Local: "kml"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: "http://www.opengis.net/kml/2.2"}},
+	}
+	document := xml.StartElement{Name: xml.Name{Local: "Document"}}
+	return writeXmlDocument(w, []xml.StartElement{root, document}, stores, func(s *model.Store, c model.Coordinate) interface{} {
+		note, tags := favoriteNoteAndTags(s)
+		placemark := &kmlPlacemark{
+			Name:        s.Name,
+			Description: note,
+			ExtendedData: []kmlData{
+				{Name: "placeId", Value: s.Id},
+				{Name: "priceLevel", Value: string(s.PriceLevel)},
+				{Name: "regularOpeningHours", Value: s.RegularOpeningHours},
+				{Name: "tags", Value: strings.Join(tags, ",")},
+			},
+			// KMLの座標は経度,緯度の順
+			Coordinates: formatCoordinate(c.Lng) + "," + formatCoordinate(c.Lat),
+		}
+		if savedAt := formatSavedAt(s); savedAt != "" {
+			placemark.TimeStamp = &kmlTimeStamp{When: savedAt}
+		}
+		return placemark
+	})
+}
+
+// startsの要素を順に入れ子にした中に、位置の正しい店舗ごとにelementの返す要素を書き出す。
+// 文字列の値はencoding/xmlがエスケープする
+func writeXmlDocument(w io.Writer, starts []xml.StartElement, stores []*model.Store, element func(*model.Store, model.Coordinate) interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	for _, start := range starts {
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+	}
+	for _, s := range stores {
+		c, err := s.Location.Coordinate()
+		if err != nil {
+			continue
+		}
+		if err := enc.Encode(element(s, c)); err != nil {
+			return err
+		}
+	}
+	for i := len(starts) - 1; i >= 0; i-- {
+		if err := enc.EncodeToken(starts[i].End()); err != nil {
+			return err
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func favoriteNoteAndTags(s *model.Store) (string, []string) {
+	if s.Favorite == nil {
+		return "", nil
+	}
+	return s.Favorite.Note, s.Favorite.Tags
+}
+
+func formatSavedAt(s *model.Store) string {
+	if s.Favorite == nil || s.Favorite.SavedAt.IsZero() {
+		return ""
+	}
+	return s.Favorite.SavedAt.UTC().Format(time.RFC3339)
+}
+
+func formatCoordinate(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
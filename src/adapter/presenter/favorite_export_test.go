@@ -0,0 +1,128 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeExportStores() []*model.Store {
+	return []*model.Store{
+		{
+			Id:                  "Id001",
+			Name:                `Tom & Jerry's "<cafe>"`,
+			RegularOpeningHours: "Mon: 09:00 - 18:00",
+			PriceLevel:          model.PriceLevelModerate,
+			Location:            model.Location{Lat: "35.713", Lng: "139.762"},
+			Favorite: &model.Favorite{
+				Note:    "窓際の席, 電源あり\n<b>おすすめ</b>",
+				Tags:    []string{"cafe", "wifi"},
+				SavedAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.FixedZone("JST", 9*60*60)),
+			},
+		},
+		{
+			Id:       "Id002",
+			Name:     "Unknown",
+			Favorite: &model.Favorite{Tags: []string{}},
+		},
+	}
+}
+
+func TestOutputFavoriteStoresExportAsCsv(t *testing.T) {
+	/* Arrange */
+	expected := "id,name,latitude,longitude,price_level,regular_opening_hours,saved_at,note,tags\n" +
+		"Id001,\"Tom & Jerry's \"\"<cafe>\"\"\",35.713,139.762,PRICE_LEVEL_MODERATE,Mon: 09:00 - 18:00,2024-05-01T03:30:00Z,\"窓際の席, 電源あり\n<b>おすすめ</b>\",\"cafe,wifi\"\n" +
+		"Id002,Unknown,,,,,,,\n"
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputFavoriteStoresExport(makeExportStores(), model.ExportFormatCsv)
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="favorite-stores.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputFavoriteStoresExportAsGpx(t *testing.T) {
+	/* Arrange */
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="clean-storemap-api" xmlns="http://www.topografix.com/GPX/1/1">
+  <wpt lat="35.713" lon="139.762">
+    <time>2024-05-01T03:30:00Z</time>
+    <name>Tom &amp; Jerry&#39;s &#34;&lt;cafe&gt;&#34;</name>
+    <cmt>Mon: 09:00 - 18:00</cmt>
+    <desc>窓際の席, 電源あり&#xA;&lt;b&gt;おすすめ&lt;/b&gt;</desc>
+    <type>PRICE_LEVEL_MODERATE</type>
+  </wpt>
+</gpx>
+`
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputFavoriteStoresExport(makeExportStores(), model.ExportFormatGpx)
+
+	/* Assert */
+	// 名前やメモの記号はエスケープし、位置の不正な店舗は含めないこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, "application/gpx+xml", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="favorite-stores.gpx"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputFavoriteStoresExportAsKml(t *testing.T) {
+	/* Arrange */
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Placemark>
+      <name>Tom &amp; Jerry&#39;s &#34;&lt;cafe&gt;&#34;</name>
+      <description>窓際の席, 電源あり&#xA;&lt;b&gt;おすすめ&lt;/b&gt;</description>
+      <TimeStamp>
+        <when>2024-05-01T03:30:00Z</when>
+      </TimeStamp>
+      <ExtendedData>
+        <Data name="placeId">
+          <value>Id001</value>
+        </Data>
+        <Data name="priceLevel">
+          <value>PRICE_LEVEL_MODERATE</value>
+        </Data>
+        <Data name="regularOpeningHours">
+          <value>Mon: 09:00 - 18:00</value>
+        </Data>
+        <Data name="tags">
+          <value>cafe,wifi</value>
+        </Data>
+      </ExtendedData>
+      <Point>
+        <coordinates>139.762,35.713</coordinates>
+      </Point>
+    </Placemark>
+  </Document>
+</kml>
+`
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputFavoriteStoresExport(makeExportStores(), model.ExportFormatKml)
+
+	/* Assert */
+	// 座標は経度,緯度の順に出力すること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, "application/vnd.google-earth.kml+xml", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="favorite-stores.kml"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
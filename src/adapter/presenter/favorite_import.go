@@ -0,0 +1,44 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"net/http"
+)
+
+// 結果ごとの件数と、行ごとの結果
+type ImportOutputJson struct {
+	Imported  int                        `json:"imported"`
+	Duplicate int                        `json:"duplicate"`
+	Invalid   int                        `json:"invalid"`
+	Results   []importResultForPresenter `json:"results"`
+}
+
+type importResultForPresenter struct {
+	Row     int    `json:"row"`
+	Name    string `json:"name"`
+	StoreId string `json:"storeId,omitempty"` // 店舗がわからなかった場合は出力しない
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func (sp *StorePresenter) OutputImportResults(results []*model.ImportResult) error {
+	output_json := &ImportOutputJson{Results: make([]importResultForPresenter, 0)}
+	for _, v := range results {
+		switch v.Status {
+		case model.ImportStatusImported:
+			output_json.Imported++
+		case model.ImportStatusDuplicate:
+			output_json.Duplicate++
+		case model.ImportStatusInvalid:
+			output_json.Invalid++
+		}
+		output_json.Results = append(output_json.Results, importResultForPresenter{
+			Row:     v.Row,
+			Name:    v.Name,
+			StoreId: v.StoreId,
+			Status:  string(v.Status),
+			Reason:  v.Reason,
+		})
+	}
+	return sp.c.JSON(http.StatusOK, output_json)
+}
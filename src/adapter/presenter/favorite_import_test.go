@@ -0,0 +1,32 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputImportResults(t *testing.T) {
+	/* Arrange */
+	expected := "{\"imported\":1,\"duplicate\":1,\"invalid\":1,\"results\":[" +
+		"{\"row\":1,\"name\":\"UEC cafe\",\"storeId\":\"Id001\",\"status\":\"imported\"}," +
+		"{\"row\":2,\"name\":\"UEC restaurant\",\"storeId\":\"Id002\",\"status\":\"duplicate\"}," +
+		"{\"row\":3,\"name\":\"\",\"status\":\"invalid\",\"reason\":\"name is required\"}]}\n"
+	results := []*model.ImportResult{
+		{Row: 1, Name: "UEC cafe", StoreId: "Id001", Status: model.ImportStatusImported},
+		{Row: 2, Name: "UEC restaurant", StoreId: "Id002", Status: model.ImportStatusDuplicate},
+		{Row: 3, Status: model.ImportStatusInvalid, Reason: "name is required"},
+	}
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputImportResults(results)
+
+	/* Assert */
+	// 結果ごとの件数と行ごとの結果を返すこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
@@ -0,0 +1,74 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FavoriteListPresenter struct {
+	c echo.Context
+}
+
+func NewFavoriteListOutputPort(c echo.Context) port.FavoriteListOutputPort {
+	return &FavoriteListPresenter{c: c}
+}
+
+type FavoriteListsOutputJson struct {
+	Lists []favoriteListForPresenter `json:"lists"`
+}
+
+type favoriteListForPresenter struct {
+	Id          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Position    int                 `json:"position"`
+	Stores      []storeForPresenter `json:"stores"`
+}
+
+func (fp *FavoriteListPresenter) OutputLists(lists []*model.FavoriteList) error {
+	json_lists := make([]favoriteListForPresenter, 0)
+	for _, v := range lists {
+		json_lists = append(json_lists, newFavoriteListForPresenter(v))
+	}
+	return fp.c.JSON(http.StatusOK, &FavoriteListsOutputJson{Lists: json_lists})
+}
+
+func (fp *FavoriteListPresenter) OutputList(list *model.FavoriteList) error {
+	return fp.c.JSON(http.StatusOK, newFavoriteListForPresenter(list))
+}
+
+func (fp *FavoriteListPresenter) OutputUpdateListResult() error {
+	return fp.c.JSON(http.StatusOK, map[string]interface{}{})
+}
+
+func (fp *FavoriteListPresenter) OutputListNotFound() error {
+	errMsg := "List not found"
+	return fp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
+
+func (fp *FavoriteListPresenter) OutputAlreadyExistListStore() error {
+	errMsg := "Already exist store in list"
+	return fp.c.JSON(http.StatusConflict, map[string]interface{}{"error": errMsg})
+}
+
+func (fp *FavoriteListPresenter) OutputListStoreNotFound() error {
+	errMsg := "Store not found in list"
+	return fp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
+
+func newFavoriteListForPresenter(v *model.FavoriteList) favoriteListForPresenter {
+	stores := make([]storeForPresenter, 0)
+	for _, s := range v.Stores {
+		stores = append(stores, newStoreForPresenter(s))
+	}
+	return favoriteListForPresenter{
+		Id:          v.Id,
+		Name:        v.Name,
+		Description: v.Description,
+		Position:    v.Position,
+		Stores:      stores,
+	}
+}
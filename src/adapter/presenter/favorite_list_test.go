@@ -0,0 +1,53 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputLists(t *testing.T) {
+	/* Arrange */
+	expected := "{\"lists\":[{\"id\":\"list_1\",\"name\":\"Work lunch\",\"description\":\"平日のお昼\",\"position\":0,\"stores\":[{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"PRICE_LEVEL_MODERATE\",\"location\":{\"latitude\":\"35.713\",\"longitude\":\"139.762\"}}]},{\"id\":\"list_2\",\"name\":\"Want to try\",\"description\":\"\",\"position\":1,\"stores\":[]}]}\n"
+	lists := []*model.FavoriteList{
+		{
+			Id:          "list_1",
+			Name:        "Work lunch",
+			Description: "平日のお昼",
+			Position:    0,
+			Stores: []*model.Store{
+				{Id: "Id001", Name: "UEC cafe", PriceLevel: model.PriceLevelModerate, Location: model.Location{Lat: "35.713", Lng: "139.762"}},
+			},
+		},
+		{Id: "list_2", Name: "Want to try", Position: 1},
+	}
+	c, rec := newRouter()
+	fp := &FavoriteListPresenter{c: c}
+
+	/* Act */
+	actual := fp.OutputLists(lists)
+
+	/* Assert */
+	// 店舗のないリストはstoresが空の配列になること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputListNotFound(t *testing.T) {
+	/* Arrange */
+	expected := "{\"error\":\"List not found\"}\n"
+	c, rec := newRouter()
+	fp := &FavoriteListPresenter{c: c}
+
+	/* Act */
+	actual := fp.OutputListNotFound()
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
@@ -0,0 +1,90 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FollowPresenter struct {
+	c echo.Context
+}
+
+func NewFollowOutputPort(c echo.Context) port.FollowOutputPort {
+	return &FollowPresenter{c: c}
+}
+
+type UsersOutputJson struct {
+	Users []publicUserForPresenter `json:"users"`
+}
+
+// 他のユーザーに返す項目。メールアドレスや年齢などは含めない
+type publicUserForPresenter struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type FeedOutputJson struct {
+	Items      []feedItemForPresenter `json:"items"`
+	NextCursor string                 `json:"nextCursor,omitempty"` // 続きのページがない場合は出力しない
+}
+
+type feedItemForPresenter struct {
+	User        publicUserForPresenter `json:"user"`
+	Store       storeForPresenter      `json:"store"`
+	FavoritedAt time.Time              `json:"favoritedAt"`
+}
+
+func (fp *FollowPresenter) OutputFollowResult() error {
+	return fp.c.JSON(http.StatusOK, map[string]interface{}{})
+}
+
+func (fp *FollowPresenter) OutputAlreadyFollowing() error {
+	errMsg := "Already following user"
+	return fp.c.JSON(http.StatusConflict, map[string]interface{}{"error": errMsg})
+}
+
+func (fp *FollowPresenter) OutputUnfollowResult() error {
+	return fp.c.JSON(http.StatusOK, map[string]interface{}{})
+}
+
+func (fp *FollowPresenter) OutputFollowNotFound() error {
+	errMsg := "Not following user"
+	return fp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
+
+func (fp *FollowPresenter) OutputUserNotFound() error {
+	errMsg := "User not found"
+	return fp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
+
+func (fp *FollowPresenter) OutputUsers(users []*model.User) error {
+	json_users := make([]publicUserForPresenter, 0)
+	for _, v := range users {
+		json_users = append(json_users, newPublicUserForPresenter(v))
+	}
+	return fp.c.JSON(http.StatusOK, &UsersOutputJson{Users: json_users})
+}
+
+func (fp *FollowPresenter) OutputFeed(items []*model.FeedItem, next *model.FeedCursor) error {
+	json_items := make([]feedItemForPresenter, 0)
+	for _, v := range items {
+		json_items = append(json_items, feedItemForPresenter{
+			User:        newPublicUserForPresenter(&v.User),
+			Store:       newStoreForPresenter(v.Store),
+			FavoritedAt: v.FavoritedAt,
+		})
+	}
+	output_json := &FeedOutputJson{Items: json_items}
+	if next != nil {
+		output_json.NextCursor = next.Encode()
+	}
+	return fp.c.JSON(http.StatusOK, output_json)
+}
+
+func newPublicUserForPresenter(user *model.User) publicUserForPresenter {
+	return publicUserForPresenter{Id: user.Id, Name: user.Name}
+}
@@ -0,0 +1,50 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputUsers(t *testing.T) {
+	/* Arrange */
+	expected := "{\"users\":[{\"id\":\"user_2\",\"name\":\"Bob\"}]}\n"
+	users := []*model.User{{Id: "user_2", Name: "Bob", Email: "bob@example.com", Age: 20}}
+	c, rec := newRouter()
+	fp := &FollowPresenter{c: c}
+
+	/* Act */
+	actual := fp.OutputUsers(users)
+
+	/* Assert */
+	// メールアドレスや年齢などを出力しないこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputFeed(t *testing.T) {
+	/* Arrange */
+	favoritedAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	next := &model.FeedCursor{FavoritedAt: favoritedAt, UserId: "user_2", StoreId: "Id001"}
+	expected := "{\"items\":[{\"user\":{\"id\":\"user_2\",\"name\":\"Bob\"},\"store\":{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\",\"location\":{\"latitude\":\"35.6\",\"longitude\":\"139.5\"}},\"favoritedAt\":\"2024-05-01T12:30:00Z\"}],\"nextCursor\":\"" + next.Encode() + "\"}\n"
+	items := []*model.FeedItem{
+		{
+			User:        model.User{Id: "user_2", Name: "Bob"},
+			Store:       &model.Store{Id: "Id001", Name: "UEC cafe", Location: model.Location{Lat: "35.6", Lng: "139.5"}},
+			FavoritedAt: favoritedAt,
+		},
+	}
+	c, rec := newRouter()
+	fp := &FollowPresenter{c: c}
+
+	/* Act */
+	actual := fp.OutputFeed(items, next)
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
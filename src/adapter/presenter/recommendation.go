@@ -0,0 +1,41 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"math"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RecommendationPresenter struct {
+	c echo.Context
+}
+
+func NewRecommendationOutputPort(c echo.Context) port.RecommendationOutputPort {
+	return &RecommendationPresenter{c: c}
+}
+
+type RecommendationOutputJson struct {
+	Recommendations []recommendationForPresenter `json:"recommendations"`
+}
+
+// 店舗の項目にスコアと理由を加えて返す
+type recommendationForPresenter struct {
+	storeForPresenter
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+func (rp *RecommendationPresenter) OutputRecommendations(recommendations []*model.Recommendation) error {
+	json_recommendations := make([]recommendationForPresenter, 0)
+	for _, v := range recommendations {
+		json_recommendations = append(json_recommendations, recommendationForPresenter{
+			storeForPresenter: newStoreForPresenter(v.Store),
+			Score:             math.Round(v.Score*1000) / 1000,
+			Reason:            string(v.Reason),
+		})
+	}
+	return rp.c.JSON(http.StatusOK, &RecommendationOutputJson{Recommendations: json_recommendations})
+}
@@ -0,0 +1,32 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputRecommendations(t *testing.T) {
+	/* Arrange */
+	expected := "{\"recommendations\":[{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\",\"location\":{\"latitude\":\"35.6\",\"longitude\":\"139.5\"},\"score\":0.667,\"reason\":\"similar_users\"}]}\n"
+	recommendations := []*model.Recommendation{
+		{
+			StoreId: "Id001",
+			Store:   &model.Store{Id: "Id001", Name: "UEC cafe", Location: model.Location{Lat: "35.6", Lng: "139.5"}},
+			Score:   0.66666,
+			Reason:  model.ReasonSimilarUsers,
+		},
+	}
+	c, rec := newRouter()
+	rp := &RecommendationPresenter{c: c}
+
+	/* Act */
+	actual := rp.OutputRecommendations(recommendations)
+
+	/* Assert */
+	// 店舗の項目と同じ階層にスコアと理由を出力すること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
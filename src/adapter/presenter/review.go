@@ -0,0 +1,79 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReviewPresenter struct {
+	c echo.Context
+}
+
+func NewReviewOutputPort(c echo.Context) port.ReviewOutputPort {
+	return &ReviewPresenter{c: c}
+}
+
+type ReviewOutputJson struct {
+	Reviews       []reviewForPresenter `json:"reviews"`
+	AverageRating *float64             `json:"averageRating,omitempty"` // 店舗のレビューを返す場合のみ出力する
+	ReviewCount   *int                 `json:"reviewCount,omitempty"`
+	NextCursor    string               `json:"nextCursor,omitempty"` // 続きのページがない場合は出力しない
+}
+
+type reviewForPresenter struct {
+	StoreId   string    `json:"storeId"`
+	StoreName string    `json:"storeName,omitempty"`
+	UserName  string    `json:"userName,omitempty"`
+	Rating    int       `json:"rating"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (rp *ReviewPresenter) OutputReviews(reviews []*model.Review, summary *model.ReviewSummary, next *model.Cursor) error {
+	json_reviews := make([]reviewForPresenter, 0)
+	for _, v := range reviews {
+		json_reviews = append(json_reviews, reviewForPresenter{
+			StoreId:   v.StoreId,
+			StoreName: v.StoreName,
+			UserName:  v.UserName,
+			Rating:    v.Rating,
+			Text:      v.Text,
+			CreatedAt: v.CreatedAt,
+			UpdatedAt: v.UpdatedAt,
+		})
+	}
+	output_json := &ReviewOutputJson{Reviews: json_reviews}
+	if summary != nil {
+		count := summary.Count
+		output_json.ReviewCount = &count
+		output_json.AverageRating = roundRating(summary)
+	}
+	if next != nil {
+		output_json.NextCursor = next.Encode()
+	}
+	return rp.c.JSON(http.StatusOK, output_json)
+}
+
+func (rp *ReviewPresenter) OutputSaveReviewResult() error {
+	return rp.c.JSON(http.StatusOK, map[string]interface{}{})
+}
+
+func (rp *ReviewPresenter) OutputAlreadyExistReview() error {
+	errMsg := "Already exist review"
+	return rp.c.JSON(http.StatusConflict, map[string]interface{}{"error": errMsg})
+}
+
+func (rp *ReviewPresenter) OutputReviewNotFound() error {
+	errMsg := "Review not found"
+	return rp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
+
+func (rp *ReviewPresenter) OutputStoreNotFound() error {
+	errMsg := "Store not found"
+	return rp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
@@ -0,0 +1,70 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputReviews(t *testing.T) {
+	/* Arrange */
+	next := &model.Cursor{Offset: 1}
+	expected := "{\"reviews\":[{\"storeId\":\"Id001\",\"userName\":\"太郎\",\"rating\":5,\"text\":\"おいしい\",\"createdAt\":\"2024-05-01T12:00:00Z\",\"updatedAt\":\"2024-05-02T12:00:00Z\"}],\"averageRating\":4.7,\"reviewCount\":3,\"nextCursor\":\"" + next.Encode() + "\"}\n"
+	reviews := []*model.Review{
+		{
+			StoreId:   "Id001",
+			UserId:    "user_1",
+			UserName:  "太郎",
+			Rating:    5,
+			Text:      "おいしい",
+			CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	c, rec := newRouter()
+	rp := &ReviewPresenter{c: c}
+
+	/* Act */
+	actual := rp.OutputReviews(reviews, &model.ReviewSummary{Average: 4.6667, Count: 3}, next)
+
+	/* Assert */
+	// ユーザーidは出力されないこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputUserReviews(t *testing.T) {
+	/* Arrange */
+	expected := "{\"reviews\":[]}\n"
+	c, rec := newRouter()
+	rp := &ReviewPresenter{c: c}
+
+	/* Act */
+	actual := rp.OutputReviews([]*model.Review{}, nil, nil)
+
+	/* Assert */
+	// 集計を渡さない場合は平均と件数が出力されないこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputAlreadyExistReview(t *testing.T) {
+	/* Arrange */
+	expected := "{\"error\":\"Already exist review\"}\n"
+	c, rec := newRouter()
+	rp := &ReviewPresenter{c: c}
+
+	/* Act */
+	actual := rp.OutputAlreadyExistReview()
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
@@ -0,0 +1,62 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/port"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SharePresenter struct {
+	c echo.Context
+}
+
+func NewShareOutputPort(c echo.Context) port.ShareOutputPort {
+	return &SharePresenter{c: c}
+}
+
+type ShareLinksOutputJson struct {
+	Links []shareLinkForPresenter `json:"links"`
+}
+
+type shareLinkForPresenter struct {
+	Id        string    `json:"id"`
+	Token     string    `json:"token,omitempty"` // 作成時のみ出力する
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (sp *SharePresenter) OutputShareLink(link *model.ShareLink) error {
+	return sp.c.JSON(http.StatusOK, newShareLinkForPresenter(link))
+}
+
+func (sp *SharePresenter) OutputShareLinks(links []*model.ShareLink) error {
+	json_links := make([]shareLinkForPresenter, 0)
+	for _, v := range links {
+		json_links = append(json_links, newShareLinkForPresenter(v))
+	}
+	return sp.c.JSON(http.StatusOK, &ShareLinksOutputJson{Links: json_links})
+}
+
+func (sp *SharePresenter) OutputRevokeShareLinkResult() error {
+	return sp.c.JSON(http.StatusOK, map[string]interface{}{})
+}
+
+func (sp *SharePresenter) OutputShareLinkNotFound() error {
+	errMsg := "Share link not found"
+	return sp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
+
+// 店舗一覧と同じ形式で出力する
+func (sp *SharePresenter) OutputSharedStores(stores []*model.Store) error {
+	return NewStoreOutputPort(sp.c).OutputAllStores(stores, nil)
+}
+
+func newShareLinkForPresenter(link *model.ShareLink) shareLinkForPresenter {
+	return shareLinkForPresenter{
+		Id:        link.Id,
+		Token:     link.Token,
+		CreatedAt: link.CreatedAt,
+	}
+}
@@ -0,0 +1,59 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputShareLink(t *testing.T) {
+	/* Arrange */
+	expected := "{\"id\":\"link_1\",\"token\":\"token_1\",\"createdAt\":\"2024-05-01T12:30:00Z\"}\n"
+	link := &model.ShareLink{Id: "link_1", Token: "token_1", CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)}
+	c, rec := newRouter()
+	sp := &SharePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputShareLink(link)
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputShareLinks(t *testing.T) {
+	/* Arrange */
+	expected := "{\"links\":[{\"id\":\"link_1\",\"createdAt\":\"2024-05-01T12:30:00Z\"}]}\n"
+	links := []*model.ShareLink{{Id: "link_1", CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)}}
+	c, rec := newRouter()
+	sp := &SharePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputShareLinks(links)
+
+	/* Assert */
+	// 作成後はトークンを出力しないこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputSharedStores(t *testing.T) {
+	/* Arrange */
+	expected := "{\"stores\":[{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\",\"location\":{\"latitude\":\"35.6\",\"longitude\":\"139.5\"}}]}\n"
+	stores := []*model.Store{{Id: "Id001", Name: "UEC cafe", Location: model.Location{Lat: "35.6", Lng: "139.5"}}}
+	c, rec := newRouter()
+	sp := &SharePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputSharedStores(stores)
+
+	/* Assert */
+	// 店舗一覧と同じ形式で出力すること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
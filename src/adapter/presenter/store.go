@@ -3,7 +3,10 @@ package presenter
 import (
 	model "clean-storemap-api/src/entity"
 	"clean-storemap-api/src/usecase/port"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -17,7 +20,8 @@ func NewStoreOutputPort(c echo.Context) port.StoreOutputPort {
 }
 
 type StoreOutputJson struct {
-	Stores []storeForPresenter `json:"stores"`
+	Stores     []storeForPresenter `json:"stores"`
+	NextCursor string              `json:"nextCursor,omitempty"` // 続きのページがない場合は出力しない
 }
 
 type locationForPresenter struct {
@@ -25,30 +29,72 @@ type locationForPresenter struct {
 	Longitude string `json:"longitude"`
 }
 
+type dayTimeForPresenter struct {
+	Day    int `json:"day"`
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
+}
+
+type openingPeriodForPresenter struct {
+	Open  dayTimeForPresenter  `json:"open"`
+	Close *dayTimeForPresenter `json:"close,omitempty"`
+}
+
+type openingHoursForPresenter struct {
+	Periods          []openingPeriodForPresenter `json:"periods"`
+	UtcOffsetMinutes int                         `json:"utcOffsetMinutes"`
+}
+
 type storeForPresenter struct {
-	Id                  string               `json:"id"`
-	Name                string               `json:"name"`
-	RegularOpeningHours string               `json:"regularOpeningHours"`
-	PriceLevel          string               `json:"priceLevel"`
-	Location            locationForPresenter `json:"location"`
-}
-
-func (sp *StorePresenter) OutputAllStores(stores []*model.Store) error {
-	json_stores := make([]storeForPresenter, 0)
-	for _, v := range stores {
-		json_stores = append(json_stores, storeForPresenter{
-			Id:                  v.Id,
-			Name:                v.Name,
-			RegularOpeningHours: v.RegularOpeningHours,
-			PriceLevel:          v.PriceLevel,
-			Location: locationForPresenter{
-				Latitude:  v.Location.Lat,
-				Longitude: v.Location.Lng,
+	Id                  string                    `json:"id"`
+	Name                string                    `json:"name"`
+	RegularOpeningHours string                    `json:"regularOpeningHours"`
+	PriceLevel          string                    `json:"priceLevel"`
+	Location            locationForPresenter      `json:"location"`
+	OpeningHours        *openingHoursForPresenter `json:"openingHours,omitempty"`
+	DistanceMeters      *float64                  `json:"distanceMeters,omitempty"` // 起点が指定された場合のみ出力する
+	Note                *string                   `json:"note,omitempty"`           // 本人のお気に入りとして返す場合のみ出力する
+	Tags                []string                  `json:"tags,omitempty"`
+	VisitCount          *int                      `json:"visitCount,omitempty"`    // 本人のお気に入りとして返す場合のみ出力する
+	LastVisitedAt       *time.Time                `json:"lastVisitedAt,omitempty"` // 訪れたことがない場合は出力しない
+	AverageRating       *float64                  `json:"averageRating,omitempty"` // レビューがない場合は出力しない
+	ReviewCount         *int                      `json:"reviewCount,omitempty"`   // 集計した場合のみ出力する
+	FavoriteCount       *int                      `json:"favoriteCount,omitempty"` // ランキングとして返す場合のみ出力する
+}
+
+type StoreClusterOutputJson struct {
+	Clusters []storeClusterForPresenter `json:"clusters"`
+}
+
+type storeClusterForPresenter struct {
+	Center   locationForPresenter `json:"center"`
+	Count    int                  `json:"count"`
+	StoreIds []string             `json:"storeIds"`
+}
+
+type FavoriteTagsOutputJson struct {
+	Tags []string `json:"tags"`
+}
+
+// Acceptヘッダに応じてJSONまたはGeoJSONで出力する
+func (sp *StorePresenter) OutputAllStores(stores []*model.Store, next *model.Cursor) error {
+	sp.c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	return negotiateStoreListFormat(sp.c).output(sp.c, stores, next)
+}
+
+func (sp *StorePresenter) OutputStoreClusters(clusters []*model.StoreCluster) error {
+	json_clusters := make([]storeClusterForPresenter, 0)
+	for _, v := range clusters {
+		json_clusters = append(json_clusters, storeClusterForPresenter{
+			Center: locationForPresenter{
+				Latitude:  strconv.FormatFloat(v.Center.Lat, 'f', 6, 64),
+				Longitude: strconv.FormatFloat(v.Center.Lng, 'f', 6, 64),
 			},
+			Count:    v.Count,
+			StoreIds: v.StoreIds,
 		})
 	}
-	output_json := &StoreOutputJson{Stores: json_stores}
-	return sp.c.JSON(http.StatusOK, output_json)
+	return sp.c.JSON(http.StatusOK, &StoreClusterOutputJson{Clusters: json_clusters})
 }
 
 func (sp *StorePresenter) OutputSaveFavoriteStoreResult() error {
@@ -59,3 +105,85 @@ func (sp *StorePresenter) OutputAlreadyExistFavorite() error {
 	errMsg := "Already exist favorite store"
 	return sp.c.JSON(http.StatusConflict, map[string]interface{}{"error": errMsg})
 }
+
+func (sp *StorePresenter) OutputUpdateFavoriteStoreResult() error {
+	return sp.c.JSON(http.StatusOK, map[string]interface{}{})
+}
+
+func (sp *StorePresenter) OutputDeleteFavoriteStoreResult() error {
+	return sp.c.JSON(http.StatusOK, map[string]interface{}{})
+}
+
+func (sp *StorePresenter) OutputFavoriteNotFound() error {
+	errMsg := "Favorite store not found"
+	return sp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
+
+func (sp *StorePresenter) OutputFavoriteTags(tags []string) error {
+	return sp.c.JSON(http.StatusOK, &FavoriteTagsOutputJson{Tags: tags})
+}
+
+func newStoreForPresenter(v *model.Store) storeForPresenter {
+	store := storeForPresenter{
+		Id:                  v.Id,
+		Name:                v.Name,
+		RegularOpeningHours: v.RegularOpeningHours,
+		PriceLevel:          string(v.PriceLevel),
+		Location: locationForPresenter{
+			Latitude:  v.Location.Lat,
+			Longitude: v.Location.Lng,
+		},
+		OpeningHours:   newOpeningHoursForPresenter(v.OpeningHours),
+		DistanceMeters: roundDistance(v.DistanceMeters),
+		FavoriteCount:  v.FavoriteCount,
+	}
+	if v.Favorite != nil {
+		note := v.Favorite.Note
+		store.Note = &note
+		store.Tags = v.Favorite.Tags
+		visitCount := v.Favorite.VisitCount
+		store.VisitCount = &visitCount
+		store.LastVisitedAt = v.Favorite.LastVisitedAt
+	}
+	if v.Reviews != nil {
+		count := v.Reviews.Count
+		store.ReviewCount = &count
+		store.AverageRating = roundRating(v.Reviews)
+	}
+	return store
+}
+
+// 評価の平均は小数第1位に丸める
+func roundRating(summary *model.ReviewSummary) *float64 {
+	if !summary.HasReviews() {
+		return nil
+	}
+	rounded := math.Round(summary.Average*10) / 10
+	return &rounded
+}
+
+// 位置情報の精度を考えて1メートル単位に丸める
+func roundDistance(distance *float64) *float64 {
+	if distance == nil {
+		return nil
+	}
+	rounded := math.Round(*distance)
+	return &rounded
+}
+
+func newOpeningHoursForPresenter(openingHours *model.OpeningHours) *openingHoursForPresenter {
+	if openingHours == nil {
+		return nil
+	}
+	periods := make([]openingPeriodForPresenter, 0)
+	for _, p := range openingHours.Periods {
+		period := openingPeriodForPresenter{
+			Open: dayTimeForPresenter{Day: int(p.Open.Day), Hour: p.Open.Hour, Minute: p.Open.Minute},
+		}
+		if p.Close != nil {
+			period.Close = &dayTimeForPresenter{Day: int(p.Close.Day), Hour: p.Close.Hour, Minute: p.Close.Minute}
+		}
+		periods = append(periods, period)
+	}
+	return &openingHoursForPresenter{Periods: periods, UtcOffsetMinutes: openingHours.UtcOffsetMinutes}
+}
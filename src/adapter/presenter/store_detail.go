@@ -0,0 +1,46 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"net/http"
+)
+
+type StoreDetailOutputJson struct {
+	Store storeDetailForPresenter `json:"store"`
+}
+
+type storeDetailForPresenter struct {
+	storeForPresenter
+	Address         string   `json:"address"`
+	PhoneNumber     string   `json:"phoneNumber"`
+	WebsiteUri      string   `json:"websiteUri"`
+	Rating          float64  `json:"rating"`
+	UserRatingCount int      `json:"userRatingCount"`
+	Types           []string `json:"types"`
+	BusinessStatus  string   `json:"businessStatus"`
+}
+
+func (sp *StorePresenter) OutputStoreDetail(detail *model.StoreDetail) error {
+	types := detail.Types
+	if types == nil {
+		types = make([]string, 0)
+	}
+	output_json := &StoreDetailOutputJson{
+		Store: storeDetailForPresenter{
+			storeForPresenter: newStoreForPresenter(&detail.Store),
+			Address:           detail.Address,
+			PhoneNumber:       detail.PhoneNumber,
+			WebsiteUri:        detail.WebsiteUri,
+			Rating:            detail.Rating,
+			UserRatingCount:   detail.UserRatingCount,
+			Types:             types,
+			BusinessStatus:    detail.BusinessStatus,
+		},
+	}
+	return sp.c.JSON(http.StatusOK, output_json)
+}
+
+func (sp *StorePresenter) OutputStoreNotFound() error {
+	errMsg := "Store not found"
+	return sp.c.JSON(http.StatusNotFound, map[string]interface{}{"error": errMsg})
+}
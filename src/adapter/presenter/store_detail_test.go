@@ -0,0 +1,57 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputStoreDetail(t *testing.T) {
+	/* Arrange */
+	expected := "{\"store\":{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"Sat: 06:00 - 22:00, Sun: 06:00 - 22:00\",\"priceLevel\":\"PRICE_LEVEL_MODERATE\",\"location\":{\"latitude\":\"35.713\",\"longitude\":\"139.762\"},\"address\":\"東京都調布市調布ヶ丘1-5-1\",\"phoneNumber\":\"042-443-5000\",\"websiteUri\":\"https://example.com\",\"rating\":4.2,\"userRatingCount\":120,\"types\":[\"cafe\"],\"businessStatus\":\"OPERATIONAL\"}}\n"
+	detail := &model.StoreDetail{
+		Store: model.Store{
+			Id:                  "Id001",
+			Name:                "UEC cafe",
+			RegularOpeningHours: "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
+			PriceLevel:          "PRICE_LEVEL_MODERATE",
+			Location:            model.Location{Lat: "35.713", Lng: "139.762"},
+		},
+		Address:         "東京都調布市調布ヶ丘1-5-1",
+		PhoneNumber:     "042-443-5000",
+		WebsiteUri:      "https://example.com",
+		Rating:          4.2,
+		UserRatingCount: 120,
+		Types:           []string{"cafe"},
+		BusinessStatus:  "OPERATIONAL",
+	}
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputStoreDetail(detail)
+
+	/* Assert */
+	// sp.OutputStoreDetail()が店舗の情報と詳細を同じ階層のJSONで返すこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputStoreNotFound(t *testing.T) {
+	/* Arrange */
+	expected := "{\"error\":\"Store not found\"}\n"
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputStoreNotFound()
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
@@ -0,0 +1,143 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const MIMEApplicationGeoJSON = "application/geo+json"
+
+// 店舗一覧の出力形式。リクエストのAcceptヘッダに書かれたメディアタイプで選ぶ
+type storeListFormat interface {
+	mediaType() string
+	output(c echo.Context, stores []*model.Store, next *model.Cursor) error
+}
+
+// Acceptヘッダに一致する形式がない場合は先頭の形式で出力する
+var storeListFormats = []storeListFormat{
+	jsonStoreListFormat{},
+	geoJsonStoreListFormat{},
+}
+
+// Acceptヘッダのq値の高い順に、同じ場合は書かれた順に、対応している形式を探す
+func negotiateStoreListFormat(c echo.Context) storeListFormat {
+	for _, mediaType := range acceptedMediaTypes(c.Request().Header.Get(echo.HeaderAccept)) {
+		for _, f := range storeListFormats {
+			if f.mediaType() == mediaType {
+				return f
+			}
+		}
+	}
+	return storeListFormats[0]
+}
+
+// q=0のメディアタイプは受け付けないものとして除く
+func acceptedMediaTypes(accept string) []string {
+	type accepted struct {
+		mediaType string
+		q         float64
+	}
+	types := make([]accepted, 0)
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		if mediaType == "" {
+			continue
+		}
+		q := 1.0
+		for _, p := range params[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if ok && strings.TrimSpace(key) == "q" {
+				if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			types = append(types, accepted{mediaType: mediaType, q: q})
+		}
+	}
+	sort.SliceStable(types, func(i, j int) bool {
+		return types[i].q > types[j].q
+	})
+	mediaTypes := make([]string, 0, len(types))
+	for _, t := range types {
+		mediaTypes = append(mediaTypes, t.mediaType)
+	}
+	return mediaTypes
+}
+
+type jsonStoreListFormat struct{}
+
+func (jsonStoreListFormat) mediaType() string {
+	return echo.MIMEApplicationJSON
+}
+
+func (jsonStoreListFormat) output(c echo.Context, stores []*model.Store, next *model.Cursor) error {
+	json_stores := make([]storeForPresenter, 0)
+	for _, v := range stores {
+		json_stores = append(json_stores, newStoreForPresenter(v))
+	}
+	output_json := &StoreOutputJson{Stores: json_stores}
+	if next != nil {
+		output_json.NextCursor = next.Encode()
+	}
+	return c.JSON(http.StatusOK, output_json)
+}
+
+// RFC 7946のFeatureCollection。続きのページがある場合はnextCursorを加える
+type FeatureCollectionOutputJson struct {
+	Type       string                `json:"type"`
+	Features   []featureForPresenter `json:"features"`
+	NextCursor string                `json:"nextCursor,omitempty"`
+}
+
+type featureForPresenter struct {
+	Type       string                `json:"type"`
+	Id         string                `json:"id"`
+	Geometry   *pointForPresenter    `json:"geometry"` // 位置が不正な店舗はnull
+	Properties featurePropertiesJson `json:"properties"`
+}
+
+type pointForPresenter struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"` // [経度, 緯度]
+}
+
+// 位置はgeometryで表すため、店舗の項目からlocationを除いたもの
+type featurePropertiesJson struct {
+	storeForPresenter
+	Location *locationForPresenter `json:"location,omitempty"`
+}
+
+type geoJsonStoreListFormat struct{}
+
+func (geoJsonStoreListFormat) mediaType() string {
+	return MIMEApplicationGeoJSON
+}
+
+func (geoJsonStoreListFormat) output(c echo.Context, stores []*model.Store, next *model.Cursor) error {
+	features := make([]featureForPresenter, 0)
+	for _, v := range stores {
+		feature := featureForPresenter{
+			Type:       "Feature",
+			Id:         v.Id,
+			Properties: featurePropertiesJson{storeForPresenter: newStoreForPresenter(v)},
+		}
+		if coordinate, err := v.Location.Coordinate(); err == nil {
+			feature.Geometry = &pointForPresenter{Type: "Point", Coordinates: [2]float64{coordinate.Lng, coordinate.Lat}}
+		}
+		features = append(features, feature)
+	}
+	output_json := &FeatureCollectionOutputJson{Type: "FeatureCollection", Features: features}
+	if next != nil {
+		output_json.NextCursor = next.Encode()
+	}
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationGeoJSON)
+	return c.JSON(http.StatusOK, output_json)
+}
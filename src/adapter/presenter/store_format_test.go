@@ -0,0 +1,73 @@
+package presenter
+
+import (
+	model "clean-storemap-api/src/entity"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputAllStoresAsGeoJson(t *testing.T) {
+	/* Arrange */
+	expected := "{\"type\":\"FeatureCollection\",\"features\":[" +
+		"{\"type\":\"Feature\",\"id\":\"Id001\",\"geometry\":{\"type\":\"Point\",\"coordinates\":[139.762,35.713]},\"properties\":{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"PRICE_LEVEL_MODERATE\"}}," +
+		"{\"type\":\"Feature\",\"id\":\"Id002\",\"geometry\":null,\"properties\":{\"id\":\"Id002\",\"name\":\"Unknown\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\"}}" +
+		"],\"nextCursor\":\"" + (&model.Cursor{Offset: 2}).Encode() + "\"}\n"
+	stores := []*model.Store{
+		{Id: "Id001", Name: "UEC cafe", PriceLevel: model.PriceLevelModerate, Location: model.Location{Lat: "35.713", Lng: "139.762"}},
+		{Id: "Id002", Name: "Unknown"},
+	}
+	c, rec := newRouter()
+	c.Request().Header.Set(echo.HeaderAccept, "application/geo+json")
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores(stores, &model.Cursor{Offset: 2})
+
+	/* Assert */
+	// 店舗ごとに経度・緯度の順のPointとなり、位置の不正な店舗はgeometryをnullとすること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, MIMEApplicationGeoJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, echo.HeaderAccept, rec.Header().Get(echo.HeaderVary))
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputAllStoresWithAcceptPreference(t *testing.T) {
+	/* Arrange */
+	stores := []*model.Store{
+		{Id: "Id001", Name: "UEC cafe", Location: model.Location{Lat: "35.713", Lng: "139.762"}},
+	}
+	c, rec := newRouter()
+	c.Request().Header.Set(echo.HeaderAccept, "application/geo+json;q=0.5, application/json")
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores(stores, nil)
+
+	/* Assert */
+	// q値の高いJSONで出力すること
+	if assert.NoError(t, actual) {
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+		assert.Contains(t, rec.Body.String(), "\"stores\":")
+	}
+}
+
+func TestOutputAllStoresWithUnsupportedAccept(t *testing.T) {
+	/* Arrange */
+	c, rec := newRouter()
+	c.Request().Header.Set(echo.HeaderAccept, "text/html, application/geo+json;q=0")
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores([]*model.Store{}, nil)
+
+	/* Assert */
+	// 対応する形式がない場合はJSONで出力すること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, "{\"stores\":[]}\n", rec.Body.String())
+	}
+}
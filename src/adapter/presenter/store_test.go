@@ -3,6 +3,7 @@ package presenter
 import (
 	model "clean-storemap-api/src/entity"
 	"testing"
+	"time"
 
 	"net/http"
 	"net/http/httptest"
@@ -38,7 +39,7 @@ func TestOutputAllStores(t *testing.T) {
 	sp := &StorePresenter{c: c}
 
 	/* Act */
-	actual := sp.OutputAllStores(stores)
+	actual := sp.OutputAllStores(stores, nil)
 
 	/* Assert */
 	// sp.OutputAllStores()がJSONを返すこと
@@ -47,6 +48,154 @@ func TestOutputAllStores(t *testing.T) {
 	}
 }
 
+func TestOutputStoreClusters(t *testing.T) {
+	/* Arrange */
+	expected := "{\"clusters\":[{\"center\":{\"latitude\":\"35.690000\",\"longitude\":\"139.730000\"},\"count\":2,\"storeIds\":[\"Id001\",\"Id002\"]},{\"center\":{\"latitude\":\"34.690000\",\"longitude\":\"135.500000\"},\"count\":1,\"storeIds\":[\"Id003\"]}]}\n"
+	clusters := []*model.StoreCluster{
+		{Center: model.Coordinate{Lat: 35.69, Lng: 139.73}, Count: 2, StoreIds: []string{"Id001", "Id002"}},
+		{Center: model.Coordinate{Lat: 34.69, Lng: 135.5}, Count: 1, StoreIds: []string{"Id003"}},
+	}
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputStoreClusters(clusters)
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputAllStoresWithOpeningHours(t *testing.T) {
+	/* Arrange */
+	expected := "{\"stores\":[{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"Sat: 06:00 - 22:00\",\"priceLevel\":\"PRICE_LEVEL_MODERATE\",\"location\":{\"latitude\":\"35.713\",\"longitude\":\"139.762\"},\"openingHours\":{\"periods\":[{\"open\":{\"day\":6,\"hour\":6,\"minute\":0},\"close\":{\"day\":6,\"hour\":22,\"minute\":0}},{\"open\":{\"day\":0,\"hour\":0,\"minute\":0}}],\"utcOffsetMinutes\":540}}]}\n"
+	stores := []*model.Store{
+		{
+			Id:                  "Id001",
+			Name:                "UEC cafe",
+			RegularOpeningHours: "Sat: 06:00 - 22:00",
+			PriceLevel:          "PRICE_LEVEL_MODERATE",
+			Location:            model.Location{Lat: "35.713", Lng: "139.762"},
+			OpeningHours: &model.OpeningHours{
+				Periods: []model.OpeningPeriod{
+					{Open: model.DayTime{Day: time.Saturday, Hour: 6}, Close: &model.DayTime{Day: time.Saturday, Hour: 22}},
+					{Open: model.DayTime{Day: time.Sunday}},
+				},
+				UtcOffsetMinutes: 540,
+			},
+		},
+	}
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores(stores, nil)
+
+	/* Assert */
+	// 営業時間の区間が出力され、24時間営業の区間にはcloseが含まれないこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputAllStoresWithDistance(t *testing.T) {
+	/* Arrange */
+	expected := "{\"stores\":[{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\",\"location\":{\"latitude\":\"35.713\",\"longitude\":\"139.762\"},\"distanceMeters\":124}]}\n"
+	distance := 123.6
+	stores := []*model.Store{
+		{
+			Id:             "Id001",
+			Name:           "UEC cafe",
+			Location:       model.Location{Lat: "35.713", Lng: "139.762"},
+			DistanceMeters: &distance,
+		},
+	}
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores(stores, nil)
+
+	/* Assert */
+	// 距離がメートル単位に丸めて出力されること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputAllStoresWithReviews(t *testing.T) {
+	/* Arrange */
+	expected := "{\"stores\":[{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\",\"location\":{\"latitude\":\"35.713\",\"longitude\":\"139.762\"},\"averageRating\":4.3,\"reviewCount\":3},{\"id\":\"Id002\",\"name\":\"UEC restaurant\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\",\"location\":{\"latitude\":\"35.714\",\"longitude\":\"139.763\"},\"reviewCount\":0}]}\n"
+	stores := []*model.Store{
+		{
+			Id:       "Id001",
+			Name:     "UEC cafe",
+			Location: model.Location{Lat: "35.713", Lng: "139.762"},
+			Reviews:  &model.ReviewSummary{Average: 4.3333, Count: 3},
+		},
+		{
+			Id:       "Id002",
+			Name:     "UEC restaurant",
+			Location: model.Location{Lat: "35.714", Lng: "139.763"},
+			Reviews:  &model.ReviewSummary{},
+		},
+	}
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores(stores, nil)
+
+	/* Assert */
+	// 平均評価が小数第1位に丸められ、レビューのない店舗は件数0のみ出力されること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputAllStoresWithFavoriteCount(t *testing.T) {
+	/* Arrange */
+	expected := "{\"stores\":[{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\",\"location\":{\"latitude\":\"35.713\",\"longitude\":\"139.762\"},\"favoriteCount\":12}]}\n"
+	favoriteCount := 12
+	stores := []*model.Store{
+		{
+			Id:            "Id001",
+			Name:          "UEC cafe",
+			Location:      model.Location{Lat: "35.713", Lng: "139.762"},
+			FavoriteCount: &favoriteCount,
+		},
+	}
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores(stores, nil)
+
+	/* Assert */
+	// ランキングとして返す場合はお気に入り登録数が出力されること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputAllStoresWithNextCursor(t *testing.T) {
+	/* Arrange */
+	next := &model.Cursor{Offset: 10}
+	expected := "{\"stores\":[],\"nextCursor\":\"" + next.Encode() + "\"}\n"
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores([]*model.Store{}, next)
+
+	/* Assert */
+	// 続きのページがある場合はnextCursorが出力されること
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
 func TestOutputSaveFavoriteStoreResult(t *testing.T) {
 	/* Arrange */
 	expected := "{}\n"
@@ -78,3 +227,83 @@ func TestOutputAlreadyExistFavorite(t *testing.T) {
 		assert.Equal(t, expected, rec.Body.String())
 	}
 }
+
+func TestOutputFavoriteNotFound(t *testing.T) {
+	/* Arrange */
+	expected := "{\"error\":\"Favorite store not found\"}\n"
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputFavoriteNotFound()
+
+	/* Assert */
+	// sp.OutputFavoriteNotFound()が404を返すこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputAllStoresWithFavorite(t *testing.T) {
+	/* Arrange */
+	expected := "{\"stores\":[{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\",\"location\":{\"latitude\":\"35.713\",\"longitude\":\"139.762\"},\"note\":\"窓際の席がおすすめ\",\"tags\":[\"cafe\",\"Wi-Fi\"],\"visitCount\":0}]}\n"
+	stores := []*model.Store{
+		{
+			Id:       "Id001",
+			Name:     "UEC cafe",
+			Location: model.Location{Lat: "35.713", Lng: "139.762"},
+			Favorite: &model.Favorite{Note: "窓際の席がおすすめ", Tags: []string{"cafe", "Wi-Fi"}},
+		},
+	}
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores(stores, nil)
+
+	/* Assert */
+	// お気に入りとして返す場合はメモとタグを出力し、訪れたことがない場合は最後の訪問日時を出力しないこと
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputAllStoresWithVisits(t *testing.T) {
+	/* Arrange */
+	expected := "{\"stores\":[{\"id\":\"Id001\",\"name\":\"UEC cafe\",\"regularOpeningHours\":\"\",\"priceLevel\":\"\",\"location\":{\"latitude\":\"35.713\",\"longitude\":\"139.762\"},\"note\":\"\",\"visitCount\":3,\"lastVisitedAt\":\"2024-05-01T12:30:00Z\"}]}\n"
+	lastVisitedAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	stores := []*model.Store{
+		{
+			Id:       "Id001",
+			Name:     "UEC cafe",
+			Location: model.Location{Lat: "35.713", Lng: "139.762"},
+			Favorite: &model.Favorite{Tags: []string{}, VisitCount: 3, LastVisitedAt: &lastVisitedAt},
+		},
+	}
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputAllStores(stores, nil)
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
+
+func TestOutputFavoriteTags(t *testing.T) {
+	/* Arrange */
+	expected := "{\"tags\":[\"cafe\",\"Cake\"]}\n"
+	c, rec := newRouter()
+	sp := &StorePresenter{c: c}
+
+	/* Act */
+	actual := sp.OutputFavoriteTags([]string{"cafe", "Cake"})
+
+	/* Assert */
+	if assert.NoError(t, actual) {
+		assert.Equal(t, expected, rec.Body.String())
+	}
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// キャッシュの保存先。複数のサーバで共有する場合(Redisなど)はこれを実装して差し替える
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// CachedGoogleMapDriverがキャッシュするGoogle Maps APIの呼び出し(ApiGoogleMapDriverが実装する)
+type googleMapClient interface {
+	GetStores(location Location, radius float64, maxResultCount int, rankByDistance bool) ([]*Store, error)
+	GetCurrentLocation() (Location, error)
+	SearchStores(query TextSearchQuery) ([]*Store, string, error)
+	GetStoreDetail(id string) (*StoreDetail, error)
+}
+
+// 緯度経度を丸める桁数(小数第3位で約100m)。近い位置からの検索は同じ結果を返す
+const locationPrecision = 3
+
+// Google Maps APIのレスポンスをキャッシュする。同じ条件の同時リクエストは1回の呼び出しにまとめる
+type CachedGoogleMapDriver struct {
+	next  googleMapClient
+	cache Cache
+	ttl   time.Duration
+	group singleflight.Group
+}
+
+func NewCachedGoogleMapDriver(next googleMapClient, cache Cache, ttl time.Duration) *CachedGoogleMapDriver {
+	return &CachedGoogleMapDriver{
+		next:  next,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+func (cd *CachedGoogleMapDriver) GetStores(location Location, radius float64, maxResultCount int, rankByDistance bool) ([]*Store, error) {
+	location = roundLocation(location)
+	key := fmt.Sprintf("nearby:%s:%.0f:%d:%t", locationKey(location), radius, maxResultCount, rankByDistance)
+	var stores []*Store
+	err := cd.fetch(key, &stores, func() (interface{}, error) {
+		return cd.next.GetStores(location, radius, maxResultCount, rankByDistance)
+	})
+	if err != nil {
+		return make([]*Store, 0), err
+	}
+	return stores, nil
+}
+
+func (cd *CachedGoogleMapDriver) GetCurrentLocation() (Location, error) {
+	var location Location
+	err := cd.fetch("geolocate", &location, func() (interface{}, error) {
+		return cd.next.GetCurrentLocation()
+	})
+	if err != nil {
+		return Location{}, err
+	}
+	return location, nil
+}
+
+type textSearchResult struct {
+	Stores        []*Store `json:"stores"`
+	NextPageToken string   `json:"nextPageToken"`
+}
+
+func (cd *CachedGoogleMapDriver) SearchStores(query TextSearchQuery) ([]*Store, string, error) {
+	bias := "none"
+	if query.Bias != nil {
+		query.Bias = &Circle{Center: roundLocation(query.Bias.Center), Radius: query.Bias.Radius}
+		bias = fmt.Sprintf("%s:%.0f", locationKey(query.Bias.Center), query.Bias.Radius)
+	}
+	key := fmt.Sprintf(
+		"text:%q:%s:%s:%s:%d:%s",
+		query.TextQuery, bias, sortedKey(query.PriceLevels), sortedKey(query.IncludedTypes), query.PageSize, query.PageToken,
+	)
+	var result textSearchResult
+	err := cd.fetch(key, &result, func() (interface{}, error) {
+		stores, nextPageToken, err := cd.next.SearchStores(query)
+		return textSearchResult{Stores: stores, NextPageToken: nextPageToken}, err
+	})
+	if err != nil {
+		return make([]*Store, 0), "", err
+	}
+	return result.Stores, result.NextPageToken, nil
+}
+
+func (cd *CachedGoogleMapDriver) GetStoreDetail(id string) (*StoreDetail, error) {
+	var detail *StoreDetail
+	err := cd.fetch("detail:"+id, &detail, func() (interface{}, error) {
+		return cd.next.GetStoreDetail(id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+// キャッシュにあればそれを返し、なければcallの結果をキャッシュしてから返す。エラーはキャッシュしない
+func (cd *CachedGoogleMapDriver) fetch(key string, dest interface{}, call func() (interface{}, error)) error {
+	if cached, ok := cd.cache.Get(key); ok {
+		return json.Unmarshal(cached, dest)
+	}
+	encoded, err, _ := cd.group.Do(key, func() (interface{}, error) {
+		value, err := call()
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		cd.cache.Set(key, encoded, cd.ttl)
+		return encoded, nil
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded.([]byte), dest)
+}
+
+func roundLocation(location Location) Location {
+	scale := math.Pow(10, locationPrecision)
+	return Location{
+		Lat: math.Round(location.Lat*scale) / scale,
+		Lng: math.Round(location.Lng*scale) / scale,
+	}
+}
+
+func locationKey(location Location) string {
+	return fmt.Sprintf("%.*f,%.*f", locationPrecision, location.Lat, locationPrecision, location.Lng)
+}
+
+// 指定の順番が違うだけの条件を同じキーにする
+func sortedKey(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// 件数の上限を超えた場合は最も長く使われていないものから削除するインメモリのキャッシュ
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // 先頭が最近使われたもの
+	now      func() time.Time
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (mc *MemoryCache) Get(key string) ([]byte, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	element, ok := mc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryCacheEntry)
+	if !mc.now().Before(entry.expiresAt) {
+		mc.order.Remove(element)
+		delete(mc.entries, key)
+		return nil, false
+	}
+	mc.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (mc *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	expiresAt := mc.now().Add(ttl)
+	if element, ok := mc.entries[key]; ok {
+		entry := element.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		mc.order.MoveToFront(element)
+		return
+	}
+	mc.entries[key] = mc.order.PushFront(&memoryCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for mc.order.Len() > mc.capacity {
+		oldest := mc.order.Back()
+		mc.order.Remove(oldest)
+		delete(mc.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeGoogleMapClient struct {
+	calls   atomic.Int32
+	release chan struct{} // nilでなければ閉じられるまで呼び出しを待たせる
+	err     error
+}
+
+func (f *fakeGoogleMapClient) GetStores(location Location, radius float64, maxResultCount int, rankByDistance bool) ([]*Store, error) {
+	f.calls.Add(1)
+	if f.release != nil {
+		<-f.release
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*Store{{Id: "Id001", Name: "UEC cafe", Location: location}}, nil
+}
+
+func (f *fakeGoogleMapClient) GetCurrentLocation() (Location, error) {
+	f.calls.Add(1)
+	return Location{Lat: 35.6, Lng: 139.7}, nil
+}
+
+func (f *fakeGoogleMapClient) SearchStores(query TextSearchQuery) ([]*Store, string, error) {
+	f.calls.Add(1)
+	return []*Store{{Id: "Id001", Name: query.TextQuery}}, "token", nil
+}
+
+func (f *fakeGoogleMapClient) GetStoreDetail(id string) (*StoreDetail, error) {
+	f.calls.Add(1)
+	return nil, nil
+}
+
+func TestCachedGetStores(t *testing.T) {
+	/* Arrange */
+	client := &fakeGoogleMapClient{}
+	cd := NewCachedGoogleMapDriver(client, NewMemoryCache(10), time.Minute)
+
+	/* Act */
+	first, err1 := cd.GetStores(Location{Lat: 35.71301, Lng: 139.76204}, 500, 10, false)
+	second, err2 := cd.GetStores(Location{Lat: 35.71298, Lng: 139.76196}, 500, 10, false)
+
+	/* Assert */
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	// 丸めると同じ位置になる検索は1回しか呼び出さないこと
+	assert.Equal(t, int32(1), client.calls.Load())
+	assert.Equal(t, first, second)
+	// 丸めた位置で検索されること
+	assert.Equal(t, Location{Lat: 35.713, Lng: 139.762}, first[0].Location)
+}
+
+func TestCachedGetStoresWithDifferentRadius(t *testing.T) {
+	/* Arrange */
+	client := &fakeGoogleMapClient{}
+	cd := NewCachedGoogleMapDriver(client, NewMemoryCache(10), time.Minute)
+
+	/* Act */
+	cd.GetStores(Location{Lat: 35.713, Lng: 139.762}, 500, 10, false)
+	cd.GetStores(Location{Lat: 35.713, Lng: 139.762}, 1000, 10, false)
+
+	/* Assert */
+	// 半径が違う場合は別の検索として呼び出すこと
+	assert.Equal(t, int32(2), client.calls.Load())
+}
+
+func TestCachedGetStoresDoesNotCacheError(t *testing.T) {
+	/* Arrange */
+	client := &fakeGoogleMapClient{err: errors.New("quota exceeded")}
+	cd := NewCachedGoogleMapDriver(client, NewMemoryCache(10), time.Minute)
+
+	/* Act */
+	_, err1 := cd.GetStores(Location{Lat: 35.713, Lng: 139.762}, 500, 10, false)
+	_, err2 := cd.GetStores(Location{Lat: 35.713, Lng: 139.762}, 500, 10, false)
+
+	/* Assert */
+	assert.Error(t, err1)
+	assert.Error(t, err2)
+	// エラーはキャッシュせず毎回呼び出すこと
+	assert.Equal(t, int32(2), client.calls.Load())
+}
+
+func TestCachedGetStoresSharesConcurrentCalls(t *testing.T) {
+	/* Arrange */
+	client := &fakeGoogleMapClient{release: make(chan struct{})}
+	cd := NewCachedGoogleMapDriver(client, NewMemoryCache(10), time.Minute)
+
+	/* Act */
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cd.GetStores(Location{Lat: 35.713, Lng: 139.762}, 500, 10, false)
+		}()
+	}
+	// 全てのリクエストが呼び出しの完了を待っている状態にする
+	assert.Eventually(t, func() bool { return client.calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(client.release)
+	wg.Wait()
+
+	/* Assert */
+	// 同時に来た同じ条件の検索は1回の呼び出しにまとめること
+	assert.Equal(t, int32(1), client.calls.Load())
+}
+
+func TestCachedSearchStores(t *testing.T) {
+	/* Arrange */
+	client := &fakeGoogleMapClient{}
+	cd := NewCachedGoogleMapDriver(client, NewMemoryCache(10), time.Minute)
+
+	/* Act */
+	cd.SearchStores(TextSearchQuery{TextQuery: "cafe", PriceLevels: []string{"PRICE_LEVEL_MODERATE", "PRICE_LEVEL_INEXPENSIVE"}})
+	stores, nextPageToken, err := cd.SearchStores(TextSearchQuery{TextQuery: "cafe", PriceLevels: []string{"PRICE_LEVEL_INEXPENSIVE", "PRICE_LEVEL_MODERATE"}})
+
+	/* Assert */
+	assert.NoError(t, err)
+	// 価格帯の指定順が違うだけの検索はキャッシュを使うこと
+	assert.Equal(t, int32(1), client.calls.Load())
+	assert.Equal(t, "cafe", stores[0].Name)
+	assert.Equal(t, "token", nextPageToken)
+}
+
+func TestMemoryCacheExpires(t *testing.T) {
+	/* Arrange */
+	now := time.Date(2024, 10, 5, 12, 0, 0, 0, time.UTC)
+	mc := NewMemoryCache(10)
+	mc.now = func() time.Time { return now }
+	mc.Set("key", []byte("value"), time.Minute)
+
+	/* Act */
+	_, beforeExpiry := mc.Get("key")
+	now = now.Add(time.Minute)
+	_, afterExpiry := mc.Get("key")
+
+	/* Assert */
+	// TTLを過ぎたものは取得できないこと
+	assert.True(t, beforeExpiry)
+	assert.False(t, afterExpiry)
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	/* Arrange */
+	mc := NewMemoryCache(2)
+	mc.Set("a", []byte("1"), time.Minute)
+	mc.Set("b", []byte("2"), time.Minute)
+	mc.Get("a")
+
+	/* Act */
+	mc.Set("c", []byte("3"), time.Minute)
+
+	/* Assert */
+	// 上限を超えた場合は最も長く使われていないものが削除されること
+	_, hasA := mc.Get("a")
+	_, hasB := mc.Get("b")
+	_, hasC := mc.Get("c")
+	assert.True(t, hasA)
+	assert.False(t, hasB)
+	assert.True(t, hasC)
+}
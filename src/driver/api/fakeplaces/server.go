@@ -0,0 +1,414 @@
+// Geolocation APIとPlaces API(New)のうち、このアプリが使う部分だけを再現するサーバ。
+// APIキーを使わずに開発したり、実際のドライバを通したテストを行うために使う
+package fakeplaces
+
+import (
+	_ "embed"
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed fixtures/places.json
+var defaultFixtures []byte
+
+// searchNearby・searchTextで一度に返せる最大の件数
+const maxResultCount = 20
+
+// フィールドマスクに指定できる項目(Place Detailsでの形式)
+var knownFields = map[string]bool{
+	"id":                  true,
+	"displayName":         true,
+	"displayName.text":    true,
+	"regularOpeningHours": true,
+	"regularOpeningHours.weekdayDescriptions": true,
+	"regularOpeningHours.periods":             true,
+	"utcOffsetMinutes":                        true,
+	"priceLevel":                              true,
+	"location":                                true,
+	"types":                                   true,
+	"formattedAddress":                        true,
+	"nationalPhoneNumber":                     true,
+	"websiteUri":                              true,
+	"rating":                                  true,
+	"userRatingCount":                         true,
+	"businessStatus":                          true,
+}
+
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Places APIのPlaceと同じ形式のJSONをそのまま保持する
+type Place map[string]interface{}
+
+type Fixtures struct {
+	Location LatLng  `json:"location"` // geolocateで返す位置
+	Places   []Place `json:"places"`
+}
+
+type Server struct {
+	apiKey   string
+	fixtures Fixtures
+	mux      *http.ServeMux
+}
+
+// 組み込みのフィクスチャを返すサーバを作成する
+func NewServer(apiKey string) *Server {
+	fixtures, err := LoadFixtures(defaultFixtures)
+	if err != nil {
+		panic(err)
+	}
+	return NewServerWithFixtures(apiKey, fixtures)
+}
+
+func NewServerWithFixtures(apiKey string, fixtures *Fixtures) *Server {
+	s := &Server{apiKey: apiKey, fixtures: *fixtures, mux: http.NewServeMux()}
+	s.mux.HandleFunc("POST /geolocation/v1/geolocate", s.geolocate)
+	s.mux.HandleFunc("POST /v1/places:searchNearby", s.searchNearby)
+	s.mux.HandleFunc("POST /v1/places:searchText", s.searchText)
+	s.mux.HandleFunc("GET /v1/places/{id}", s.getPlace)
+	return s
+}
+
+func LoadFixtures(data []byte) (*Fixtures, error) {
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, err
+	}
+	return &fixtures, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// serverUrlで起動したサーバに接続する場合にドライバへ渡す接続先
+func GeolocationBaseUrl(serverUrl string) string {
+	return strings.TrimRight(serverUrl, "/") + "/geolocation/v1"
+}
+
+func PlacesBaseUrl(serverUrl string) string {
+	return strings.TrimRight(serverUrl, "/") + "/v1"
+}
+
+func (s *Server) geolocate(w http.ResponseWriter, r *http.Request) {
+	// Geolocation APIはAPIキーをクエリパラメータで受け取る
+	if r.URL.Query().Get("key") != s.apiKey {
+		writeError(w, http.StatusBadRequest, "API key not valid. Please pass a valid API key.", "INVALID_ARGUMENT")
+		return
+	}
+	writeJson(w, map[string]interface{}{
+		"location": s.fixtures.Location,
+		"accuracy": 100.0,
+	})
+}
+
+type circle struct {
+	Center struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+	} `json:"center"`
+	Radius float64 `json:"radius"`
+}
+
+type searchNearbyRequest struct {
+	IncludedTypes       []string `json:"includedTypes"`
+	MaxResultCount      int      `json:"maxResultCount"`
+	RankPreference      string   `json:"rankPreference"`
+	LocationRestriction struct {
+		Circle *circle `json:"circle"`
+	} `json:"locationRestriction"`
+}
+
+func (s *Server) searchNearby(w http.ResponseWriter, r *http.Request) {
+	fields, ok := s.authorize(w, r, "places.", false)
+	if !ok {
+		return
+	}
+	var req searchNearbyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON payload received.", "INVALID_ARGUMENT")
+		return
+	}
+	if req.LocationRestriction.Circle == nil {
+		writeError(w, http.StatusBadRequest, "locationRestriction is required.", "INVALID_ARGUMENT")
+		return
+	}
+	if req.MaxResultCount < 0 || req.MaxResultCount > maxResultCount {
+		writeError(w, http.StatusBadRequest, "maxResultCount must be between 1 and 20.", "INVALID_ARGUMENT")
+		return
+	}
+	if req.MaxResultCount == 0 {
+		req.MaxResultCount = maxResultCount
+	}
+	c := req.LocationRestriction.Circle
+	// 人気順はフィクスチャの順番とする
+	candidates := s.fixtures.Places
+	if req.RankPreference == "DISTANCE" {
+		candidates = s.sortedByDistance(c)
+	}
+	places := make([]Place, 0)
+	for _, p := range candidates {
+		if distance(c, p) > c.Radius {
+			continue
+		}
+		if len(req.IncludedTypes) > 0 && !hasAnyType(p, req.IncludedTypes) {
+			continue
+		}
+		places = append(places, p)
+	}
+	if len(places) > req.MaxResultCount {
+		places = places[:req.MaxResultCount]
+	}
+	writeJson(w, searchResponse(places, "", fields))
+}
+
+type searchTextRequest struct {
+	TextQuery    string   `json:"textQuery"`
+	PageSize     int      `json:"pageSize"`
+	PageToken    string   `json:"pageToken"`
+	IncludedType string   `json:"includedType"`
+	PriceLevels  []string `json:"priceLevels"`
+	LocationBias struct {
+		Circle *circle `json:"circle"`
+	} `json:"locationBias"`
+}
+
+func (s *Server) searchText(w http.ResponseWriter, r *http.Request) {
+	fields, ok := s.authorize(w, r, "places.", true)
+	if !ok {
+		return
+	}
+	var req searchTextRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON payload received.", "INVALID_ARGUMENT")
+		return
+	}
+	if strings.TrimSpace(req.TextQuery) == "" {
+		writeError(w, http.StatusBadRequest, "textQuery is required.", "INVALID_ARGUMENT")
+		return
+	}
+	if req.PageSize < 0 || req.PageSize > maxResultCount {
+		writeError(w, http.StatusBadRequest, "pageSize must be between 1 and 20.", "INVALID_ARGUMENT")
+		return
+	}
+	if req.PageSize == 0 {
+		req.PageSize = maxResultCount
+	}
+	// ページトークンは読み飛ばす件数をそのまま使う
+	offset := 0
+	if req.PageToken != "" {
+		o, err := strconv.Atoi(req.PageToken)
+		if err != nil || o < 0 {
+			writeError(w, http.StatusBadRequest, "pageToken is invalid.", "INVALID_ARGUMENT")
+			return
+		}
+		offset = o
+	}
+	candidates := s.fixtures.Places
+	if req.LocationBias.Circle != nil {
+		candidates = s.sortedByDistance(req.LocationBias.Circle)
+	}
+	matched := make([]Place, 0)
+	for _, p := range candidates {
+		if !matchesText(p, req.TextQuery) {
+			continue
+		}
+		if req.IncludedType != "" && !hasAnyType(p, []string{req.IncludedType}) {
+			continue
+		}
+		if len(req.PriceLevels) > 0 && !contains(req.PriceLevels, stringField(p, "priceLevel")) {
+			continue
+		}
+		matched = append(matched, p)
+	}
+	nextPageToken := ""
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	end := offset + req.PageSize
+	if end < len(matched) {
+		nextPageToken = strconv.Itoa(end)
+	} else {
+		end = len(matched)
+	}
+	writeJson(w, searchResponse(matched[offset:end], nextPageToken, fields))
+}
+
+func (s *Server) getPlace(w http.ResponseWriter, r *http.Request) {
+	fields, ok := s.authorize(w, r, "", false)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	for _, p := range s.fixtures.Places {
+		if stringField(p, "id") == id {
+			writeJson(w, applyFieldMask(p, fields))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Requested entity was not found.", "NOT_FOUND")
+}
+
+// APIキーとフィールドマスクを確認し、フィールドマスクの項目をprefixを除いた形式で返す。
+// 問題があればエラーのレスポンスを書き込みfalseを返す
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, prefix string, allowNextPageToken bool) ([]string, bool) {
+	if r.Header.Get("X-Goog-Api-Key") != s.apiKey {
+		writeError(w, http.StatusForbidden, "API key not valid. Please pass a valid API key.", "PERMISSION_DENIED")
+		return nil, false
+	}
+	mask := r.Header.Get("X-Goog-FieldMask")
+	if mask == "" {
+		writeError(w, http.StatusBadRequest, "FieldMask is a required parameter.", "INVALID_ARGUMENT")
+		return nil, false
+	}
+	fields := make([]string, 0)
+	for _, path := range strings.Split(mask, ",") {
+		path = strings.TrimSpace(path)
+		if allowNextPageToken && path == "nextPageToken" {
+			continue
+		}
+		field, ok := strings.CutPrefix(path, prefix)
+		if !ok || !knownFields[field] {
+			writeError(w, http.StatusBadRequest, "Invalid field: "+path, "INVALID_ARGUMENT")
+			return nil, false
+		}
+		fields = append(fields, field)
+	}
+	return fields, true
+}
+
+func searchResponse(places []Place, nextPageToken string, fields []string) map[string]interface{} {
+	masked := make([]Place, 0, len(places))
+	for _, p := range places {
+		masked = append(masked, applyFieldMask(p, fields))
+	}
+	// 本物のAPIと同じく、結果が無い場合はplacesを含めない
+	response := map[string]interface{}{}
+	if len(masked) > 0 {
+		response["places"] = masked
+	}
+	if nextPageToken != "" {
+		response["nextPageToken"] = nextPageToken
+	}
+	return response
+}
+
+// フィールドマスクに含まれる項目のみを残したコピーを返す
+func applyFieldMask(place Place, fields []string) Place {
+	masked := Place{}
+	for _, field := range fields {
+		copyPath(masked, place, strings.Split(field, "."))
+	}
+	return masked
+}
+
+func copyPath(dst map[string]interface{}, src map[string]interface{}, path []string) {
+	value, ok := src[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		dst[path[0]] = value
+		return
+	}
+	child, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	dstChild, ok := dst[path[0]].(map[string]interface{})
+	if !ok {
+		dstChild = map[string]interface{}{}
+		dst[path[0]] = dstChild
+	}
+	copyPath(dstChild, child, path[1:])
+}
+
+func (s *Server) sortedByDistance(c *circle) []Place {
+	places := append([]Place{}, s.fixtures.Places...)
+	sort.SliceStable(places, func(i, j int) bool {
+		return distance(c, places[i]) < distance(c, places[j])
+	})
+	return places
+}
+
+// 円の中心から店舗までの距離(メートル)
+func distance(c *circle, p Place) float64 {
+	const earthRadius = 6371000.0
+	location, _ := p["location"].(map[string]interface{})
+	lat, _ := location["latitude"].(float64)
+	lng, _ := location["longitude"].(float64)
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat - c.Center.Latitude)
+	dLng := toRad(lng - c.Center.Longitude)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(c.Center.Latitude))*math.Cos(toRad(lat))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// 店名・住所・種類のいずれかにキーワードの全ての語が含まれていれば一致とする
+func matchesText(p Place, textQuery string) bool {
+	displayName, _ := p["displayName"].(map[string]interface{})
+	name, _ := displayName["text"].(string)
+	types, _ := p["types"].([]interface{})
+	haystack := []string{strings.ToLower(name), strings.ToLower(stringField(p, "formattedAddress"))}
+	for _, t := range types {
+		if s, ok := t.(string); ok {
+			haystack = append(haystack, s)
+		}
+	}
+	text := strings.Join(haystack, " ")
+	for _, word := range strings.Fields(strings.ToLower(textQuery)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAnyType(p Place, includedTypes []string) bool {
+	types, _ := p["types"].([]interface{})
+	for _, t := range types {
+		if s, ok := t.(string); ok && contains(includedTypes, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringField(p Place, key string) string {
+	s, _ := p[key].(string)
+	return s
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJson(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	json.NewEncoder(w).Encode(body)
+}
+
+// Google APIと同じ形式のエラーを返す
+func writeError(w http.ResponseWriter, code int, message string, status string) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+			"status":  status,
+		},
+	})
+}
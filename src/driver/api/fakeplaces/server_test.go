@@ -0,0 +1,72 @@
+package fakeplaces
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func postSearchNearby(s *Server, apiKey string, fieldMask string) *httptest.ResponseRecorder {
+	body := `{"maxResultCount": 20, "locationRestriction": {"circle": {"center": {"latitude": 35.713, "longitude": 139.762}, "radius": 500}}}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/places:searchNearby", strings.NewReader(body))
+	req.Header.Set("X-Goog-Api-Key", apiKey)
+	if fieldMask != "" {
+		req.Header.Set("X-Goog-FieldMask", fieldMask)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSearchNearbyAppliesFieldMask(t *testing.T) {
+	/* Arrange */
+	s := NewServer("key")
+
+	/* Act */
+	rec := postSearchNearby(s, "key", "places.id,places.displayName.text")
+
+	/* Assert */
+	assert.Equal(t, http.StatusOK, rec.Code)
+	// フィールドマスクに含まれない項目は返さないこと
+	assert.Contains(t, rec.Body.String(), `"id":"ChIJfake_uec_cafe_0001"`)
+	assert.Contains(t, rec.Body.String(), `"displayName":{"text":"UEC cafe"}`)
+	assert.NotContains(t, rec.Body.String(), "priceLevel")
+}
+
+func TestSearchNearbyRequiresFieldMask(t *testing.T) {
+	/* Arrange */
+	s := NewServer("key")
+
+	/* Act */
+	rec := postSearchNearby(s, "key", "")
+
+	/* Assert */
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSearchNearbyRejectsUnknownField(t *testing.T) {
+	/* Arrange */
+	s := NewServer("key")
+
+	/* Act */
+	// Place Detailsの形式(places.なし)はsearchNearbyでは使えないこと
+	rec := postSearchNearby(s, "key", "id,places.displayName")
+
+	/* Assert */
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSearchNearbyRejectsInvalidApiKey(t *testing.T) {
+	/* Arrange */
+	s := NewServer("key")
+
+	/* Act */
+	rec := postSearchNearby(s, "wrong", "places.id")
+
+	/* Assert */
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "PERMISSION_DENIED")
+}
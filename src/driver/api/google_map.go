@@ -6,10 +6,26 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"net/url"
+	"strings"
+	"time"
 )
 
-type ApiGoogleMapDriver struct{}
+// 接続先とHTTPクライアントを差し替えられるようにしておくことで、オフラインでもfakeplacesに接続して動かせる
+type ApiGoogleMapDriver struct {
+	client             *http.Client
+	geolocationBaseUrl string
+	placesBaseUrl      string
+	apiKey             string
+}
+
+const (
+	DefaultGeolocationBaseUrl = "https://www.googleapis.com/geolocation/v1"
+	DefaultPlacesBaseUrl      = "https://places.googleapis.com/v1"
+)
+
+// searchNearbyで一度に取得できる最大の件数(ページトークンは返されない)
+const MaxResultCount = 20
 
 type Location struct {
 	Lat float64 `json:"latitude"`
@@ -24,49 +40,151 @@ type GeolocationApiResponse struct {
 	Accuracy float64 `json:"accuracy"`
 }
 
+// 営業時間の開店・閉店時刻(dayは0が日曜日)
+type Point struct {
+	Day    int `json:"day"`
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
+}
+
+// closeが存在しない場合は24時間営業
+type Period struct {
+	Open  Point  `json:"open"`
+	Close *Point `json:"close"`
+}
+
+type Place struct {
+	Id          string `json:"id"`
+	DisplayName struct {
+		Text string `json:"text"`
+	} `json:"displayName"`
+	RegularOpeningHours struct {
+		WeekdayDescriptions []string `json:"weekdayDescriptions"`
+		Periods             []Period `json:"periods"`
+	} `json:"regularOpeningHours"`
+	UtcOffsetMinutes int      `json:"utcOffsetMinutes"`
+	PriceLevel       string   `json:"priceLevel"`
+	Location         Location `json:"location"`
+	Types            []string `json:"types"`
+	// Place Detailsでのみ取得する項目
+	FormattedAddress    string  `json:"formattedAddress"`
+	NationalPhoneNumber string  `json:"nationalPhoneNumber"`
+	WebsiteUri          string  `json:"websiteUri"`
+	Rating              float64 `json:"rating"`
+	UserRatingCount     int     `json:"userRatingCount"`
+	BusinessStatus      string  `json:"businessStatus"`
+}
+
 type PlacesApiResponse struct {
-	Places []struct {
-		Id          string `json:"id"`
-		DisplayName struct {
-			Text string `json:"text"`
-		} `json:"displayName"`
-		RegularOpeningHours struct {
-			WeekdayDescriptions []string `json:"weekdayDescriptions"`
-		} `json:"regularOpeningHours"`
-		PriceLevel string   `json:"priceLevel"`
-		Location   Location `json:"location"`
-	} `json:"places"`
+	Places        []Place `json:"places"`
+	NextPageToken string  `json:"nextPageToken"`
+}
+
+type Circle struct {
+	Center Location `json:"center"`
+	Radius float64  `json:"radius"`
+}
+
+type StoreDetail struct {
+	Store
+	Address         string
+	PhoneNumber     string
+	WebsiteUri      string
+	Rating          float64
+	UserRatingCount int
+	BusinessStatus  string
+}
+
+// searchTextの検索条件
+type TextSearchQuery struct {
+	TextQuery     string
+	Bias          *Circle  // 指定した円の周辺の店舗を優先する(nilの場合は指定しない)
+	PriceLevels   []string // PRICE_LEVEL_MODERATEなどの形式
+	IncludedTypes []string // cafe, restaurantなどの形式
+	PageSize      int
+	PageToken     string
 }
 
 type Store struct {
 	Id                  string   `json:"places.id"`
 	Name                string   `json:"places.displayName.text"`
 	RegularOpeningHours []string `json:"places.regularOpeningHours.weekdayDescriptions"`
+	OpeningPeriods      []Period `json:"places.regularOpeningHours.periods"`
+	UtcOffsetMinutes    int      `json:"places.utcOffsetMinutes"`
 	PriceLevel          string   `json:"places.priceLevel"`
 	Location            Location `json:"places.location"`
+	Types               []string `json:"places.types"`
 }
 
-func NewGoogleMapDriver() *ApiGoogleMapDriver {
-	return &ApiGoogleMapDriver{}
+// 空の値を渡した場合はGoogleのAPIに接続する
+func NewGoogleMapDriver(client *http.Client, geolocationBaseUrl string, placesBaseUrl string, apiKey string) *ApiGoogleMapDriver {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if geolocationBaseUrl == "" {
+		geolocationBaseUrl = DefaultGeolocationBaseUrl
+	}
+	if placesBaseUrl == "" {
+		placesBaseUrl = DefaultPlacesBaseUrl
+	}
+	return &ApiGoogleMapDriver{
+		client:             client,
+		geolocationBaseUrl: strings.TrimRight(geolocationBaseUrl, "/"),
+		placesBaseUrl:      strings.TrimRight(placesBaseUrl, "/"),
+		apiKey:             apiKey,
+	}
 }
 
-func (ap *ApiGoogleMapDriver) GetStores() ([]*Store, error) {
-	location, err := getCurrentLocation()
+// 指定された位置を中心とした半径radius(メートル)以内の店舗を最大maxResultCount件取得する。
+// rankByDistanceがfalseの場合は人気順となる
+func (ap *ApiGoogleMapDriver) GetStores(location Location, radius float64, maxResultCount int, rankByDistance bool) ([]*Store, error) {
+	if maxResultCount < 1 || maxResultCount > MaxResultCount {
+		maxResultCount = MaxResultCount
+	}
+	stores, err := ap.searchStoresNearby(location, radius, maxResultCount, rankByDistance)
 	if err != nil {
 		fmt.Println("Error:", err)
 		return make([]*Store, 0), err
 	}
-	stores, err := searchStoresNearby(location)
+	return stores, nil
+}
+
+// 検索の中心がリクエストで指定されなかった場合のみ使用する(サーバの位置が返ってくる)
+func (ap *ApiGoogleMapDriver) GetCurrentLocation() (Location, error) {
+	location, err := ap.getCurrentLocation()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return Location{}, err
+	}
+	return location, nil
+}
+
+// キーワードで店舗を検索し、次のページがあればそのページトークンも返す
+func (ap *ApiGoogleMapDriver) SearchStores(query TextSearchQuery) ([]*Store, string, error) {
+	if query.PageSize < 1 || query.PageSize > MaxResultCount {
+		query.PageSize = MaxResultCount
+	}
+	stores, nextPageToken, err := ap.searchStoresByText(query)
 	if err != nil {
 		fmt.Println("Error:", err)
-		return make([]*Store, 0), err
+		return make([]*Store, 0), "", err
 	}
-	return stores, nil
+	return stores, nextPageToken, nil
+}
+
+// 店舗の詳細を取得する。存在しない店舗の場合はnilを返す
+func (ap *ApiGoogleMapDriver) GetStoreDetail(id string) (*StoreDetail, error) {
+	detail, err := ap.getPlaceDetail(id)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+	return detail, nil
 }
 
-func getCurrentLocation() (Location, error) {
-	resp, err := http.Post(
-		"https://www.googleapis.com/geolocation/v1/geolocate?key="+os.Getenv("GOOGLE_MAP_API_KEY"),
+func (ap *ApiGoogleMapDriver) getCurrentLocation() (Location, error) {
+	resp, err := ap.client.Post(
+		ap.geolocationBaseUrl+"/geolocate?key="+url.QueryEscape(ap.apiKey),
 		"application/json",
 		nil,
 	)
@@ -78,6 +196,9 @@ func getCurrentLocation() (Location, error) {
 	if err != nil {
 		return Location{}, err
 	}
+	if resp.StatusCode != http.StatusOK {
+		return Location{}, fmt.Errorf("geolocation api returned status %d: %s", resp.StatusCode, body)
+	}
 	var response GeolocationApiResponse
 	err = json.Unmarshal(body, &response)
 	if err != nil {
@@ -90,32 +211,133 @@ func getCurrentLocation() (Location, error) {
 	return location, nil
 }
 
-func searchStoresNearby(location Location) ([]*Store, error) {
-	requestBody := fmt.Sprintf(`{
-		"includedTypes": ["cafe", "restaurant"],
-		"maxResultCount": 10,
+func (ap *ApiGoogleMapDriver) searchStoresNearby(location Location, radius float64, maxResultCount int, rankByDistance bool) ([]*Store, error) {
+	rankPreference := "POPULARITY"
+	if rankByDistance {
+		rankPreference = "DISTANCE"
+	}
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"includedTypes":  []string{"cafe", "restaurant"},
+		"maxResultCount": maxResultCount,
+		"rankPreference": rankPreference,
+		"languageCode":   "ja",
+		"regionCode":     "JP",
+		"locationRestriction": map[string]interface{}{
+			"circle": Circle{Center: location, Radius: radius},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	placeResponse, err := ap.postPlaces(
+		ap.placesBaseUrl+"/places:searchNearby",
+		"places.id,places.displayName,places.regularOpeningHours.weekdayDescriptions,places.regularOpeningHours.periods,places.utcOffsetMinutes,places.priceLevel,places.location",
+		requestBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return toStores(placeResponse.Places), nil
+}
+
+func (ap *ApiGoogleMapDriver) searchStoresByText(query TextSearchQuery) ([]*Store, string, error) {
+	requestBody := map[string]interface{}{
+		"textQuery":    query.TextQuery,
+		"pageSize":     query.PageSize,
 		"languageCode": "ja",
-		"regionCode": "JP",
-		"locationRestriction": {
-			"circle": {
-				"center": {"latitude": "%f", "longitude": "%f"},
-				"radius": 500.0
-			}
+		"regionCode":   "JP",
+	}
+	if query.PageToken != "" {
+		requestBody["pageToken"] = query.PageToken
+	}
+	if query.Bias != nil {
+		requestBody["locationBias"] = map[string]interface{}{"circle": query.Bias}
+	}
+	if len(query.PriceLevels) > 0 {
+		requestBody["priceLevels"] = query.PriceLevels
+	}
+	// searchTextで指定できる種類は1つだけなので、複数の場合は取得後に絞り込む
+	if len(query.IncludedTypes) == 1 {
+		requestBody["includedType"] = query.IncludedTypes[0]
+	}
+	encoded, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, "", err
+	}
+	placeResponse, err := ap.postPlaces(
+		ap.placesBaseUrl+"/places:searchText",
+		"places.id,places.displayName,places.regularOpeningHours.weekdayDescriptions,places.regularOpeningHours.periods,places.utcOffsetMinutes,places.priceLevel,places.location,places.types,nextPageToken",
+		encoded,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	stores := make([]*Store, 0)
+	for _, store := range toStores(placeResponse.Places) {
+		if len(query.IncludedTypes) > 1 && !hasAnyType(store.Types, query.IncludedTypes) {
+			continue
 		}
-	}`, location.Lat, location.Lng)
-	client := &http.Client{}
+		stores = append(stores, store)
+	}
+	return stores, placeResponse.NextPageToken, nil
+}
+
+func (ap *ApiGoogleMapDriver) getPlaceDetail(id string) (*StoreDetail, error) {
+	req, err := http.NewRequest(
+		"GET",
+		ap.placesBaseUrl+"/places/"+url.PathEscape(id)+"?languageCode=ja&regionCode=JP",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Goog-Api-Key", ap.apiKey)
+	req.Header.Set("X-Goog-FieldMask", "id,displayName,regularOpeningHours.weekdayDescriptions,regularOpeningHours.periods,utcOffsetMinutes,priceLevel,location,types,formattedAddress,nationalPhoneNumber,websiteUri,rating,userRatingCount,businessStatus")
+	resp, err := ap.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// 存在しないidや形式の誤ったidの場合はエラーではなく見つからなかったものとする
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("places api returned status %d: %s", resp.StatusCode, body)
+	}
+	var place Place
+	if err := json.Unmarshal(body, &place); err != nil {
+		return nil, err
+	}
+	return &StoreDetail{
+		Store:           *toStores([]Place{place})[0],
+		Address:         place.FormattedAddress,
+		PhoneNumber:     place.NationalPhoneNumber,
+		WebsiteUri:      place.WebsiteUri,
+		Rating:          place.Rating,
+		UserRatingCount: place.UserRatingCount,
+		BusinessStatus:  place.BusinessStatus,
+	}, nil
+}
+
+// Places APIにPOSTし、必要なフィールドのみを含んだレスポンスを返す
+func (ap *ApiGoogleMapDriver) postPlaces(endpoint string, fieldMask string, requestBody []byte) (*PlacesApiResponse, error) {
 	req, err := http.NewRequest(
 		"POST",
-		"https://places.googleapis.com/v1/places:searchNearby",
-		bytes.NewBuffer([]byte(requestBody)),
+		endpoint,
+		bytes.NewBuffer(requestBody),
 	)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Goog-Api-Key", os.Getenv("GOOGLE_MAP_API_KEY"))
-	req.Header.Set("X-Goog-FieldMask", "places.id,places.displayName,places.regularOpeningHours.weekdayDescriptions,places.priceLevel,places.location")
-	resp, err := client.Do(req)
+	req.Header.Set("X-Goog-Api-Key", ap.apiKey)
+	req.Header.Set("X-Goog-FieldMask", fieldMask)
+	resp, err := ap.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -124,23 +346,44 @@ func searchStoresNearby(location Location) ([]*Store, error) {
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("places api returned status %d: %s", resp.StatusCode, body)
+	}
 	var placeResponse PlacesApiResponse
 	err = json.Unmarshal(body, &placeResponse)
 	if err != nil {
 		return nil, err
 	}
+	return &placeResponse, nil
+}
+
+func toStores(places []Place) []*Store {
 	stores := make([]*Store, 0)
-	for _, place := range placeResponse.Places {
+	for _, place := range places {
 		stores = append(stores, &Store{
 			Id:                  place.Id,
 			Name:                place.DisplayName.Text,
 			RegularOpeningHours: place.RegularOpeningHours.WeekdayDescriptions,
+			OpeningPeriods:      place.RegularOpeningHours.Periods,
+			UtcOffsetMinutes:    place.UtcOffsetMinutes,
 			PriceLevel:          place.PriceLevel,
 			Location: Location{
 				Lat: place.Location.Lat,
 				Lng: place.Location.Lng,
 			},
+			Types: place.Types,
 		})
 	}
-	return stores, nil
+	return stores
+}
+
+func hasAnyType(types []string, includedTypes []string) bool {
+	for _, t := range types {
+		for _, included := range includedTypes {
+			if t == included {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"clean-storemap-api/src/driver/api/fakeplaces"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testApiKey = "test-api-key"
+
+// fakeplacesのサーバに接続したドライバを作成する
+func newFakeGoogleMapDriver(t *testing.T, apiKey string) *ApiGoogleMapDriver {
+	server := httptest.NewServer(fakeplaces.NewServer(testApiKey))
+	t.Cleanup(server.Close)
+	return NewGoogleMapDriver(
+		server.Client(),
+		fakeplaces.GeolocationBaseUrl(server.URL),
+		fakeplaces.PlacesBaseUrl(server.URL),
+		apiKey,
+	)
+}
+
+func TestGetCurrentLocation(t *testing.T) {
+	/* Arrange */
+	ap := newFakeGoogleMapDriver(t, testApiKey)
+
+	/* Act */
+	location, err := ap.GetCurrentLocation()
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Equal(t, Location{Lat: 35.713, Lng: 139.762}, location)
+}
+
+func TestGetStores(t *testing.T) {
+	/* Arrange */
+	ap := newFakeGoogleMapDriver(t, testApiKey)
+
+	/* Act */
+	stores, err := ap.GetStores(Location{Lat: 35.713, Lng: 139.762}, 500, 20, false)
+
+	/* Assert */
+	assert.NoError(t, err)
+	// 半径内の店舗のみが返ること
+	ids := make([]string, 0)
+	for _, s := range stores {
+		ids = append(ids, s.Id)
+	}
+	assert.Equal(t, []string{"ChIJfake_uec_cafe_0001", "ChIJfake_uec_restaurant_0002", "ChIJfake_hongo_coffee_0003"}, ids)
+	assert.Equal(t, "UEC cafe", stores[0].Name)
+	assert.Equal(t, "PRICE_LEVEL_MODERATE", stores[0].PriceLevel)
+	assert.Equal(t, 540, stores[0].UtcOffsetMinutes)
+	assert.Len(t, stores[0].RegularOpeningHours, 7)
+	assert.Len(t, stores[0].OpeningPeriods, 6)
+	// 24時間営業の店舗は閉店時刻がないこと
+	assert.Nil(t, stores[2].OpeningPeriods[0].Close)
+}
+
+func TestGetStoresRankByDistance(t *testing.T) {
+	/* Arrange */
+	ap := newFakeGoogleMapDriver(t, testApiKey)
+
+	/* Act */
+	stores, err := ap.GetStores(Location{Lat: 35.7105, Lng: 139.7601}, 1000, 20, true)
+
+	/* Assert */
+	assert.NoError(t, err)
+	// 中心から近い順に返ること
+	ids := make([]string, 0)
+	for _, s := range stores {
+		ids = append(ids, s.Id)
+	}
+	assert.Equal(t, []string{"ChIJfake_hongo_coffee_0003", "ChIJfake_uec_cafe_0001", "ChIJfake_uec_restaurant_0002", "ChIJfake_yushima_sushi_0004"}, ids)
+}
+
+func TestGetStoresWithMaxResultCount(t *testing.T) {
+	/* Arrange */
+	ap := newFakeGoogleMapDriver(t, testApiKey)
+
+	/* Act */
+	stores, err := ap.GetStores(Location{Lat: 35.713, Lng: 139.762}, 500, 1, false)
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Len(t, stores, 1)
+}
+
+func TestGetStoresWithInvalidApiKey(t *testing.T) {
+	/* Arrange */
+	ap := newFakeGoogleMapDriver(t, "wrong-api-key")
+
+	/* Act */
+	stores, err := ap.GetStores(Location{Lat: 35.713, Lng: 139.762}, 500, 20, false)
+
+	/* Assert */
+	assert.Error(t, err)
+	assert.Empty(t, stores)
+}
+
+func TestSearchStoresWithPageToken(t *testing.T) {
+	/* Arrange */
+	ap := newFakeGoogleMapDriver(t, testApiKey)
+	query := TextSearchQuery{TextQuery: "cafe", PageSize: 2}
+
+	/* Act */
+	first, nextPageToken, err1 := ap.SearchStores(query)
+	query.PageToken = nextPageToken
+	second, lastPageToken, err2 := ap.SearchStores(query)
+
+	/* Assert */
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Len(t, first, 2)
+	assert.NotEmpty(t, nextPageToken)
+	// 最後のページではページトークンが返らないこと
+	assert.Len(t, second, 1)
+	assert.Empty(t, lastPageToken)
+	assert.NotEqual(t, first[0].Id, second[0].Id)
+}
+
+func TestSearchStoresWithConditions(t *testing.T) {
+	/* Arrange */
+	ap := newFakeGoogleMapDriver(t, testApiKey)
+
+	/* Act */
+	stores, nextPageToken, err := ap.SearchStores(TextSearchQuery{
+		TextQuery:     "本郷",
+		Bias:          &Circle{Center: Location{Lat: 35.713, Lng: 139.762}, Radius: 1000},
+		PriceLevels:   []string{"PRICE_LEVEL_INEXPENSIVE"},
+		IncludedTypes: []string{"cafe", "bakery"},
+	})
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Empty(t, nextPageToken)
+	assert.Len(t, stores, 1)
+	assert.Equal(t, "ChIJfake_hongo_coffee_0003", stores[0].Id)
+	assert.Contains(t, stores[0].Types, "cafe")
+}
+
+func TestGetStoreDetail(t *testing.T) {
+	/* Arrange */
+	ap := newFakeGoogleMapDriver(t, testApiKey)
+
+	/* Act */
+	detail, err := ap.GetStoreDetail("ChIJfake_yushima_sushi_0004")
+
+	/* Assert */
+	assert.NoError(t, err)
+	assert.Equal(t, "湯島寿司", detail.Name)
+	assert.Equal(t, "東京都文京区湯島3-1-1", detail.Address)
+	assert.Equal(t, "03-0000-0004", detail.PhoneNumber)
+	assert.Equal(t, 4.7, detail.Rating)
+	assert.Equal(t, 88, detail.UserRatingCount)
+	assert.Equal(t, "OPERATIONAL", detail.BusinessStatus)
+}
+
+func TestGetStoreDetailNotFound(t *testing.T) {
+	/* Arrange */
+	ap := newFakeGoogleMapDriver(t, testApiKey)
+
+	/* Act */
+	detail, err := ap.GetStoreDetail("ChIJunknown")
+
+	/* Assert */
+	// 存在しない店舗はエラーではなくnilを返すこと
+	assert.NoError(t, err)
+	assert.Nil(t, detail)
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DbCheckinDriver struct{}
+
+func NewCheckinDriver() *DbCheckinDriver {
+	return &DbCheckinDriver{}
+}
+
+// ユーザーが店舗を訪れた記録。同じ店舗に何度でも記録できる
+type Checkin struct {
+	Id        uint      `gorm:"primaryKey"`
+	UserId    string    `gorm:"not null;size:64;index:idx_checkins_user_visited_at,priority:1"`
+	User      User      `gorm:"foreignKey:UserId;references:Id"`
+	StoreId   string    `gorm:"not null;size:255;index"`
+	Store     Store     `gorm:"foreignKey:StoreId;references:Id"`
+	VisitedAt time.Time `gorm:"not null;index:idx_checkins_user_visited_at,priority:2"`
+	PartySize int       // 指定されなかった場合は0
+	CreatedAt time.Time
+}
+
+// ユーザーの店舗ごとの訪問の集計結果
+type VisitSummary struct {
+	StoreId       string
+	Count         int
+	LastVisitedAt time.Time
+}
+
+// ユーザーの訪問履歴を訪問日時の新しい順にoffsetから最大limit件、店舗の情報と合わせて返す
+func (dbc *DbCheckinDriver) FindByUser(userId string, offset int, limit int) ([]*Checkin, error) {
+	var checkins []*Checkin
+	err := DB.Preload("Store").
+		Where("user_id = ?", userId).
+		Order("visited_at desc, id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&checkins).Error
+	if err != nil {
+		return nil, err
+	}
+	return checkins, nil
+}
+
+// 店舗の情報として最後に保存された時点のものを返す。一度も保存されていない場合はnil
+func (dbc *DbCheckinDriver) FindStore(storeId string) (*Store, error) {
+	return (&DbStoreDriver{}).FindStore(storeId)
+}
+
+// 店舗の情報を最新のものに更新してから訪問を記録する
+func (dbc *DbCheckinDriver) Create(store *Store, checkin *Checkin) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := upsertStore(tx, store); err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(checkin).Error
+	})
+}
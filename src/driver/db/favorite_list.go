@@ -0,0 +1,120 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DbFavoriteListDriver struct{}
+
+func NewFavoriteListDriver() *DbFavoriteListDriver {
+	return &DbFavoriteListDriver{}
+}
+
+// ユーザーが名前を付けて作成した店舗のリスト
+type FavoriteList struct {
+	Id          string              `gorm:"primaryKey;size:64"`
+	UserId      string              `gorm:"not null;size:64;index"`
+	User        User                `gorm:"foreignKey:UserId;references:Id"`
+	Name        string              `gorm:"not null"`
+	Description string              `gorm:"type:text"`
+	Position    int                 `gorm:"not null"`
+	Stores      []FavoriteListStore `gorm:"foreignKey:FavoriteListId;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// リストに追加された店舗。同じ店舗を同じリストに複数回追加することはできない
+type FavoriteListStore struct {
+	FavoriteListId string `gorm:"primaryKey;size:64"`
+	StoreId        string `gorm:"primaryKey;size:255;index"`
+	Store          Store  `gorm:"foreignKey:StoreId;references:Id"`
+	CreatedAt      time.Time
+}
+
+// ユーザーのリストを並び順に、店舗を追加した順に含めて返す
+func (dbf *DbFavoriteListDriver) FindListsByUser(userId string) ([]*FavoriteList, error) {
+	var lists []*FavoriteList
+	err := preloadListStores(DB).Where("user_id = ?", userId).Order("position, created_at").Find(&lists).Error
+	if err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// 他のユーザーのリストや存在しない場合はnilを返す
+func (dbf *DbFavoriteListDriver) FindList(listId string, userId string) (*FavoriteList, error) {
+	var lists []FavoriteList
+	err := preloadListStores(DB).Where("id = ? AND user_id = ?", listId, userId).Limit(1).Find(&lists).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(lists) == 0 {
+		return nil, nil
+	}
+	return &lists[0], nil
+}
+
+// ユーザーのリストの末尾に追加する
+func (dbf *DbFavoriteListDriver) CreateList(list *FavoriteList) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&FavoriteList{}).Where("user_id = ?", list.UserId).Count(&count).Error; err != nil {
+			return err
+		}
+		list.Position = int(count)
+		return tx.Omit(clause.Associations).Create(list).Error
+	})
+}
+
+func (dbf *DbFavoriteListDriver) UpdateList(list *FavoriteList) error {
+	return DB.Model(&FavoriteList{}).
+		Where("id = ? AND user_id = ?", list.Id, list.UserId).
+		Updates(map[string]interface{}{"name": list.Name, "description": list.Description}).Error
+}
+
+// 各リストのPositionをまとめて更新する
+func (dbf *DbFavoriteListDriver) ReorderLists(lists []*FavoriteList) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		for _, l := range lists {
+			err := tx.Model(&FavoriteList{}).
+				Where("id = ? AND user_id = ?", l.Id, l.UserId).
+				Update("position", l.Position).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// リストに追加された店舗は外部キーのON DELETE CASCADEで削除される
+func (dbf *DbFavoriteListDriver) DeleteList(listId string, userId string) error {
+	return DB.Where("id = ? AND user_id = ?", listId, userId).Delete(&FavoriteList{}).Error
+}
+
+// 店舗の情報を最新のものに更新してからリストに追加する
+func (dbf *DbFavoriteListDriver) AddStore(store *Store, listStore *FavoriteListStore) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := upsertStore(tx, store); err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(listStore).Error
+	})
+}
+
+func (dbf *DbFavoriteListDriver) DeleteStore(listId string, storeId string) (bool, error) {
+	result := DB.Where("favorite_list_id = ? AND store_id = ?", listId, storeId).Delete(&FavoriteListStore{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func preloadListStores(db *gorm.DB) *gorm.DB {
+	return db.Preload("Stores", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at")
+	}).Preload("Stores.Store")
+}
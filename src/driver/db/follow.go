@@ -0,0 +1,114 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type DbFollowDriver struct{}
+
+func NewFollowDriver() *DbFollowDriver {
+	return &DbFollowDriver{}
+}
+
+// ユーザー同士のフォロー。同じユーザーを複数回フォローすることはできない
+type Follow struct {
+	FollowerId string `gorm:"primaryKey;size:64"`
+	Follower   User   `gorm:"foreignKey:FollowerId;references:Id"`
+	FolloweeId string `gorm:"primaryKey;size:64;index"`
+	Followee   User   `gorm:"foreignKey:FolloweeId;references:Id"`
+	CreatedAt  time.Time
+}
+
+// フィードの取得条件。Beforeがnilの場合は最新の項目から返す
+type FeedFilter struct {
+	UserId       string
+	Visibilities []string // お気に入りを閲覧できる公開範囲
+	Before       *FeedPosition
+	Limit        int
+}
+
+// フィードの並び順での位置
+type FeedPosition struct {
+	CreatedAt time.Time
+	UserId    string
+	StoreId   string
+}
+
+func (dbf *DbFollowDriver) ExistUser(userId string) (bool, error) {
+	var count int64
+	if err := DB.Model(&User{}).Where("id = ?", userId).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (dbf *DbFollowDriver) ExistFollow(followerId string, followeeId string) (bool, error) {
+	var count int64
+	err := DB.Model(&Follow{}).Where("follower_id = ? AND followee_id = ?", followerId, followeeId).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (dbf *DbFollowDriver) CreateFollow(follow *Follow) error {
+	return DB.Omit(clause.Associations).Create(follow).Error
+}
+
+// フォローを解除した場合はtrueを返す
+func (dbf *DbFollowDriver) DeleteFollow(followerId string, followeeId string) (bool, error) {
+	result := DB.Where("follower_id = ? AND followee_id = ?", followerId, followeeId).Delete(&Follow{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// フォローしているユーザーをフォローした順に返す
+func (dbf *DbFollowDriver) FindFollowing(userId string) ([]*User, error) {
+	var users []*User
+	err := DB.Joins("JOIN follows ON follows.followee_id = users.id").
+		Where("follows.follower_id = ?", userId).
+		Order("follows.created_at").
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// フォロワーをフォローされた順に返す
+func (dbf *DbFollowDriver) FindFollowers(userId string) ([]*User, error) {
+	var users []*User
+	err := DB.Joins("JOIN follows ON follows.follower_id = users.id").
+		Where("follows.followee_id = ?", userId).
+		Order("follows.created_at").
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// フォローしているユーザーのお気に入りを、登録日時の新しい順に店舗とユーザーの情報と合わせて返す
+func (dbf *DbFollowDriver) FindFeed(filter *FeedFilter) ([]*FavoriteStore, error) {
+	query := DB.Preload("Store").Preload("User").
+		Joins("JOIN follows ON follows.followee_id = favorite_stores.user_id").
+		Joins("JOIN users ON users.id = favorite_stores.user_id").
+		Where("follows.follower_id = ?", filter.UserId).
+		Where("users.favorite_visibility IN ?", filter.Visibilities)
+	if filter.Before != nil {
+		query = query.Where("(favorite_stores.created_at, favorite_stores.user_id, favorite_stores.store_id) < (?, ?, ?)",
+			filter.Before.CreatedAt, filter.Before.UserId, filter.Before.StoreId)
+	}
+	var favorites []*FavoriteStore
+	err := query.Order("favorite_stores.created_at DESC, favorite_stores.user_id DESC, favorite_stores.store_id DESC").
+		Limit(filter.Limit).
+		Find(&favorites).Error
+	if err != nil {
+		return nil, err
+	}
+	return favorites, nil
+}
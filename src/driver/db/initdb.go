@@ -25,8 +25,32 @@ func InitDB() {
 		log.Fatalf("failed to migrate User: %v", err)
 	}
 
-	// FavoriteStoreテーブルを作成
-	if err := DB.AutoMigrate(&FavoriteStore{}); err != nil {
-		log.Fatalf("failed to migrate FavoriteStore: %v", err)
+	// StoreテーブルとFavoriteStoreテーブルを作成(旧形式のFavoriteStoreテーブルがあれば移し替える)
+	if err := migrateStores(DB); err != nil {
+		log.Fatalf("failed to migrate Store: %v", err)
+	}
+	if err := migrateStoreCoordinates(DB); err != nil {
+		log.Fatalf("failed to migrate Store coordinates: %v", err)
+	}
+
+	// 以下のテーブルはUserテーブルとStoreテーブルを参照するため最後に作成する
+	if err := DB.AutoMigrate(&FavoriteList{}, &FavoriteListStore{}); err != nil {
+		log.Fatalf("failed to migrate FavoriteList: %v", err)
+	}
+
+	if err := DB.AutoMigrate(&Review{}); err != nil {
+		log.Fatalf("failed to migrate Review: %v", err)
+	}
+
+	if err := DB.AutoMigrate(&Checkin{}); err != nil {
+		log.Fatalf("failed to migrate Checkin: %v", err)
+	}
+
+	if err := DB.AutoMigrate(&ShareLink{}); err != nil {
+		log.Fatalf("failed to migrate ShareLink: %v", err)
+	}
+
+	if err := DB.AutoMigrate(&Follow{}); err != nil {
+		log.Fatalf("failed to migrate Follow: %v", err)
 	}
 }
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 店舗の情報をお気に入りごとに複製して保存していた頃のfavorite_storesテーブルの移行先の名前
+const legacyFavoriteStoresTable = "favorite_stores_v1"
+
+const legacyUserConstraint = "fk_favorite_stores_user"
+
+// 移行前のfavorite_storesテーブルの行
+type legacyFavoriteStore struct {
+	UserId              string
+	StoreId             string
+	StoreName           string
+	RegularOpeningHours string
+	OpeningPeriods      string
+	UtcOffsetMinutes    int
+	PriceLevel          string
+	Latitude            string
+	Longitude           string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// 旧形式のfavorite_storesテーブルがあれば名前を変えて残し、storesとfavorite_storesに分けて移し替える。
+// 移し替えは新しいfavorite_storesが空の場合のみ行うため、途中で失敗しても再起動すればやり直せる
+func migrateStores(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if migrator.HasTable(&FavoriteStore{}) && migrator.HasColumn(&FavoriteStore{}, "store_name") {
+		if err := migrator.RenameTable(&FavoriteStore{}, legacyFavoriteStoresTable); err != nil {
+			return err
+		}
+		// 外部キーの名前はデータベース内で重複できないため、新しいテーブルで作り直せるよう旧テーブルから外す
+		if migrator.HasConstraint(legacyFavoriteStoresTable, legacyUserConstraint) {
+			if err := migrator.DropConstraint(legacyFavoriteStoresTable, legacyUserConstraint); err != nil {
+				return err
+			}
+		}
+	}
+	if err := db.AutoMigrate(&Store{}, &FavoriteStore{}); err != nil {
+		return err
+	}
+	if !migrator.HasTable(legacyFavoriteStoresTable) {
+		return nil
+	}
+	var count int64
+	if err := db.Model(&FavoriteStore{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var rows []legacyFavoriteStore
+	if err := db.Table(legacyFavoriteStoresTable).Order("created_at").Find(&rows).Error; err != nil {
+		return err
+	}
+	// 店舗の情報は最後に登録されたもの、お気に入りは最初に登録されたものを残す
+	stores := make(map[string]*Store)
+	favorites := make(map[[2]string]*FavoriteStore)
+	storeIds := make([]string, 0)
+	favoriteKeys := make([][2]string, 0)
+	for _, r := range rows {
+		if _, ok := stores[r.StoreId]; !ok {
+			storeIds = append(storeIds, r.StoreId)
+		}
+		stores[r.StoreId] = &Store{
+			Id:                  r.StoreId,
+			Name:                r.StoreName,
+			RegularOpeningHours: r.RegularOpeningHours,
+			OpeningPeriods:      r.OpeningPeriods,
+			UtcOffsetMinutes:    r.UtcOffsetMinutes,
+			PriceLevel:          r.PriceLevel,
+			Latitude:            r.Latitude,
+			Longitude:           r.Longitude,
+			CreatedAt:           r.CreatedAt,
+			UpdatedAt:           r.UpdatedAt,
+		}
+		key := [2]string{r.UserId, r.StoreId}
+		if _, ok := favorites[key]; !ok {
+			favoriteKeys = append(favoriteKeys, key)
+			favorites[key] = &FavoriteStore{
+				UserId:    r.UserId,
+				StoreId:   r.StoreId,
+				CreatedAt: r.CreatedAt,
+				UpdatedAt: r.UpdatedAt,
+			}
+		}
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, id := range storeIds {
+			if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(stores[id]).Error; err != nil {
+				return err
+			}
+		}
+		for _, key := range favoriteKeys {
+			if err := tx.Omit(clause.Associations).Create(favorites[key]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("migrated %d favorites of %d stores from %s", len(favoriteKeys), len(storeIds), legacyFavoriteStoresTable)
+	return nil
+}
+
+// 文字列でのみ保存していた頃の店舗に、範囲検索用の数値の緯度経度を設定する
+func migrateStoreCoordinates(db *gorm.DB) error {
+	result := db.Model(&Store{}).
+		Where("lat IS NULL AND latitude <> '' AND longitude <> ''").
+		Updates(map[string]interface{}{
+			"lat": gorm.Expr("CAST(latitude AS DECIMAL(10, 7))"),
+			"lng": gorm.Expr("CAST(longitude AS DECIMAL(10, 7))"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("set numeric coordinates of %d stores", result.RowsAffected)
+	}
+	return nil
+}
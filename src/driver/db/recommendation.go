@@ -0,0 +1,38 @@
+package db
+
+type DbRecommendationDriver struct{}
+
+func NewRecommendationDriver() *DbRecommendationDriver {
+	return &DbRecommendationDriver{}
+}
+
+// ユーザー本人と、本人と同じ店舗を1つ以上お気に入り登録しているユーザーのお気に入りを、ユーザーの情報と合わせて返す
+func (dbr *DbRecommendationDriver) FindSimilarUserFavorites(userId string) ([]*FavoriteStore, error) {
+	similarUsers := DB.Table("favorite_stores AS mine").
+		Select("DISTINCT others.user_id").
+		Joins("JOIN favorite_stores AS others ON others.store_id = mine.store_id").
+		Where("mine.user_id = ?", userId)
+
+	var favorites []*FavoriteStore
+	err := DB.Preload("User").
+		Where("user_id IN (?)", similarUsers).
+		Order("user_id, created_at").
+		Find(&favorites).Error
+	if err != nil {
+		return nil, err
+	}
+	return favorites, nil
+}
+
+func (dbr *DbRecommendationDriver) FindStores(storeIds []string) ([]*Store, error) {
+	var stores []*Store
+	err := DB.Where("id IN ?", storeIds).Find(&stores).Error
+	if err != nil {
+		return nil, err
+	}
+	return stores, nil
+}
+
+func (dbr *DbRecommendationDriver) GetTopStores(filter *RankingFilter) ([]*RankedStore, error) {
+	return (&DbStoreDriver{}).GetTopStores(filter)
+}
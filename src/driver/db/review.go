@@ -0,0 +1,132 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DbReviewDriver struct{}
+
+func NewReviewDriver() *DbReviewDriver {
+	return &DbReviewDriver{}
+}
+
+// ユーザーが店舗に付けた評価とレビュー。同じ店舗に同じユーザーが複数書くことはできない
+type Review struct {
+	StoreId   string `gorm:"primaryKey;size:255"`
+	Store     Store  `gorm:"foreignKey:StoreId;references:Id"`
+	UserId    string `gorm:"primaryKey;size:64;index"`
+	User      User   `gorm:"foreignKey:UserId;references:Id"`
+	Rating    int    `gorm:"not null"`
+	Text      string `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// 店舗ごとのレビューの集計結果
+type ReviewSummary struct {
+	StoreId string
+	Average float64
+	Count   int
+}
+
+// 店舗のレビューを新しい順にoffsetから最大limit件、書いたユーザーと合わせて返す
+func (dbr *DbReviewDriver) FindByStore(storeId string, offset int, limit int) ([]*Review, error) {
+	var reviews []*Review
+	err := DB.Preload("User").
+		Where("store_id = ?", storeId).
+		Order("created_at desc, user_id").
+		Offset(offset).
+		Limit(limit).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// ユーザーのレビューを新しい順にoffsetから最大limit件、店舗の情報と合わせて返す
+func (dbr *DbReviewDriver) FindByUser(userId string, offset int, limit int) ([]*Review, error) {
+	var reviews []*Review
+	err := DB.Preload("Store").
+		Where("user_id = ?", userId).
+		Order("created_at desc, store_id").
+		Offset(offset).
+		Limit(limit).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (dbr *DbReviewDriver) Find(storeId string, userId string) (*Review, error) {
+	var reviews []Review
+	err := DB.Where("store_id = ? AND user_id = ?", storeId, userId).Limit(1).Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(reviews) == 0 {
+		return nil, nil
+	}
+	return &reviews[0], nil
+}
+
+// レビューがない場合は件数0の集計を返す
+func (dbr *DbReviewDriver) GetSummary(storeId string) (*ReviewSummary, error) {
+	summary := &ReviewSummary{StoreId: storeId}
+	err := DB.Model(&Review{}).
+		Select("COALESCE(AVG(rating), 0) AS average, COUNT(*) AS count").
+		Where("store_id = ?", storeId).
+		Scan(summary).Error
+	if err != nil {
+		return nil, err
+	}
+	summary.StoreId = storeId
+	return summary, nil
+}
+
+// 店舗の情報として最後に保存された時点のものを返す。一度も保存されていない場合はnil
+func (dbr *DbReviewDriver) FindStore(storeId string) (*Store, error) {
+	return (&DbStoreDriver{}).FindStore(storeId)
+}
+
+// レビューを書く店舗がstoresテーブルになければ追加する
+func (dbr *DbReviewDriver) SaveStore(store *Store) error {
+	return upsertStore(DB, store)
+}
+
+func (dbr *DbReviewDriver) Create(review *Review) error {
+	return DB.Omit(clause.Associations).Create(review).Error
+}
+
+// 評価と本文を更新する。レビューが存在しなかった場合はfalseを返す
+func (dbr *DbReviewDriver) Update(review *Review) (bool, error) {
+	exist := false
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		// MySQLは値が変わらない場合に更新した行数を0とするため、存在は件数で確認する
+		var count int64
+		err := tx.Model(&Review{}).Where("store_id = ? AND user_id = ?", review.StoreId, review.UserId).Count(&count).Error
+		if err != nil || count == 0 {
+			return err
+		}
+		exist = true
+		return tx.Model(&Review{}).
+			Where("store_id = ? AND user_id = ?", review.StoreId, review.UserId).
+			Updates(map[string]interface{}{"rating": review.Rating, "text": review.Text}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return exist, nil
+}
+
+func (dbr *DbReviewDriver) Delete(storeId string, userId string) (bool, error) {
+	result := DB.Where("store_id = ? AND user_id = ?", storeId, userId).Delete(&Review{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
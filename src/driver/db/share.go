@@ -0,0 +1,62 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type DbShareDriver struct{}
+
+func NewShareDriver() *DbShareDriver {
+	return &DbShareDriver{}
+}
+
+// お気に入りを公開するリンク。トークンはハッシュのみ保存する
+type ShareLink struct {
+	Id        string `gorm:"primaryKey;size:64"`
+	UserId    string `gorm:"not null;size:64;index"`
+	User      User   `gorm:"foreignKey:UserId;references:Id"`
+	TokenHash string `gorm:"not null;size:64;uniqueIndex"`
+	CreatedAt time.Time
+}
+
+func (dbs *DbShareDriver) CreateLink(link *ShareLink) error {
+	return DB.Omit(clause.Associations).Create(link).Error
+}
+
+// 作成した順に返す
+func (dbs *DbShareDriver) FindLinksByUser(userId string) ([]*ShareLink, error) {
+	var links []*ShareLink
+	err := DB.Where("user_id = ?", userId).Order("created_at").Find(&links).Error
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// 他のユーザーのリンクは削除しない。削除した場合はtrueを返す
+func (dbs *DbShareDriver) DeleteLink(id string, userId string) (bool, error) {
+	result := DB.Where("id = ? AND user_id = ?", id, userId).Delete(&ShareLink{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// 存在しない場合はnilを返す
+func (dbs *DbShareDriver) FindLinkByTokenHash(tokenHash string) (*ShareLink, error) {
+	var links []ShareLink
+	err := DB.Where("token_hash = ?", tokenHash).Limit(1).Find(&links).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+	return &links[0], nil
+}
+
+func (dbs *DbShareDriver) FindFavoriteByUser(userId string) ([]*FavoriteStore, error) {
+	return (&DbStoreDriver{}).FindFavoriteByUser(userId)
+}
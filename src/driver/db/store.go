@@ -1,10 +1,10 @@
 package db
 
 import (
-	"errors"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DbStoreDriver struct{}
@@ -14,22 +14,65 @@ func NewStoreDriver() *DbStoreDriver {
 }
 
 /* interfaceと型は同義。仮にgatewayがDBの型を知ったとしても、どんなDBから来たかわかるわけではないのでおk */
-type FavoriteStore struct {
-	Id                  string `gorm:"primaryKey"`
-	UserId              string `gorm:"not null;size:64"`
-	User                User   `gorm:"foreignKey:UserId;references:Id"`
-	StoreId             string `gorm:"not null"`
-	StoreName           string `gorm:"not null"`
+// 店舗の情報。Googleのplace idごとに1行で、お気に入り登録されるたびに最新の情報で更新する
+type Store struct {
+	Id                  string `gorm:"primaryKey;size:255"`
+	Name                string `gorm:"not null"`
 	RegularOpeningHours string
+	OpeningPeriods      string `gorm:"type:text"` // 営業時間の区間をJSONで保存する
+	UtcOffsetMinutes    int
 	PriceLevel          string
-	Latitude            string `gorm:"not null"`
-	Longitude           string `gorm:"not null"`
+	Latitude            string   `gorm:"not null"`
+	Longitude           string   `gorm:"not null"`
+	Lat                 *float64 `gorm:"index:idx_stores_lat_lng,priority:1"` // 範囲検索用の数値の緯度
+	Lng                 *float64 `gorm:"index:idx_stores_lat_lng,priority:2"` // 範囲検索用の数値の経度
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 }
 
-func (dbs *DbStoreDriver) GetStores() ([]*FavoriteStore, error) {
-	var stores []*FavoriteStore
+// ユーザーがお気に入り登録した店舗。同じ店舗を同じユーザーが複数回登録することはできない
+type FavoriteStore struct {
+	UserId    string    `gorm:"primaryKey;size:64;index:idx_favorite_stores_user_created_at,priority:1"`
+	User      User      `gorm:"foreignKey:UserId;references:Id"`
+	StoreId   string    `gorm:"primaryKey;size:255;index"`
+	Store     Store     `gorm:"foreignKey:StoreId;references:Id"`
+	Note      string    `gorm:"type:text"`                                            // 本人にのみ見えるメモ
+	Tags      string    `gorm:"type:text"`                                            // タグをJSONの配列で保存する
+	CreatedAt time.Time `gorm:"index:idx_favorite_stores_user_created_at,priority:2"` // フィードで新しい順に取得するため
+	UpdatedAt time.Time
+}
+
+// ランキングとして集計した店舗とお気に入り登録数
+type RankedStore struct {
+	Store         `gorm:"embedded"`
+	FavoriteCount int
+}
+
+// ランキングの集計条件。nilの項目では絞り込まない
+type RankingFilter struct {
+	Since     *time.Time
+	Until     *time.Time
+	Limit     int
+	Age       *int
+	MinSex    *float32
+	MaxSex    *float32
+	MinGender *float32
+	MaxGender *float32
+	MinUsers  int // 1店舗あたりに必要な最小のユーザー数。満たない店舗はランキングに含めない
+}
+
+// 地図の表示範囲。MinLngがMaxLngより大きい場合は経度180度の線をまたぐ
+type BoundingBoxFilter struct {
+	MinLng float64
+	MinLat float64
+	MaxLng float64
+	MaxLat float64
+	Limit  int
+}
+
+// お気に入り登録されたことのある店舗を重複なく返す
+func (dbs *DbStoreDriver) GetStores() ([]*Store, error) {
+	var stores []*Store
 	err := DB.Find(&stores).Error
 	if err != nil {
 		return nil, err
@@ -37,66 +80,206 @@ func (dbs *DbStoreDriver) GetStores() ([]*FavoriteStore, error) {
 	return stores, nil
 }
 
+// 範囲内の店舗をid順に最大Limit件返す。緯度の範囲はidx_stores_lat_lngで絞り込む
+func (dbs *DbStoreDriver) FindStoresInBoundingBox(filter *BoundingBoxFilter) ([]*Store, error) {
+	tx := DB.Where("lat BETWEEN ? AND ?", filter.MinLat, filter.MaxLat)
+	if filter.MinLng > filter.MaxLng {
+		tx = tx.Where("(lng >= ? OR lng <= ?)", filter.MinLng, filter.MaxLng)
+	} else {
+		tx = tx.Where("lng BETWEEN ? AND ?", filter.MinLng, filter.MaxLng)
+	}
+	var stores []*Store
+	err := tx.Order("id").Limit(filter.Limit).Find(&stores).Error
+	if err != nil {
+		return nil, err
+	}
+	return stores, nil
+}
+
 func (dbs *DbStoreDriver) FindFavorite(storeId string, userId string) (*FavoriteStore, error) {
-	var stores []FavoriteStore
-	err := DB.Where("store_id = ? AND user_id = ?", storeId, userId).Find(&stores).Error
+	var favorites []FavoriteStore
+	err := DB.Where("store_id = ? AND user_id = ?", storeId, userId).Limit(1).Find(&favorites).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
 		return nil, err
 	}
-	if len(stores) == 0 {
+	if len(favorites) == 0 {
 		return nil, nil
 	}
+	return &favorites[0], nil
+}
 
+// 店舗の情報として最後にお気に入り登録された時点のものを返す。一度も登録されていない場合はnil
+func (dbs *DbStoreDriver) FindStore(storeId string) (*Store, error) {
+	var stores []Store
+	err := DB.Where("id = ?", storeId).Limit(1).Find(&stores).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(stores) == 0 {
+		return nil, nil
+	}
 	return &stores[0], nil
 }
 
+// 登録した順にお気に入りを店舗の情報と合わせて返す
 func (dbs *DbStoreDriver) FindFavoriteByUser(userId string) ([]*FavoriteStore, error) {
-	var stores []*FavoriteStore
-	err := DB.Where("user_id = ?", userId).Find(&stores).Error
+	var favorites []*FavoriteStore
+	err := DB.Preload("Store").Where("user_id = ?", userId).Order("created_at").Find(&favorites).Error
 	if err != nil {
 		return nil, err
 	}
-	return stores, nil
+	return favorites, nil
+}
+
+// 店舗の情報を最新のものに更新してからお気に入りを登録する
+func (dbs *DbStoreDriver) SaveFavorite(store *Store, favorite *FavoriteStore) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := upsertStore(tx, store); err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(favorite).Error
+	})
+}
+
+// 店舗の情報を最新のものに更新してから、お気に入りをまとめて登録する。1件でも失敗した場合はすべて登録しない
+func (dbs *DbStoreDriver) SaveFavorites(stores []*Store, favorites []*FavoriteStore) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		for _, s := range stores {
+			if err := upsertStore(tx, s); err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).CreateInBatches(favorites, 100).Error
+	})
 }
 
-func (dbs *DbStoreDriver) SaveStore(dbStore *FavoriteStore) error {
-	err := DB.Create(&dbStore).Error
+// 店舗がなければ追加し、あれば情報を更新する
+func upsertStore(tx *gorm.DB, store *Store) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "regular_opening_hours", "opening_periods", "utc_offset_minutes", "price_level", "latitude", "longitude", "lat", "lng", "updated_at"}),
+	}).Create(store).Error
+}
+
+// お気に入りのメモとタグを更新する。お気に入りが存在しなかった場合はfalseを返す
+func (dbs *DbStoreDriver) UpdateFavorite(favorite *FavoriteStore) (bool, error) {
+	exist := false
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		// MySQLは値が変わらない場合に更新した行数を0とするため、存在は件数で確認する
+		var count int64
+		err := tx.Model(&FavoriteStore{}).Where("store_id = ? AND user_id = ?", favorite.StoreId, favorite.UserId).Count(&count).Error
+		if err != nil || count == 0 {
+			return err
+		}
+		exist = true
+		return tx.Model(&FavoriteStore{}).
+			Where("store_id = ? AND user_id = ?", favorite.StoreId, favorite.UserId).
+			Updates(map[string]interface{}{"note": favorite.Note, "tags": favorite.Tags}).Error
+	})
 	if err != nil {
-		return err
+		return false, err
+	}
+	return exist, nil
+}
+
+// お気に入りを削除する。ランキングで数えないよう行ごと削除し、店舗の情報は他のユーザーのために残す
+func (dbs *DbStoreDriver) DeleteFavorite(storeId string, userId string) (bool, error) {
+	result := DB.Where("store_id = ? AND user_id = ?", storeId, userId).Delete(&FavoriteStore{})
+	if result.Error != nil {
+		return false, result.Error
 	}
-	return nil
+	return result.RowsAffected > 0, nil
 }
 
-func (dbs *DbStoreDriver) GetTopStores() ([]*FavoriteStore, error) {
-	oneWeekAgo := time.Now().AddDate(0, 0, -7)
+// 期間内に書かれたレビューの平均の高い順に最大Limit件を取得する。平均が同じ場合はレビューの多い順とする
+func (dbs *DbStoreDriver) GetTopRatedStores(filter *RankingFilter) ([]*Store, error) {
+	var stores []*Store
+	tx := DB.Model(&Store{}).
+		Select("stores.*").
+		Joins("JOIN reviews ON reviews.store_id = stores.id")
+	err := applyRankingFilter(tx, "reviews", filter).
+		Order("AVG(reviews.rating) desc, COUNT(*) desc, stores.id").
+		Find(&stores).Error
+	if err != nil {
+		return nil, err
+	}
+	return stores, nil
+}
 
-	// store_idごとにカウント、多い順に最大10件を取得
-	var topStoreIds []string
-	err := DB.Model(&FavoriteStore{}).
-		Select("store_id").
-		Where("created_at >= ?", oneWeekAgo).
+// 指定した店舗のレビューの平均と件数を1回のクエリで集計する。レビューのない店舗は含まない
+func (dbs *DbStoreDriver) GetReviewSummaries(storeIds []string) ([]*ReviewSummary, error) {
+	var summaries []*ReviewSummary
+	err := DB.Model(&Review{}).
+		Select("store_id, AVG(rating) AS average, COUNT(*) AS count").
+		Where("store_id IN ?", storeIds).
 		Group("store_id").
-		Order("COUNT(*) desc").
-		Limit(10).
-		Pluck("store_id", &topStoreIds).Error
+		Scan(&summaries).Error
 	if err != nil {
 		return nil, err
 	}
+	return summaries, nil
+}
 
-	// 取得したstore_idに対応するfavorite_storeを順番に取得
-	var stores []*FavoriteStore
-	for _, storeId := range topStoreIds {
-		var store FavoriteStore
-		err = DB.Where("store_id = ?", storeId).
-			First(&store).Error
-		if err != nil {
-			return nil, err
-		}
-		stores = append(stores, &store)
+// ユーザーが指定した店舗を訪れた回数と最後に訪れた日時を1回のクエリで集計する。訪れたことのない店舗は含まない
+func (dbs *DbStoreDriver) GetVisitSummaries(userId string, storeIds []string) ([]*VisitSummary, error) {
+	var summaries []*VisitSummary
+	err := DB.Model(&Checkin{}).
+		Select("store_id, COUNT(*) AS count, MAX(visited_at) AS last_visited_at").
+		Where("user_id = ? AND store_id IN ?", userId, storeIds).
+		Group("store_id").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, err
 	}
+	return summaries, nil
+}
 
+// 期間内にお気に入り登録された数を店舗ごとに1回のクエリで数え、多い順に最大Limit件を取得する
+func (dbs *DbStoreDriver) GetTopStores(filter *RankingFilter) ([]*RankedStore, error) {
+	var stores []*RankedStore
+	tx := DB.Model(&Store{}).
+		Select("stores.*, COUNT(*) AS favorite_count").
+		Joins("JOIN favorite_stores ON favorite_stores.store_id = stores.id")
+	err := applyRankingFilter(tx, "favorite_stores", filter).
+		Order("favorite_count desc, stores.id").
+		Scan(&stores).Error
+	if err != nil {
+		return nil, err
+	}
 	return stores, nil
 }
+
+// tableの行を店舗ごとに集計する条件を付ける。tableは主キーにuser_idを含むため、行数は店舗ごとのユーザー数と等しい
+func applyRankingFilter(tx *gorm.DB, table string, filter *RankingFilter) *gorm.DB {
+	if filter.Since != nil {
+		tx = tx.Where(table+".created_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		tx = tx.Where(table+".created_at <= ?", *filter.Until)
+	}
+	if filter.hasSegment() {
+		tx = tx.Joins("JOIN users ON users.id = " + table + ".user_id")
+		if filter.Age != nil {
+			tx = tx.Where("users.age = ?", *filter.Age)
+		}
+		if filter.MinSex != nil {
+			tx = tx.Where("users.sex >= ?", *filter.MinSex)
+		}
+		if filter.MaxSex != nil {
+			tx = tx.Where("users.sex <= ?", *filter.MaxSex)
+		}
+		if filter.MinGender != nil {
+			tx = tx.Where("users.gender >= ?", *filter.MinGender)
+		}
+		if filter.MaxGender != nil {
+			tx = tx.Where("users.gender <= ?", *filter.MaxGender)
+		}
+	}
+	return tx.Group("stores.id").
+		Having("COUNT(*) >= ?", filter.MinUsers).
+		Limit(filter.Limit)
+}
+
+func (f *RankingFilter) hasSegment() bool {
+	return f.Age != nil || f.MinSex != nil || f.MaxSex != nil || f.MinGender != nil || f.MaxGender != nil
+}
@@ -12,14 +12,15 @@ func NewUserDriver() *DbUserDriver {
 }
 
 type User struct {
-	Id        string  `gorm:"primaryKey"`
-	Name      string  `gorm:"not null"`
-	Email     string  `gorm:"unique"`
-	Age       int     `gorm:"not null"`
-	Sex       float32 `gorm:"not null"`
-	Gender    float32 `gorm:"not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Id                 string  `gorm:"primaryKey"`
+	Name               string  `gorm:"not null"`
+	Email              string  `gorm:"unique"`
+	Age                int     `gorm:"not null"`
+	Sex                float32 `gorm:"not null"`
+	Gender             float32 `gorm:"not null"`
+	FavoriteVisibility string  `gorm:"not null;size:16;default:private"` // お気に入りの公開範囲(public, followers, private)
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
 
 func (dbu *DbUserDriver) CreateUser(user *User) (*User, error) {
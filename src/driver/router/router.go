@@ -28,16 +28,28 @@ type RouterI interface {
 }
 
 type Router struct {
-	echo            *echo.Echo
-	storeController controller.StoreI
-	userController  controller.UserI
+	echo                     *echo.Echo
+	storeController          controller.StoreI
+	userController           controller.UserI
+	favoriteListController   controller.FavoriteListI
+	reviewController         controller.ReviewI
+	checkinController        controller.CheckinI
+	recommendationController controller.RecommendationI
+	shareController          controller.ShareI
+	followController         controller.FollowI
 }
 
-func NewRouter(echo *echo.Echo, storeController controller.StoreI, userController controller.UserI) RouterI {
+func NewRouter(echo *echo.Echo, storeController controller.StoreI, userController controller.UserI, favoriteListController controller.FavoriteListI, reviewController controller.ReviewI, checkinController controller.CheckinI, recommendationController controller.RecommendationI, shareController controller.ShareI, followController controller.FollowI) RouterI {
 	return &Router{
-		echo:            echo,
-		storeController: storeController,
-		userController:  userController,
+		echo:                     echo,
+		storeController:          storeController,
+		userController:           userController,
+		favoriteListController:   favoriteListController,
+		reviewController:         reviewController,
+		checkinController:        checkinController,
+		recommendationController: recommendationController,
+		shareController:          shareController,
+		followController:         followController,
 	}
 }
 
@@ -45,18 +57,51 @@ func (router *Router) Serve(ctx context.Context) {
 	// ログイン前のルーティング
 	router.echo.GET("/", router.storeController.GetStores)
 	router.echo.POST("/login", router.userController.LoginUser)
-	router.echo.GET("/auth", router.userController.GetAuthUrl)            // Google認証用のURLを取得し返す
-	router.echo.GET("/auth/signup", router.userController.SignupWithAuth) // ユーザの認証を確認し仮登録する
+	router.echo.GET("/auth", router.userController.GetAuthUrl)                        // Google認証用のURLを取得し返す
+	router.echo.GET("/auth/signup", router.userController.SignupWithAuth)             // ユーザの認証を確認し仮登録する
+	router.echo.GET("/shared/:token", router.shareController.GetSharedFavoriteStores) // 共有リンクからお気に入りを閲覧する
 
 	// ログイン後のルーティング(認証が必要なパスはここより下に書く)
 	// 認証のためのJWTMiddlewareを設定
 	secured := router.echo.Group("")
 	secured.Use(middleware.JwtAuthMiddleware())
 
+	secured.GET("/stores", router.storeController.GetStoresInBoundingBox)
+	secured.GET("/stores/clusters", router.storeController.GetStoreClusters)
 	secured.GET("/stores/opening-hours", router.storeController.GetNearStores)
+	secured.GET("/stores/search", router.storeController.SearchStores)
+	secured.GET("/stores/:id", router.storeController.GetStore)
 	secured.GET("/stores/favorite-ranking", router.storeController.GetTopFavoriteStores)
+	secured.GET("/stores/:id/reviews", router.reviewController.GetStoreReviews)
+	secured.POST("/stores/:id/reviews", router.reviewController.CreateReview)
+	secured.POST("/stores/:id/checkins", router.checkinController.CreateCheckin)
 	secured.GET("/user/favorite-store", router.storeController.GetFavoriteStores)
 	secured.POST("/user/favorite-store", router.storeController.SaveFavoriteStore)
+	secured.GET("/user/favorite-store/tags", router.storeController.GetFavoriteTags)
+	secured.GET("/user/favorite-store/export", router.storeController.ExportFavoriteStores)
+	secured.POST("/user/favorite-store/import", router.storeController.ImportFavoriteStores)
+	secured.PUT("/user/favorite-store/:storeId", router.storeController.UpdateFavoriteStore)
+	secured.DELETE("/user/favorite-store/:storeId", router.storeController.DeleteFavoriteStore)
+	secured.GET("/user/lists", router.favoriteListController.GetLists)
+	secured.POST("/user/lists", router.favoriteListController.CreateList)
+	secured.GET("/user/lists/:listId", router.favoriteListController.GetList)
+	secured.PUT("/user/lists/:listId", router.favoriteListController.UpdateList)
+	secured.DELETE("/user/lists/:listId", router.favoriteListController.DeleteList)
+	secured.POST("/user/lists/:listId/stores", router.favoriteListController.AddStore)
+	secured.DELETE("/user/lists/:listId/stores/:storeId", router.favoriteListController.RemoveStore)
+	secured.GET("/user/reviews", router.reviewController.GetUserReviews)
+	secured.PUT("/user/reviews/:storeId", router.reviewController.UpdateReview)
+	secured.DELETE("/user/reviews/:storeId", router.reviewController.DeleteReview)
+	secured.GET("/user/checkins", router.checkinController.GetCheckins)
+	secured.GET("/user/recommendations", router.recommendationController.GetRecommendations)
+	secured.GET("/user/share-links", router.shareController.GetShareLinks)
+	secured.POST("/user/share-links", router.shareController.CreateShareLink)
+	secured.DELETE("/user/share-links/:id", router.shareController.RevokeShareLink)
+	secured.GET("/user/following", router.followController.GetFollowing)
+	secured.GET("/user/followers", router.followController.GetFollowers)
+	secured.GET("/user/feed", router.followController.GetFeed)
+	secured.POST("/users/:userId/follow", router.followController.Follow)
+	secured.DELETE("/users/:userId/follow", router.followController.Unfollow)
 	secured.PUT("/user", router.userController.UpdateUser)
 	router.echo.Logger.Fatal(router.echo.Start(":8080"))
 }
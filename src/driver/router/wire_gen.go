@@ -13,12 +13,16 @@ import (
 	"clean-storemap-api/src/driver/api"
 	"clean-storemap-api/src/driver/auth"
 	"clean-storemap-api/src/driver/db"
+	model "clean-storemap-api/src/entity"
 	"clean-storemap-api/src/usecase/interactor"
 	"context"
+	"fmt"
 	"github.com/google/wire"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"os"
+	"strconv"
+	"time"
 )
 
 // Injectors from wire.go:
@@ -30,7 +34,8 @@ func InitializeRouter(ctx context.Context) (RouterI, error) {
 	storeOutputFactory := NewStoreOutputFactory()
 	storeInputFactory := NewStoreInputFactory()
 	storeRepositoryFactory := NewStoreRepositoryFactory()
-	storeI := controller.NewStoreController(storeDriverFactory, googleMapDriverFactory, storeOutputFactory, storeInputFactory, storeRepositoryFactory)
+	priceRanges := NewPriceRanges()
+	storeI := controller.NewStoreController(storeDriverFactory, googleMapDriverFactory, storeOutputFactory, storeInputFactory, storeRepositoryFactory, priceRanges)
 	userDriverFactory := NewUserDriverFactory()
 	googleOAuthDriverFactory := NewGoogleOAuthDriverFactory()
 	jwtDriverFactory := NewJwtDriverFactory()
@@ -38,7 +43,37 @@ func InitializeRouter(ctx context.Context) (RouterI, error) {
 	userInputFactory := NewUserInputFactory()
 	userRepositoryFactory := NewUserRepositoryFactory()
 	userI := controller.NewUserController(userDriverFactory, googleOAuthDriverFactory, jwtDriverFactory, userOutputFactory, userInputFactory, userRepositoryFactory)
-	routerI := NewRouter(echo, storeI, userI)
+	favoriteListDriverFactory := NewFavoriteListDriverFactory()
+	favoriteListOutputFactory := NewFavoriteListOutputFactory()
+	favoriteListInputFactory := NewFavoriteListInputFactory()
+	favoriteListRepositoryFactory := NewFavoriteListRepositoryFactory()
+	favoriteListI := controller.NewFavoriteListController(favoriteListDriverFactory, favoriteListOutputFactory, favoriteListInputFactory, favoriteListRepositoryFactory)
+	reviewDriverFactory := NewReviewDriverFactory()
+	reviewOutputFactory := NewReviewOutputFactory()
+	reviewInputFactory := NewReviewInputFactory()
+	reviewRepositoryFactory := NewReviewRepositoryFactory()
+	reviewI := controller.NewReviewController(reviewDriverFactory, googleMapDriverFactory, reviewOutputFactory, reviewInputFactory, reviewRepositoryFactory)
+	checkinDriverFactory := NewCheckinDriverFactory()
+	checkinOutputFactory := NewCheckinOutputFactory()
+	checkinInputFactory := NewCheckinInputFactory()
+	checkinRepositoryFactory := NewCheckinRepositoryFactory()
+	checkinI := controller.NewCheckinController(checkinDriverFactory, googleMapDriverFactory, checkinOutputFactory, checkinInputFactory, checkinRepositoryFactory)
+	recommendationDriverFactory := NewRecommendationDriverFactory()
+	recommendationOutputFactory := NewRecommendationOutputFactory()
+	recommendationInputFactory := NewRecommendationInputFactory()
+	recommendationRepositoryFactory := NewRecommendationRepositoryFactory()
+	recommendationI := controller.NewRecommendationController(recommendationDriverFactory, recommendationOutputFactory, recommendationInputFactory, recommendationRepositoryFactory)
+	shareDriverFactory := NewShareDriverFactory()
+	shareOutputFactory := NewShareOutputFactory()
+	shareInputFactory := NewShareInputFactory()
+	shareRepositoryFactory := NewShareRepositoryFactory()
+	shareI := controller.NewShareController(shareDriverFactory, shareOutputFactory, shareInputFactory, shareRepositoryFactory)
+	followDriverFactory := NewFollowDriverFactory()
+	followOutputFactory := NewFollowOutputFactory()
+	followInputFactory := NewFollowInputFactory()
+	followRepositoryFactory := NewFollowRepositoryFactory()
+	followI := controller.NewFollowController(followDriverFactory, followOutputFactory, followInputFactory, followRepositoryFactory)
+	routerI := NewRouter(echo, storeI, userI, favoriteListI, reviewI, checkinI, recommendationI, shareI, followI)
 	return routerI, nil
 }
 
@@ -48,9 +83,19 @@ var echoSet = wire.NewSet(
 	NewEcho,
 )
 
+var configSet = wire.NewSet(
+	NewPriceRanges,
+)
+
 var driverSet = wire.NewSet(
 	NewStoreDriverFactory,
 	NewUserDriverFactory,
+	NewFavoriteListDriverFactory,
+	NewReviewDriverFactory,
+	NewCheckinDriverFactory,
+	NewRecommendationDriverFactory,
+	NewShareDriverFactory,
+	NewFollowDriverFactory,
 	NewGoogleMapDriverFactory,
 	NewGoogleOAuthDriverFactory,
 	NewJwtDriverFactory,
@@ -59,19 +104,37 @@ var driverSet = wire.NewSet(
 var inputPortSet = wire.NewSet(
 	NewStoreInputFactory,
 	NewUserInputFactory,
+	NewFavoriteListInputFactory,
+	NewReviewInputFactory,
+	NewCheckinInputFactory,
+	NewRecommendationInputFactory,
+	NewShareInputFactory,
+	NewFollowInputFactory,
 )
 
 var repositorySet = wire.NewSet(
 	NewStoreRepositoryFactory,
 	NewUserRepositoryFactory,
+	NewFavoriteListRepositoryFactory,
+	NewReviewRepositoryFactory,
+	NewCheckinRepositoryFactory,
+	NewRecommendationRepositoryFactory,
+	NewShareRepositoryFactory,
+	NewFollowRepositoryFactory,
 )
 
 var outputPortSet = wire.NewSet(
 	NewStoreOutputFactory,
 	NewUserOutputFactory,
+	NewFavoriteListOutputFactory,
+	NewReviewOutputFactory,
+	NewCheckinOutputFactory,
+	NewRecommendationOutputFactory,
+	NewShareOutputFactory,
+	NewFollowOutputFactory,
 )
 
-var controllerSet = wire.NewSet(controller.NewStoreController, controller.NewUserController)
+var controllerSet = wire.NewSet(controller.NewStoreController, controller.NewUserController, controller.NewFavoriteListController, controller.NewReviewController, controller.NewCheckinController, controller.NewRecommendationController, controller.NewShareController, controller.NewFollowController)
 
 func NewEcho() *echo.Echo {
 	e := echo.New()
@@ -87,13 +150,39 @@ func NewEcho() *echo.Echo {
 	return e
 }
 
+// 価格帯ごとの金額の範囲。PRICE_LEVEL_RANGESで既定値を上書きできる
+func NewPriceRanges() model.PriceRanges {
+	ranges, err := model.ParsePriceRanges(os.Getenv("PRICE_LEVEL_RANGES"))
+	if err != nil {
+		fmt.Printf("invalid PRICE_LEVEL_RANGES, using default: %s\n", err)
+		return model.DefaultPriceRanges()
+	}
+	return ranges
+}
+
 // StoreのDI
 func NewStoreDriverFactory() controller.StoreDriverFactory {
 	return &db.DbStoreDriver{}
 }
 
+// Google Maps APIの呼び出しは有料なので、同じ条件のレスポンスはキャッシュする
 func NewGoogleMapDriverFactory() controller.GoogleMapDriverFactory {
-	return &api.ApiGoogleMapDriver{}
+	ttl, err := time.ParseDuration(os.Getenv("GOOGLE_MAP_CACHE_TTL"))
+	if err != nil {
+		ttl = 10 * time.Minute
+	}
+	size, err := strconv.Atoi(os.Getenv("GOOGLE_MAP_CACHE_SIZE"))
+	if err != nil || size < 1 {
+		size = 1000
+	}
+	// 接続先を指定しない場合はGoogleのAPIに接続する(オフラインではfakeplacesのURLを指定する)
+	googleMapDriver := api.NewGoogleMapDriver(
+		nil,
+		os.Getenv("GOOGLE_GEOLOCATION_API_URL"),
+		os.Getenv("GOOGLE_PLACES_API_URL"),
+		os.Getenv("GOOGLE_MAP_API_KEY"),
+	)
+	return api.NewCachedGoogleMapDriver(googleMapDriver, api.NewMemoryCache(size), ttl)
 }
 
 func NewStoreOutputFactory() controller.StoreOutputFactory {
@@ -132,3 +221,105 @@ func NewUserInputFactory() controller.UserInputFactory {
 func NewUserRepositoryFactory() controller.UserRepositoryFactory {
 	return gateway.NewUserRepository
 }
+
+// FavoriteListのDI
+func NewFavoriteListDriverFactory() controller.FavoriteListDriverFactory {
+	return &db.DbFavoriteListDriver{}
+}
+
+func NewFavoriteListOutputFactory() controller.FavoriteListOutputFactory {
+	return presenter.NewFavoriteListOutputPort
+}
+
+func NewFavoriteListInputFactory() controller.FavoriteListInputFactory {
+	return interactor.NewFavoriteListInputPort
+}
+
+func NewFavoriteListRepositoryFactory() controller.FavoriteListRepositoryFactory {
+	return gateway.NewFavoriteListRepository
+}
+
+// ReviewのDI
+func NewReviewDriverFactory() controller.ReviewDriverFactory {
+	return &db.DbReviewDriver{}
+}
+
+func NewReviewOutputFactory() controller.ReviewOutputFactory {
+	return presenter.NewReviewOutputPort
+}
+
+func NewReviewInputFactory() controller.ReviewInputFactory {
+	return interactor.NewReviewInputPort
+}
+
+func NewReviewRepositoryFactory() controller.ReviewRepositoryFactory {
+	return gateway.NewReviewRepository
+}
+
+// CheckinのDI
+func NewCheckinDriverFactory() controller.CheckinDriverFactory {
+	return &db.DbCheckinDriver{}
+}
+
+func NewCheckinOutputFactory() controller.CheckinOutputFactory {
+	return presenter.NewCheckinOutputPort
+}
+
+func NewCheckinInputFactory() controller.CheckinInputFactory {
+	return interactor.NewCheckinInputPort
+}
+
+func NewCheckinRepositoryFactory() controller.CheckinRepositoryFactory {
+	return gateway.NewCheckinRepository
+}
+
+// RecommendationのDI
+func NewRecommendationDriverFactory() controller.RecommendationDriverFactory {
+	return &db.DbRecommendationDriver{}
+}
+
+func NewRecommendationOutputFactory() controller.RecommendationOutputFactory {
+	return presenter.NewRecommendationOutputPort
+}
+
+func NewRecommendationInputFactory() controller.RecommendationInputFactory {
+	return interactor.NewRecommendationInputPort
+}
+
+func NewRecommendationRepositoryFactory() controller.RecommendationRepositoryFactory {
+	return gateway.NewRecommendationRepository
+}
+
+// ShareのDI
+func NewShareDriverFactory() controller.ShareDriverFactory {
+	return &db.DbShareDriver{}
+}
+
+func NewShareOutputFactory() controller.ShareOutputFactory {
+	return presenter.NewShareOutputPort
+}
+
+func NewShareInputFactory() controller.ShareInputFactory {
+	return interactor.NewShareInputPort
+}
+
+func NewShareRepositoryFactory() controller.ShareRepositoryFactory {
+	return gateway.NewShareRepository
+}
+
+// FollowのDI
+func NewFollowDriverFactory() controller.FollowDriverFactory {
+	return &db.DbFollowDriver{}
+}
+
+func NewFollowOutputFactory() controller.FollowOutputFactory {
+	return presenter.NewFollowOutputPort
+}
+
+func NewFollowInputFactory() controller.FollowInputFactory {
+	return interactor.NewFollowInputPort
+}
+
+func NewFollowRepositoryFactory() controller.FollowRepositoryFactory {
+	return gateway.NewFollowRepository
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	maxPartySize = 100
+	// 端末の時計のずれを考えて、少し先の時刻までは受け付ける
+	maxCheckinClockSkew = 5 * time.Minute
+)
+
+// ユーザーが店舗を訪れた記録
+type Checkin struct {
+	StoreId   string
+	StoreName string // 取得時のみ設定する
+	UserId    string
+	VisitedAt time.Time
+	PartySize int // 指定されなかった場合は0
+}
+
+// 訪問日時が指定されなかった場合はnowを訪問日時とする
+func NewCheckin(storeId string, userId string, visitedAt *time.Time, partySize *int, now time.Time) (*Checkin, error) {
+	checkin := &Checkin{StoreId: storeId, UserId: userId, VisitedAt: now}
+	if visitedAt != nil {
+		if visitedAt.After(now.Add(maxCheckinClockSkew)) {
+			return nil, errors.New("visitedAt must not be in the future")
+		}
+		checkin.VisitedAt = *visitedAt
+	}
+	if partySize != nil {
+		if *partySize < 1 || *partySize > maxPartySize {
+			return nil, errors.New("partySize must be between 1 and 100, got " + strconv.Itoa(*partySize))
+		}
+		checkin.PartySize = *partySize
+	}
+	return checkin, nil
+}
+
+// 営業時間が分からない店舗はいつでも訪問できるものとする
+func (c *Checkin) IsDuringOpeningHours(store *Store) bool {
+	return store.OpeningHours == nil || store.OpeningHours.IsOpenAt(c.VisitedAt)
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+)
+
+const (
+	MinZoom = 0
+	MaxZoom = 22 // 地図のタイルで指定できる最も詳細なズームレベル
+	// クラスタリングのために範囲内から取得する最大の店舗数
+	MaxClusterStores = 10000
+)
+
+// 地図上で近くにある店舗のまとまり。1店舗のみの場合はCenterがその店舗の位置となる
+type StoreCluster struct {
+	Center   Coordinate
+	Count    int
+	StoreIds []string // まとまりを代表する店舗(中心に近い順に数件)
+}
+
+func ParseZoom(s string) (int, error) {
+	if s == "" {
+		return 0, errors.New("zoom is required")
+	}
+	zoom, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.New("zoom is invalid")
+	}
+	if zoom < MinZoom || zoom > MaxZoom {
+		return 0, errors.New("zoom must be between 0 and 22, got " + s)
+	}
+	return zoom, nil
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+const (
+	DefaultPageSize = 10 // limitが指定されなかった場合の件数
+	MaxPageSize     = 20 // 1ページで返せる最大の件数(Places APIの上限と同じ)
+)
+
+// 次のページの位置。クライアントには中身のわからない文字列として渡す
+type Cursor struct {
+	PageToken string // 上流のAPIが返すページトークン
+	Offset    int    // 上流がページトークンを返さない場合の読み飛ばす件数
+}
+
+// ページングの指定。Cursorが空の場合は先頭のページとなる
+type PageRequest struct {
+	Cursor Cursor
+	Limit  int
+}
+
+type cursorJson struct {
+	PageToken string `json:"t,omitempty"`
+	Offset    int    `json:"o,omitempty"`
+}
+
+func (c *Cursor) Encode() string {
+	encoded, _ := json.Marshal(cursorJson{PageToken: c.PageToken, Offset: c.Offset})
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return &Cursor{}, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("cursor is invalid")
+	}
+	var c cursorJson
+	if err := json.Unmarshal(decoded, &c); err != nil {
+		return nil, errors.New("cursor is invalid")
+	}
+	if c.Offset < 0 {
+		return nil, errors.New("cursor is invalid")
+	}
+	return &Cursor{PageToken: c.PageToken, Offset: c.Offset}, nil
+}
+
+func NewPageRequest(cursor string, limit string) (*PageRequest, error) {
+	c, err := DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	page := &PageRequest{Cursor: *c, Limit: DefaultPageSize}
+	if limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, errors.New("limit is invalid")
+		}
+		if l < 1 || l > MaxPageSize {
+			return nil, errors.New("limit must be between 1 and 20, got " + limit)
+		}
+		page.Limit = l
+	}
+	return page, nil
+}
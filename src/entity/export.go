@@ -0,0 +1,23 @@
+package model
+
+import "errors"
+
+// お気に入りを書き出す形式
+type ExportFormat string
+
+const (
+	ExportFormatCsv ExportFormat = "csv"
+	ExportFormatGpx ExportFormat = "gpx"
+	ExportFormatKml ExportFormat = "kml"
+)
+
+// 形式を指定しなかった場合はCSVとする
+func NewExportFormat(format string) (ExportFormat, error) {
+	switch ExportFormat(format) {
+	case "":
+		return ExportFormatCsv, nil
+	case ExportFormatCsv, ExportFormatGpx, ExportFormatKml:
+		return ExportFormat(format), nil
+	}
+	return "", errors.New("format must be csv, gpx or kml, got " + format)
+}
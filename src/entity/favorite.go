@@ -0,0 +1,110 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxNoteLength = 1000
+	maxTagLength  = 30
+	maxTagCount   = 20
+)
+
+// お気に入りにユーザーが付けた情報。本人にのみ返す
+type Favorite struct {
+	Note          string
+	Tags          []string
+	VisitCount    int        // 取得時のみ設定する
+	LastVisitedAt *time.Time // 取得時のみ設定する(訪問したことがない場合はnil)
+	SavedAt       time.Time  // 取得時と、取り込む際に元の登録日時がわかる場合のみ設定する
+}
+
+func NewFavorite(note string, tags []string) (*Favorite, error) {
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return nil, errors.New("note is too long")
+	}
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	return &Favorite{Note: note, Tags: normalized}, nil
+}
+
+// 前後の空白を取り除き、大文字小文字を区別せずに重複したタグを除いて返す
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, errors.New("tag is too long, got " + tag)
+		}
+		if strings.Contains(tag, ",") {
+			return nil, errors.New("tag must not contain comma, got " + tag)
+		}
+		if !containsTag(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > maxTagCount {
+		return nil, errors.New("too many tags")
+	}
+	return normalized, nil
+}
+
+func (f *Favorite) HasTag(tag string) bool {
+	return f != nil && containsTag(f.Tags, tag)
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// ユーザーがお気に入りに付けたタグのうちprefixで始まるものを、使った回数の多い順に最大limit件返す
+func SuggestTags(stores []*Store, prefix string, limit int) []string {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	counts := make(map[string]int)
+	// 大文字小文字の違うタグは最初に使われた表記にまとめる
+	spellings := make(map[string]string)
+	for _, s := range stores {
+		if s.Favorite == nil {
+			continue
+		}
+		for _, tag := range s.Favorite.Tags {
+			key := strings.ToLower(tag)
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			if _, ok := spellings[key]; !ok {
+				spellings[key] = tag
+			}
+			counts[key]++
+		}
+	}
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	suggestions := make([]string, 0, min(limit, len(keys)))
+	for _, key := range keys[:min(limit, len(keys))] {
+		suggestions = append(suggestions, spellings[key])
+	}
+	return suggestions
+}
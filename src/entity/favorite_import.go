@@ -0,0 +1,58 @@
+package model
+
+const (
+	// 1回で取り込める最大の行数
+	MaxImportRows = 1000
+	// 店舗のidがない行を、保存された位置からこの距離(メートル)以内にある同じ名前の店舗に対応させる
+	ImportMatchRadius = 100.0
+)
+
+// 取り込んだ行ごとの結果
+type ImportStatus string
+
+const (
+	ImportStatusImported  ImportStatus = "imported"
+	ImportStatusDuplicate ImportStatus = "duplicate" // すでにお気に入り登録されているか、同じファイル内で重複している
+	ImportStatusInvalid   ImportStatus = "invalid"
+)
+
+// 取り込むファイルの1行(GeoJSONの場合は1つのFeature)
+type ImportRow struct {
+	Row     int    // 1始まりの行番号(CSVはヘッダを除く)
+	Name    string // 結果に表示する店舗名
+	Store   *Store // 検証に成功した場合のみ。Idが空の場合は取り込む際に店舗を探す
+	Invalid string // 検証に失敗した理由
+}
+
+type ImportResult struct {
+	Row     int
+	Name    string
+	StoreId string
+	Status  ImportStatus
+	Reason  string // Statusがinvalidの場合のみ
+}
+
+func (r *ImportRow) Result(storeId string, status ImportStatus, reason string) *ImportResult {
+	return &ImportResult{Row: r.Row, Name: r.Name, StoreId: storeId, Status: status, Reason: reason}
+}
+
+// candidatesのうちsavedの位置からImportMatchRadius以内で最も近い店舗を返す。見つからない場合はnil
+func MatchImportedStore(saved *Store, candidates []*Store) *Store {
+	origin, err := saved.Location.Coordinate()
+	if err != nil {
+		return nil
+	}
+	var nearest *Store
+	nearestDistance := ImportMatchRadius
+	for _, c := range candidates {
+		coordinate, err := c.Location.Coordinate()
+		if err != nil {
+			continue
+		}
+		if d := Distance(origin, coordinate); d <= nearestDistance {
+			nearest = c
+			nearestDistance = d
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxListNameLength        = 50
+	maxListDescriptionLength = 500
+)
+
+// ユーザーが名前を付けて店舗をまとめたリスト。同じ店舗を複数のリストに入れられる
+type FavoriteList struct {
+	Id          string // uuidを使用
+	Name        string
+	Description string
+	Position    int      // ユーザーのリスト一覧での並び順(0始まり)
+	Stores      []*Store // リストに追加した順
+}
+
+// リストの変更内容。nilの項目は変更しない
+type FavoriteListChange struct {
+	Name        *string
+	Description *string
+	Position    *int
+}
+
+func NewFavoriteList(name string, description string) (*FavoriteList, error) {
+	change, err := NewFavoriteListChange(&name, &description, nil)
+	if err != nil {
+		return nil, err
+	}
+	list := &FavoriteList{}
+	list.Apply(change)
+	return list, nil
+}
+
+func NewFavoriteListChange(name *string, description *string, position *int) (*FavoriteListChange, error) {
+	change := &FavoriteListChange{Description: description, Position: position}
+	if name != nil {
+		n := strings.TrimSpace(*name)
+		if n == "" {
+			return nil, errors.New("name is required")
+		}
+		if utf8.RuneCountInString(n) > maxListNameLength {
+			return nil, errors.New("name is too long")
+		}
+		change.Name = &n
+	}
+	if description != nil && utf8.RuneCountInString(*description) > maxListDescriptionLength {
+		return nil, errors.New("description is too long")
+	}
+	if position != nil && *position < 0 {
+		return nil, errors.New("position must be a non-negative integer")
+	}
+	return change, nil
+}
+
+// 名前と説明を変更する。並び順はMoveFavoriteListで変更する
+func (l *FavoriteList) Apply(change *FavoriteListChange) {
+	if change.Name != nil {
+		l.Name = *change.Name
+	}
+	if change.Description != nil {
+		l.Description = *change.Description
+	}
+}
+
+func (l *FavoriteList) HasStore(storeId string) bool {
+	for _, s := range l.Stores {
+		if s.Id == storeId {
+			return true
+		}
+	}
+	return false
+}
+
+// idのリストをpositionの位置に移動し、全てのリストのPositionを並び順に振り直す。
+// positionが範囲外の場合は先頭または末尾に移動する
+func MoveFavoriteList(lists []*FavoriteList, id string, position int) ([]*FavoriteList, error) {
+	moved := make([]*FavoriteList, 0, len(lists))
+	var target *FavoriteList
+	for _, l := range lists {
+		if l.Id == id {
+			target = l
+			continue
+		}
+		moved = append(moved, l)
+	}
+	if target == nil {
+		return nil, errors.New("list is not found")
+	}
+	position = max(0, min(position, len(moved)))
+	moved = append(moved[:position], append([]*FavoriteList{target}, moved[position:]...)...)
+	for i, l := range moved {
+		l.Position = i
+	}
+	return moved, nil
+}
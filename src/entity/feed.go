@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// フォローしているユーザーがお気に入り登録した店舗
+type FeedItem struct {
+	User        User // IdとNameのみ設定する
+	Store       *Store
+	FavoritedAt time.Time
+}
+
+// フィードで最後に返した項目の位置。同じ日時の項目はユーザーと店舗のidの順に並べる
+type FeedCursor struct {
+	FavoritedAt time.Time
+	UserId      string
+	StoreId     string
+}
+
+// フィードのページングの指定。Cursorがnilの場合は最新の項目から返す
+type FeedPageRequest struct {
+	Cursor *FeedCursor
+	Limit  int
+}
+
+type feedCursorJson struct {
+	FavoritedAt time.Time `json:"f"`
+	UserId      string    `json:"u"`
+	StoreId     string    `json:"s"`
+}
+
+func (c *FeedCursor) Encode() string {
+	encoded, _ := json.Marshal(feedCursorJson{FavoritedAt: c.FavoritedAt, UserId: c.UserId, StoreId: c.StoreId})
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func DecodeFeedCursor(s string) (*FeedCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("cursor is invalid")
+	}
+	var c feedCursorJson
+	if err := json.Unmarshal(decoded, &c); err != nil {
+		return nil, errors.New("cursor is invalid")
+	}
+	if c.FavoritedAt.IsZero() || c.UserId == "" || c.StoreId == "" {
+		return nil, errors.New("cursor is invalid")
+	}
+	return &FeedCursor{FavoritedAt: c.FavoritedAt, UserId: c.UserId, StoreId: c.StoreId}, nil
+}
+
+// limitは店舗の一覧と同じ範囲で指定する
+func NewFeedPageRequest(cursor string, limit string) (*FeedPageRequest, error) {
+	page, err := NewPageRequest("", limit)
+	if err != nil {
+		return nil, err
+	}
+	feedPage := &FeedPageRequest{Limit: page.Limit}
+	if cursor != "" {
+		if feedPage.Cursor, err = DecodeFeedCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+	return feedPage, nil
+}
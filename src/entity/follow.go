@@ -0,0 +1,25 @@
+package model
+
+import (
+	"errors"
+)
+
+// ユーザーが自分のお気に入りを誰に公開するか
+type Visibility string
+
+const (
+	VisibilityPublic    Visibility = "public"    // 誰にでも公開する
+	VisibilityFollowers Visibility = "followers" // フォロワーにのみ公開する
+	VisibilityPrivate   Visibility = "private"   // 自分以外には公開しない(初期値)
+)
+
+// フォロワーがお気に入りを閲覧できる公開範囲
+var FollowerVisibilities = []Visibility{VisibilityPublic, VisibilityFollowers}
+
+func NewVisibility(s string) (Visibility, error) {
+	switch v := Visibility(s); v {
+	case VisibilityPublic, VisibilityFollowers, VisibilityPrivate:
+		return v, nil
+	}
+	return "", errors.New("visibility must be public, followers or private, got " + s)
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// 地球の平均半径(メートル)
+const EarthRadiusMeters = 6371008.8
+
+// 表示範囲内の店舗として一度に返す最大の件数
+const MaxViewportStores = 500
+
+// 距離や方位を計算するための数値の緯度経度
+type Coordinate struct {
+	Lat float64
+	Lng float64
+}
+
+func (l Location) Coordinate() (Coordinate, error) {
+	if err := l.Validate(); err != nil {
+		return Coordinate{}, err
+	}
+	lat, _ := strconv.ParseFloat(l.Lat, 64)
+	lng, _ := strconv.ParseFloat(l.Lng, 64)
+	return Coordinate{Lat: lat, Lng: lng}, nil
+}
+
+// "緯度,経度"の形式の文字列を読み取る
+func ParseCoordinate(s string) (*Coordinate, error) {
+	lat, lng, ok := strings.Cut(s, ",")
+	if !ok {
+		return nil, errors.New("origin must be latitude,longitude")
+	}
+	c, err := Location{Lat: strings.TrimSpace(lat), Lng: strings.TrimSpace(lng)}.Coordinate()
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// 地図の表示範囲。MinLngがMaxLngより大きい場合は経度180度の線(日付変更線)をまたぐ範囲とする
+type BoundingBox struct {
+	MinLng float64
+	MinLat float64
+	MaxLng float64
+	MaxLat float64
+}
+
+// "西端の経度,南端の緯度,東端の経度,北端の緯度"の形式の文字列を読み取る
+func ParseBoundingBox(s string) (*BoundingBox, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return nil, errors.New("bbox must be minLng,minLat,maxLng,maxLat")
+	}
+	southWest, err := Location{Lat: strings.TrimSpace(parts[1]), Lng: strings.TrimSpace(parts[0])}.Coordinate()
+	if err != nil {
+		return nil, err
+	}
+	northEast, err := Location{Lat: strings.TrimSpace(parts[3]), Lng: strings.TrimSpace(parts[2])}.Coordinate()
+	if err != nil {
+		return nil, err
+	}
+	if southWest.Lat > northEast.Lat {
+		return nil, errors.New("minLat must be less than or equal to maxLat")
+	}
+	return &BoundingBox{MinLng: southWest.Lng, MinLat: southWest.Lat, MaxLng: northEast.Lng, MaxLat: northEast.Lat}, nil
+}
+
+func (b *BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+func (b *BoundingBox) Contains(c Coordinate) bool {
+	if c.Lat < b.MinLat || c.Lat > b.MaxLat {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return c.Lng >= b.MinLng || c.Lng <= b.MaxLng
+	}
+	return c.Lng >= b.MinLng && c.Lng <= b.MaxLng
+}
+
+// haversine公式による2点間の大円距離(メートル)
+func Distance(from Coordinate, to Coordinate) float64 {
+	lat1 := toRadians(from.Lat)
+	lat2 := toRadians(to.Lat)
+	dLat := lat2 - lat1
+	dLng := toRadians(to.Lng - from.Lng)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// fromから見たtoの方位(北を0として時計回りに0以上360未満の度数)
+func Bearing(from Coordinate, to Coordinate) float64 {
+	lat1 := toRadians(from.Lat)
+	lat2 := toRadians(to.Lat)
+	dLng := toRadians(to.Lng - from.Lng)
+	y := math.Sin(dLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	return math.Mod(toDegrees(math.Atan2(y, x))+360, 360)
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
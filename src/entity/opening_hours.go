@@ -0,0 +1,115 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const minutesPerWeek = 7 * 24 * 60
+
+// 曜日と時刻。Places APIのregularOpeningHours.periodsと同じく0が日曜日
+type DayTime struct {
+	Day    time.Weekday
+	Hour   int
+	Minute int
+}
+
+// 営業時間の1区間。Closeがnilの場合は24時間営業を表す
+type OpeningPeriod struct {
+	Open  DayTime
+	Close *DayTime
+}
+
+// 週単位の営業時間。区間を持たない曜日は定休日となる
+type OpeningHours struct {
+	Periods          []OpeningPeriod
+	UtcOffsetMinutes int // 店舗の所在地のUTCからの差分(日本なら540)
+}
+
+func (d DayTime) validate() error {
+	if d.Day < time.Sunday || d.Day > time.Saturday {
+		return fmt.Errorf("day must be between 0 and 6, got %d", d.Day)
+	}
+	// 閉店時刻は24:00と表現されることがあるため許容する
+	if d.Hour == 24 && d.Minute == 0 {
+		return nil
+	}
+	if d.Hour < 0 || d.Hour > 23 {
+		return fmt.Errorf("hour must be between 0 and 23, got %d", d.Hour)
+	}
+	if d.Minute < 0 || d.Minute > 59 {
+		return fmt.Errorf("minute must be between 0 and 59, got %d", d.Minute)
+	}
+	return nil
+}
+
+// 週の始まり(日曜0:00)からの経過分
+func (d DayTime) minuteOfWeek() int {
+	return int(d.Day)*24*60 + d.Hour*60 + d.Minute
+}
+
+func NewOpeningHours(periods []OpeningPeriod, utcOffsetMinutes int) (*OpeningHours, error) {
+	if utcOffsetMinutes < -12*60 || utcOffsetMinutes > 14*60 {
+		return nil, fmt.Errorf("utcOffsetMinutes must be between -720 and 840, got %d", utcOffsetMinutes)
+	}
+	for _, p := range periods {
+		if err := p.Open.validate(); err != nil {
+			return nil, err
+		}
+		if p.Close == nil {
+			continue
+		}
+		if err := p.Close.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if len(periods) == 0 {
+		return nil, errors.New("periods must not be empty")
+	}
+	return &OpeningHours{Periods: periods, UtcOffsetMinutes: utcOffsetMinutes}, nil
+}
+
+// 24時間営業かどうか
+func (oh *OpeningHours) IsAlwaysOpen() bool {
+	for _, p := range oh.Periods {
+		if p.Close == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// 指定した曜日に開店する区間がない場合は定休日とする(前日からの深夜営業は含めない)
+func (oh *OpeningHours) IsClosedOn(day time.Weekday) bool {
+	if oh.IsAlwaysOpen() {
+		return false
+	}
+	for _, p := range oh.Periods {
+		if p.Open.Day == day {
+			return false
+		}
+	}
+	return true
+}
+
+// 時刻tに営業しているかを店舗のタイムゾーンで判定する
+func (oh *OpeningHours) IsOpenAt(t time.Time) bool {
+	if oh.IsAlwaysOpen() {
+		return true
+	}
+	local := t.In(time.FixedZone("", oh.UtcOffsetMinutes*60))
+	now := DayTime{Day: local.Weekday(), Hour: local.Hour(), Minute: local.Minute()}.minuteOfWeek()
+	for _, p := range oh.Periods {
+		open := p.Open.minuteOfWeek()
+		close := p.Close.minuteOfWeek()
+		// 土曜の夜から日曜の朝のように週をまたぐ区間は閉店時刻を翌週として扱う
+		if close <= open {
+			close += minutesPerWeek
+		}
+		if (open <= now && now < close) || (open <= now+minutesPerWeek && now+minutesPerWeek < close) {
+			return true
+		}
+	}
+	return false
+}
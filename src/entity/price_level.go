@@ -0,0 +1,164 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Places APIのpriceLevelと同じ値を持つ価格帯
+type PriceLevel string
+
+const (
+	PriceLevelUnknown       PriceLevel = "" // Places APIが価格帯を返さなかった場合
+	PriceLevelFree          PriceLevel = "PRICE_LEVEL_FREE"
+	PriceLevelInexpensive   PriceLevel = "PRICE_LEVEL_INEXPENSIVE"
+	PriceLevelModerate      PriceLevel = "PRICE_LEVEL_MODERATE"
+	PriceLevelExpensive     PriceLevel = "PRICE_LEVEL_EXPENSIVE"
+	PriceLevelVeryExpensive PriceLevel = "PRICE_LEVEL_VERY_EXPENSIVE"
+)
+
+// 安い順に並べた価格帯
+var priceLevels = []PriceLevel{
+	PriceLevelFree,
+	PriceLevelInexpensive,
+	PriceLevelModerate,
+	PriceLevelExpensive,
+	PriceLevelVeryExpensive,
+}
+
+// 空文字とPRICE_LEVEL_UNSPECIFIEDは価格帯が不明なものとして扱い、それ以外の未知の値はエラーとする
+func ParsePriceLevel(s string) (PriceLevel, error) {
+	if s == "" || s == "PRICE_LEVEL_UNSPECIFIED" {
+		return PriceLevelUnknown, nil
+	}
+	for _, level := range priceLevels {
+		if string(level) == s {
+			return level, nil
+		}
+	}
+	return PriceLevelUnknown, errors.New("priceLevel is invalid, got " + s)
+}
+
+// 安い順の順位(0が無料)。不明な場合は-1を返す
+func (p PriceLevel) Rank() int {
+	for i, level := range priceLevels {
+		if level == p {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p PriceLevel) IsKnown() bool {
+	return p.Rank() >= 0
+}
+
+// pがotherより安い価格帯の場合にtrueを返す。不明な価格帯は最も高いものとして扱う
+func (p PriceLevel) Less(other PriceLevel) bool {
+	if !other.IsKnown() {
+		return p.IsKnown()
+	}
+	return p.IsKnown() && p.Rank() < other.Rank()
+}
+
+// 価格帯ごとの一人あたりの金額(円)の範囲。上限と下限を含む
+type PriceRange struct {
+	Min int
+	Max int // 上限がない場合はmath.MaxInt
+}
+
+type PriceRanges map[PriceLevel]PriceRange
+
+func DefaultPriceRanges() PriceRanges {
+	return PriceRanges{
+		PriceLevelFree:          {Min: 0, Max: 0},
+		PriceLevelInexpensive:   {Min: 1, Max: 999},
+		PriceLevelModerate:      {Min: 1000, Max: 2999},
+		PriceLevelExpensive:     {Min: 3000, Max: 9999},
+		PriceLevelVeryExpensive: {Min: 10000, Max: math.MaxInt},
+	}
+}
+
+// "PRICE_LEVEL_INEXPENSIVE=1-999,PRICE_LEVEL_VERY_EXPENSIVE=10000-"の形式で指定された範囲で既定値を上書きする
+func ParsePriceRanges(s string) (PriceRanges, error) {
+	ranges := DefaultPriceRanges()
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, bounds, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, errors.New("price range must be LEVEL=MIN-MAX, got " + entry)
+		}
+		level, err := ParsePriceLevel(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		if !level.IsKnown() {
+			return nil, errors.New("price range must be LEVEL=MIN-MAX, got " + entry)
+		}
+		minText, maxText, ok := strings.Cut(bounds, "-")
+		if !ok {
+			return nil, errors.New("price range must be LEVEL=MIN-MAX, got " + entry)
+		}
+		r := PriceRange{Max: math.MaxInt}
+		if r.Min, err = strconv.Atoi(strings.TrimSpace(minText)); err != nil || r.Min < 0 {
+			return nil, errors.New("price range is invalid, got " + entry)
+		}
+		if maxText = strings.TrimSpace(maxText); maxText != "" {
+			if r.Max, err = strconv.Atoi(maxText); err != nil || r.Max < r.Min {
+				return nil, errors.New("price range is invalid, got " + entry)
+			}
+		}
+		ranges[level] = r
+	}
+	return ranges, nil
+}
+
+// 金額(円)による絞り込み条件
+type PriceFilter struct {
+	Min    int
+	Max    int // 上限がない場合はmath.MaxInt
+	Ranges PriceRanges
+}
+
+// minPrice・maxPriceがどちらも空の場合は絞り込まないためnilを返す
+func NewPriceFilter(minPrice string, maxPrice string, ranges PriceRanges) (*PriceFilter, error) {
+	if minPrice == "" && maxPrice == "" {
+		return nil, nil
+	}
+	if ranges == nil {
+		ranges = DefaultPriceRanges()
+	}
+	filter := &PriceFilter{Min: 0, Max: math.MaxInt, Ranges: ranges}
+	if minPrice != "" {
+		m, err := strconv.Atoi(minPrice)
+		if err != nil || m < 0 {
+			return nil, errors.New("minPrice must be a non-negative integer, got " + minPrice)
+		}
+		filter.Min = m
+	}
+	if maxPrice != "" {
+		m, err := strconv.Atoi(maxPrice)
+		if err != nil || m < 0 {
+			return nil, errors.New("maxPrice must be a non-negative integer, got " + maxPrice)
+		}
+		filter.Max = m
+	}
+	if filter.Min > filter.Max {
+		return nil, errors.New("minPrice must be less than or equal to maxPrice")
+	}
+	return filter, nil
+}
+
+// 価格帯の金額の範囲が指定の範囲と重なる場合にtrueを返す。価格帯が不明な店舗は除外する
+func (f *PriceFilter) Match(level PriceLevel) bool {
+	r, ok := f.Ranges[level]
+	if !ok {
+		return false
+	}
+	return r.Min <= f.Max && f.Min <= r.Max
+}
@@ -0,0 +1,164 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ランキングを集計する期間の種類
+type RankingWindow string
+
+const (
+	WindowDay    RankingWindow = "day"
+	WindowWeek   RankingWindow = "week"
+	WindowMonth  RankingWindow = "month"
+	WindowAll    RankingWindow = "all"
+	WindowCustom RankingWindow = "custom" // fromとtoで期間を指定する
+)
+
+const (
+	DefaultRankingLimit = 10
+	MaxRankingLimit     = 50
+	// 属性で絞り込んだランキングでは、これより少ない人数にしか登録されていない店舗を出さない(個人を特定されないため)
+	MinSegmentUsers = 5
+)
+
+// ランキングの集計条件。Since以降Until以前に登録されたお気に入りやレビューを数える
+type Ranking struct {
+	Since   *time.Time // nilの場合は最初から
+	Until   *time.Time // nilの場合は現在まで
+	Limit   int
+	Segment *Segment // 指定した属性のユーザーのみで集計する(nilの場合は全員)
+}
+
+// ランキングを集計するユーザーの属性。nilの項目では絞り込まない
+type Segment struct {
+	Age       *int // AgeFormatした年代(20なら20代、60は60代以上)
+	MinSex    *float32
+	MaxSex    *float32
+	MinGender *float32
+	MaxGender *float32
+}
+
+// windowを省略した場合は、fromかtoがあれば期間指定、なければ1週間とする。from・toはRFC3339形式
+func NewRanking(window string, from string, to string, limit string, segment *Segment, now time.Time) (*Ranking, error) {
+	w := RankingWindow(window)
+	if w == "" {
+		w = WindowWeek
+		if from != "" || to != "" {
+			w = WindowCustom
+		}
+	}
+	if w != WindowCustom && (from != "" || to != "") {
+		return nil, errors.New("from and to can only be specified with window=custom")
+	}
+
+	ranking := &Ranking{Limit: DefaultRankingLimit, Segment: segment}
+	switch w {
+	case WindowDay:
+		ranking.Since = timePtr(now.Add(-24 * time.Hour))
+	case WindowWeek:
+		ranking.Since = timePtr(now.AddDate(0, 0, -7))
+	case WindowMonth:
+		ranking.Since = timePtr(now.AddDate(0, -1, 0))
+	case WindowAll:
+	case WindowCustom:
+		if from == "" && to == "" {
+			return nil, errors.New("from or to is required for window=custom")
+		}
+		if from != "" {
+			t, err := time.Parse(time.RFC3339, from)
+			if err != nil {
+				return nil, errors.New("from must be RFC3339 format")
+			}
+			ranking.Since = &t
+		}
+		if to != "" {
+			t, err := time.Parse(time.RFC3339, to)
+			if err != nil {
+				return nil, errors.New("to must be RFC3339 format")
+			}
+			ranking.Until = &t
+		}
+		if ranking.Since != nil && ranking.Until != nil && ranking.Since.After(*ranking.Until) {
+			return nil, errors.New("from must be before to")
+		}
+	default:
+		return nil, errors.New("window must be day, week, month, all or custom, got " + window)
+	}
+
+	if limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, errors.New("limit is invalid")
+		}
+		if l < 1 || l > MaxRankingLimit {
+			return nil, errors.New("limit must be between 1 and 50, got " + limit)
+		}
+		ranking.Limit = l
+	}
+	return ranking, nil
+}
+
+// 1店舗のお気に入りやレビューを数える際に必要な最小の人数
+func (r *Ranking) MinUsers() int {
+	if r.Segment == nil {
+		return 1
+	}
+	return MinSegmentUsers
+}
+
+// 性別と性自認の範囲は-1.0~1.0。すべて空の場合はnilを返す
+func NewSegment(age string, minSex string, maxSex string, minGender string, maxGender string) (*Segment, error) {
+	if age == "" && minSex == "" && maxSex == "" && minGender == "" && maxGender == "" {
+		return nil, nil
+	}
+	segment := &Segment{}
+	if age != "" {
+		a, err := strconv.Atoi(age)
+		if err != nil || a < 0 || a != AgeFormat(a) {
+			return nil, errors.New("age must be one of 0, 10, 20, 30, 40, 50 and 60, got " + age)
+		}
+		segment.Age = &a
+	}
+	var err error
+	if segment.MinSex, segment.MaxSex, err = parseSpectrumRange("Sex", minSex, maxSex); err != nil {
+		return nil, err
+	}
+	if segment.MinGender, segment.MaxGender, err = parseSpectrumRange("Gender", minGender, maxGender); err != nil {
+		return nil, err
+	}
+	return segment, nil
+}
+
+func parseSpectrumRange(name string, min string, max string) (*float32, *float32, error) {
+	lower, err := parseSpectrum("min"+name, min)
+	if err != nil {
+		return nil, nil, err
+	}
+	upper, err := parseSpectrum("max"+name, max)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lower != nil && upper != nil && *lower > *upper {
+		return nil, nil, errors.New("min" + name + " must be less than or equal to max" + name)
+	}
+	return lower, upper, nil
+}
+
+func parseSpectrum(param string, value string) (*float32, error) {
+	if value == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(value, 32)
+	if err != nil || v < -1.0 || v > 1.0 {
+		return nil, errors.New(param + " must be between -1.0 and 1.0, got " + value)
+	}
+	f := float32(v)
+	return &f, nil
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// おすすめした理由
+type RecommendationReason string
+
+const (
+	ReasonSimilarUsers RecommendationReason = "similar_users" // お気に入りの似たユーザーが登録している
+	ReasonPopular      RecommendationReason = "popular"       // 多くのユーザーが登録している(似たユーザーがいない場合)
+)
+
+const (
+	DefaultRecommendationLimit = 10
+	MaxRecommendationLimit     = 50
+)
+
+// まだお気に入り登録していない店舗のおすすめ
+type Recommendation struct {
+	StoreId string
+	Store   *Store  // 店舗の情報を取得した後に設定する
+	Score   float64 // 0~1で、高いほどおすすめ
+	Reason  RecommendationReason
+}
+
+type RecommendationOptions struct {
+	Limit       int
+	Demographic bool // 年代・性別・性自認の近いユーザーを重視する
+}
+
+// ユーザーとお気に入り登録した店舗のid
+type UserFavorites struct {
+	User     User
+	StoreIds []string
+}
+
+func NewRecommendationOptions(limit string, demographic string) (*RecommendationOptions, error) {
+	options := &RecommendationOptions{Limit: DefaultRecommendationLimit}
+	if limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, errors.New("limit is invalid")
+		}
+		if l < 1 || l > MaxRecommendationLimit {
+			return nil, errors.New("limit must be between 1 and 50, got " + limit)
+		}
+		options.Limit = l
+	}
+	if demographic != "" {
+		d, err := strconv.ParseBool(demographic)
+		if err != nil {
+			return nil, errors.New("demographic must be true or false")
+		}
+		options.Demographic = d
+	}
+	return options, nil
+}
+
+// ユーザー間の協調フィルタリングでtargetがまだ登録していない店舗をおすすめの高い順に最大limit件返す。
+// 各店舗のスコアは、似ているユーザーのうちその店舗を登録している割合を類似度で重み付けしたもの
+func RecommendFromSimilarUsers(target *UserFavorites, others []*UserFavorites, options *RecommendationOptions) []*Recommendation {
+	if len(target.StoreIds) == 0 {
+		return []*Recommendation{}
+	}
+	favorited := make(map[string]bool)
+	for _, id := range target.StoreIds {
+		favorited[id] = true
+	}
+
+	scores := make(map[string]float64)
+	totalWeight := 0.0
+	for _, other := range others {
+		if other.User.Id == target.User.Id {
+			continue
+		}
+		weight := favoriteSimilarity(favorited, other.StoreIds)
+		if options.Demographic {
+			weight *= demographicCloseness(target.User, other.User)
+		}
+		if weight <= 0 {
+			continue
+		}
+		totalWeight += weight
+		for _, id := range other.StoreIds {
+			if !favorited[id] {
+				scores[id] += weight
+			}
+		}
+	}
+
+	recommendations := make([]*Recommendation, 0, len(scores))
+	for id, score := range scores {
+		recommendations = append(recommendations, &Recommendation{StoreId: id, Score: score / totalWeight, Reason: ReasonSimilarUsers})
+	}
+	sortRecommendations(recommendations)
+	return recommendations[:min(options.Limit, len(recommendations))]
+}
+
+// 人気の店舗のうちexcludeIdsに含まれないものを、登録数の最も多い店舗を1としたスコアで最大limit件返す
+func RecommendPopular(popular []*Store, excludeIds []string, limit int) []*Recommendation {
+	excluded := make(map[string]bool)
+	for _, id := range excludeIds {
+		excluded[id] = true
+	}
+	maxCount := 0
+	for _, s := range popular {
+		if s.FavoriteCount != nil && *s.FavoriteCount > maxCount {
+			maxCount = *s.FavoriteCount
+		}
+	}
+	recommendations := make([]*Recommendation, 0)
+	for _, s := range popular {
+		if excluded[s.Id] || s.FavoriteCount == nil || maxCount == 0 {
+			continue
+		}
+		recommendations = append(recommendations, &Recommendation{
+			StoreId: s.Id,
+			Store:   s,
+			Score:   float64(*s.FavoriteCount) / float64(maxCount),
+			Reason:  ReasonPopular,
+		})
+	}
+	sortRecommendations(recommendations)
+	return recommendations[:min(limit, len(recommendations))]
+}
+
+// お気に入りの集合のコサイン類似度
+func favoriteSimilarity(favorited map[string]bool, storeIds []string) float64 {
+	if len(favorited) == 0 || len(storeIds) == 0 {
+		return 0
+	}
+	common := 0
+	for _, id := range storeIds {
+		if favorited[id] {
+			common++
+		}
+	}
+	return float64(common) / math.Sqrt(float64(len(favorited)*len(storeIds)))
+}
+
+// 年代・性別・性自認の近さを0~1で返す。年代は最大60、性別と性自認は最大2離れる
+func demographicCloseness(a User, b User) float64 {
+	age := math.Abs(float64(a.Age-b.Age)) / 60
+	sex := math.Abs(float64(a.Sex-b.Sex)) / 2
+	gender := math.Abs(float64(a.Gender-b.Gender)) / 2
+	return 1 - (min(age, 1)+sex+gender)/3
+}
+
+// スコアの高い順、同じ場合は店舗のid順に並べる
+func sortRecommendations(recommendations []*Recommendation) {
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].StoreId < recommendations[j].StoreId
+	})
+}
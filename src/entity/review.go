@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MinRating           = 1
+	MaxRating           = 5
+	maxReviewTextLength = 2000
+)
+
+// ユーザーが店舗に付けた評価とレビュー。1人のユーザーは1つの店舗に1件のみ書ける
+type Review struct {
+	StoreId   string
+	StoreName string // 取得時のみ設定する
+	UserId    string
+	UserName  string // 取得時のみ設定する
+	Rating    int    // 1~5
+	Text      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// 店舗ごとのレビューの集計
+type ReviewSummary struct {
+	Average float64 // レビューがない場合は0
+	Count   int
+}
+
+func NewReview(storeId string, userId string, rating int, text string) (*Review, error) {
+	if rating < MinRating || rating > MaxRating {
+		return nil, errors.New("rating must be between 1 and 5, got " + strconv.Itoa(rating))
+	}
+	if utf8.RuneCountInString(text) > maxReviewTextLength {
+		return nil, errors.New("text is too long")
+	}
+	return &Review{StoreId: storeId, UserId: userId, Rating: rating, Text: text}, nil
+}
+
+func (r *ReviewSummary) HasReviews() bool {
+	return r != nil && r.Count > 0
+}
+
+// rがotherより評価の高い場合にtrueを返す。平均が同じ場合は件数の多い方を高いものとし、レビューがない場合は最も低いものとして扱う
+func (r *ReviewSummary) Higher(other *ReviewSummary) bool {
+	if !other.HasReviews() {
+		return r.HasReviews()
+	}
+	if !r.HasReviews() {
+		return false
+	}
+	if r.Average != other.Average {
+		return r.Average > other.Average
+	}
+	return r.Count > other.Count
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// トークンのバイト数。推測されないよう十分な長さにする
+const shareTokenBytes = 32
+
+// お気に入りをログインなしで公開するためのリンク。取り消すまで有効
+type ShareLink struct {
+	Id        string // uuidを使用
+	Token     string // 作成時のみ設定される。保存するのはハッシュのみ
+	CreatedAt time.Time
+}
+
+// 推測できないトークンを持つリンクを作成する
+func NewShareLink() (*ShareLink, error) {
+	b := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return &ShareLink{Token: base64.RawURLEncoding.EncodeToString(b)}, nil
+}
+
+// 保存・照合に使うトークンのハッシュ
+func HashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -3,6 +3,8 @@ package model
 import (
 	"errors"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 type Location struct {
@@ -10,12 +12,51 @@ type Location struct {
 	Lng string
 }
 
+// 店舗を検索する範囲。Centerが空の場合はサーバの位置情報で代用する
+type SearchArea struct {
+	Center         Location
+	Radius         float64 // メートル
+	RankByDistance bool    // 中心から近い順に取得する(指定しない場合は人気順)
+}
+
+const (
+	DefaultSearchRadius = 500.0   // 半径が指定されなかった場合の検索半径
+	MaxSearchRadius     = 50000.0 // Places APIで指定可能な最大の半径
+)
+
+// キーワードによる店舗の検索条件
+type StoreSearch struct {
+	Query         string
+	Bias          *SearchArea // 指定した範囲の店舗を優先する(nilの場合は指定しない)
+	PriceLevels   []PriceLevel
+	IncludedTypes []string
+}
+
+const maxSearchQueryLength = 200
+
 type Store struct {
 	Id                  string
 	Name                string
 	RegularOpeningHours string
-	PriceLevel          string
+	PriceLevel          PriceLevel
 	Location            Location
+	OpeningHours        *OpeningHours  // 営業時間の構造化データ(不明な場合はnil)
+	DistanceMeters      *float64       // 検索の起点からの距離(起点が指定されなかった場合はnil)
+	Favorite            *Favorite      // お気に入りとして取得した場合のみ、ユーザーが付けたメモとタグ
+	Reviews             *ReviewSummary // このアプリでのレビューの集計(集計していない場合はnil)
+	FavoriteCount       *int           // ランキングとして取得した場合のみ、期間内にお気に入り登録された数
+}
+
+// Places Detailsから取得した店舗の詳細
+type StoreDetail struct {
+	Store
+	Address         string
+	PhoneNumber     string
+	WebsiteUri      string
+	Rating          float64 // Googleでの評価(1.0~5.0)
+	UserRatingCount int
+	Types           []string
+	BusinessStatus  string // OPERATIONAL, CLOSED_TEMPORARILY, CLOSED_PERMANENTLY
 }
 
 func (l Location) Validate() error {
@@ -46,14 +87,91 @@ func NewStore(id string, name string, regularOpeningHours string, priceLevel str
 	if err := location.Validate(); err != nil {
 		return nil, err
 	}
+	level, err := ParsePriceLevel(priceLevel)
+	if err != nil {
+		return nil, err
+	}
 
 	store := &Store{
 		Id:                  id,
 		Name:                name,
 		RegularOpeningHours: regularOpeningHours,
-		PriceLevel:          priceLevel,
+		PriceLevel:          level,
 		Location:            location,
 	}
 
 	return store, nil
 }
+
+// 起点からの距離を設定する。位置が不正な場合は距離をnilにする
+func (s *Store) SetDistanceFrom(origin Coordinate) {
+	c, err := s.Location.Coordinate()
+	if err != nil {
+		s.DistanceMeters = nil
+		return
+	}
+	d := Distance(origin, c)
+	s.DistanceMeters = &d
+}
+
+func NewSearchArea(lat string, lng string, radius string) (*SearchArea, error) {
+	area := &SearchArea{Radius: DefaultSearchRadius}
+
+	if radius != "" {
+		r, err := strconv.ParseFloat(radius, 64)
+		if err != nil {
+			return nil, errors.New("radius is invalid")
+		}
+		if r <= 0 || r > MaxSearchRadius {
+			return nil, errors.New("radius must be greater than 0 and at most 50000, got " + radius)
+		}
+		area.Radius = r
+	}
+
+	// 緯度経度がどちらも指定されていない場合はサーバの位置情報を使うため中心を空のままにする
+	if lat == "" && lng == "" {
+		return area, nil
+	}
+	location := Location{Lat: lat, Lng: lng}
+	if err := location.Validate(); err != nil {
+		return nil, err
+	}
+	area.Center = location
+
+	return area, nil
+}
+
+func (a *SearchArea) HasCenter() bool {
+	return a.Center.Lat != "" || a.Center.Lng != ""
+}
+
+func NewStoreSearch(query string, bias *SearchArea, priceLevels []string, includedTypes []string) (*StoreSearch, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("query is required")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, errors.New("query must be at most 200 characters")
+	}
+	levels := make([]PriceLevel, 0)
+	for _, p := range priceLevels {
+		level, err := ParsePriceLevel(p)
+		if err != nil {
+			return nil, err
+		}
+		if !level.IsKnown() {
+			return nil, errors.New("priceLevel is invalid, got " + p)
+		}
+		levels = append(levels, level)
+	}
+	// 位置の偏りにはサーバの位置情報を使わないため、中心がない場合は指定しないものとする
+	if bias != nil && !bias.HasCenter() {
+		bias = nil
+	}
+	return &StoreSearch{
+		Query:         query,
+		Bias:          bias,
+		PriceLevels:   levels,
+		IncludedTypes: includedTypes,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// 店舗一覧の並び順
+type StoreSort string
+
+const (
+	SortByDefault  StoreSort = ""         // 取得元の順番のまま
+	SortByDistance StoreSort = "distance" // Originから近い順
+	SortByRating   StoreSort = "rating"   // レビューの平均の高い順
+)
+
+// 店舗一覧を返す際の絞り込み条件
+type StoreQuery struct {
+	OpenAt *time.Time  // 指定した時刻に営業している店舗のみに絞り込む
+	Origin *Coordinate // 指定した場合は各店舗までの距離を設定する
+	Sort   StoreSort
+	Price  *PriceFilter // 指定した金額の範囲に価格帯が重なる店舗のみに絞り込む
+	Tag    string       // 指定したタグを付けたお気に入りのみに絞り込む(大文字小文字は区別しない)
+}
+
+func NewStoreQuery(openAt *time.Time, origin *Coordinate, sortBy string, price *PriceFilter) (*StoreQuery, error) {
+	switch StoreSort(sortBy) {
+	case SortByDefault:
+	case SortByDistance:
+		if origin == nil {
+			return nil, errors.New("origin is required to sort by distance")
+		}
+	case SortByRating:
+	default:
+		return nil, errors.New("sort must be distance or rating, got " + sortBy)
+	}
+	return &StoreQuery{OpenAt: openAt, Origin: origin, Sort: StoreSort(sortBy), Price: price}, nil
+}
+
+func (q *StoreQuery) Match(store *Store) bool {
+	if q.OpenAt != nil {
+		// 営業時間が不明な店舗は営業しているか判断できないため除外する
+		if store.OpeningHours == nil || !store.OpeningHours.IsOpenAt(*q.OpenAt) {
+			return false
+		}
+	}
+	if q.Price != nil && !q.Price.Match(store.PriceLevel) {
+		return false
+	}
+	if q.Tag != "" && !store.Favorite.HasTag(q.Tag) {
+		return false
+	}
+	return true
+}
+
+func (q *StoreQuery) SortsByDistance() bool {
+	return q != nil && q.Sort == SortByDistance
+}
+
+func (q *StoreQuery) SortsByRating() bool {
+	return q != nil && q.Sort == SortByRating
+}
+
+// 条件に合う店舗のみを返す。Originがあれば距離を設定し、距離順・評価順の指定があれば並べ替える
+func (q *StoreQuery) Apply(stores []*Store) []*Store {
+	if q == nil {
+		return stores
+	}
+	filtered := make([]*Store, 0, len(stores))
+	for _, s := range stores {
+		if q.Match(s) {
+			filtered = append(filtered, s)
+		}
+	}
+	if q.Origin != nil {
+		for _, s := range filtered {
+			s.SetDistanceFrom(*q.Origin)
+		}
+	}
+	if q.Sort == SortByDistance {
+		// 位置が不正で距離がわからない店舗は最後にする
+		sort.SliceStable(filtered, func(i, j int) bool {
+			if filtered[i].DistanceMeters == nil || filtered[j].DistanceMeters == nil {
+				return filtered[j].DistanceMeters == nil && filtered[i].DistanceMeters != nil
+			}
+			return *filtered[i].DistanceMeters < *filtered[j].DistanceMeters
+		})
+	}
+	if q.Sort == SortByRating {
+		// レビューの集計がない店舗は最後にする
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Reviews.Higher(filtered[j].Reviews)
+		})
+	}
+	return filtered
+}
@@ -0,0 +1,100 @@
+// 地図に表示する店舗をズームレベルに応じてまとめる
+package cluster
+
+import (
+	model "clean-storemap-api/src/entity"
+	"math"
+	"sort"
+)
+
+const (
+	// このズームレベル以上では店舗をまとめずに1店舗ずつ返す
+	MaxClusterZoom = 17
+	// ズームレベル0で世界全体を表すタイルの幅(ピクセル)
+	tileSize = 256
+	// 1つのまとまりとする格子の幅(ピクセル)。タイルの幅を割り切れる値にして、格子が経度180度の線をまたがないようにする
+	cellSize = 64
+	// まとまりごとに返す代表の店舗の数
+	maxRepresentatives = 3
+	// Webメルカトル図法で表せる緯度の上限
+	maxMercatorLat = 85.05112878
+)
+
+type cell struct {
+	x int
+	y int
+}
+
+type member struct {
+	id         string
+	coordinate model.Coordinate
+}
+
+// 店舗をWebメルカトル図法の画面上で一定の幅の格子に分け、同じ格子に入る店舗を1つのまとまりとする。
+// 位置が不正な店舗は含めない。店舗数の多い順に、同じ場合は代表の店舗のid順に返す
+func Grid(stores []*model.Store, zoom int) []*model.StoreCluster {
+	cells := make(map[cell][]member)
+	order := make([]cell, 0)
+	for i, s := range stores {
+		c, err := s.Location.Coordinate()
+		if err != nil {
+			continue
+		}
+		key := cellOf(c, zoom)
+		if zoom >= MaxClusterZoom {
+			// 同じ格子にならないよう店舗ごとに別の格子とする
+			key = cell{x: -1, y: i}
+		}
+		if _, ok := cells[key]; !ok {
+			order = append(order, key)
+		}
+		cells[key] = append(cells[key], member{id: s.Id, coordinate: c})
+	}
+
+	clusters := make([]*model.StoreCluster, 0, len(order))
+	for _, key := range order {
+		clusters = append(clusters, newCluster(cells[key]))
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Count != clusters[j].Count {
+			return clusters[i].Count > clusters[j].Count
+		}
+		return clusters[i].StoreIds[0] < clusters[j].StoreIds[0]
+	})
+	return clusters
+}
+
+// 中心は店舗の位置の平均とし、中心に近い店舗を代表とする
+func newCluster(members []member) *model.StoreCluster {
+	var lat, lng float64
+	for _, m := range members {
+		lat += m.coordinate.Lat
+		lng += m.coordinate.Lng
+	}
+	center := model.Coordinate{Lat: lat / float64(len(members)), Lng: lng / float64(len(members))}
+	sort.SliceStable(members, func(i, j int) bool {
+		di := model.Distance(center, members[i].coordinate)
+		dj := model.Distance(center, members[j].coordinate)
+		if di != dj {
+			return di < dj
+		}
+		return members[i].id < members[j].id
+	})
+	ids := make([]string, 0, min(maxRepresentatives, len(members)))
+	for _, m := range members[:min(maxRepresentatives, len(members))] {
+		ids = append(ids, m.id)
+	}
+	return &model.StoreCluster{Center: center, Count: len(members), StoreIds: ids}
+}
+
+// ズームレベルzoomでの世界全体の画面上の位置から格子を求める
+func cellOf(c model.Coordinate, zoom int) cell {
+	worldSize := float64(tileSize) * math.Pow(2, float64(zoom))
+	lat := math.Max(-maxMercatorLat, math.Min(maxMercatorLat, c.Lat))
+	x := (c.Lng + 180) / 360 * worldSize
+	sinLat := math.Sin(lat * math.Pi / 180)
+	y := (0.5 - math.Log((1+sinLat)/(1-sinLat))/(4*math.Pi)) * worldSize
+	// 経度180度ちょうどの店舗は-180度と同じ格子に入れる
+	cells := int(worldSize / cellSize)
+	return cell{x: int(math.Floor(x/cellSize)) % cells, y: int(math.Floor(y / cellSize))}
+}
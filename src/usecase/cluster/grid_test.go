@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStore(id string, lat string, lng string) *model.Store {
+	return &model.Store{Id: id, Name: id, Location: model.Location{Lat: lat, Lng: lng}}
+}
+
+func TestGrid(t *testing.T) {
+	/* Arrange */
+	stores := []*model.Store{
+		newStore("tokyo1", "35.68", "139.76"),
+		newStore("osaka", "34.69", "135.50"),
+		newStore("tokyo2", "35.70", "139.70"),
+	}
+
+	/* Act */
+	clusters := Grid(stores, 5)
+
+	/* Assert */
+	// 近い店舗同士がまとまり、店舗数の多い順に並ぶこと
+	if assert.Len(t, clusters, 2) {
+		assert.Equal(t, 2, clusters[0].Count)
+		assert.ElementsMatch(t, []string{"tokyo1", "tokyo2"}, clusters[0].StoreIds)
+		assert.InDelta(t, 35.69, clusters[0].Center.Lat, 1e-9)
+		assert.InDelta(t, 139.73, clusters[0].Center.Lng, 1e-9)
+		assert.Equal(t, &model.StoreCluster{Center: model.Coordinate{Lat: 34.69, Lng: 135.50}, Count: 1, StoreIds: []string{"osaka"}}, clusters[1])
+	}
+}
+
+func TestGridAtHighZoom(t *testing.T) {
+	/* Arrange */
+	stores := []*model.Store{
+		newStore("Id001", "35.6812", "139.7671"),
+		newStore("Id002", "35.6812", "139.7671"),
+	}
+
+	/* Act */
+	clusters := Grid(stores, MaxClusterZoom)
+
+	/* Assert */
+	// 同じ位置の店舗でもまとめずに1店舗ずつ返すこと
+	assert.Equal(t, []*model.StoreCluster{
+		{Center: model.Coordinate{Lat: 35.6812, Lng: 139.7671}, Count: 1, StoreIds: []string{"Id001"}},
+		{Center: model.Coordinate{Lat: 35.6812, Lng: 139.7671}, Count: 1, StoreIds: []string{"Id002"}},
+	}, clusters)
+}
+
+func TestGridAcrossAntimeridian(t *testing.T) {
+	/* Arrange */
+	stores := []*model.Store{
+		newStore("east", "-16.5", "179.99"),
+		newStore("west", "-16.5", "-179.99"),
+		newStore("west2", "-16.6", "-180"),
+	}
+
+	/* Act */
+	clusters := Grid(stores, 0)
+
+	/* Assert */
+	// 経度180度の線の両側はまとめず、中心が経度0度付近にならないこと。-180度と180度は同じ側とする
+	if assert.Len(t, clusters, 2) {
+		assert.ElementsMatch(t, []string{"west", "west2"}, clusters[0].StoreIds)
+		assert.Less(t, clusters[0].Center.Lng, -179.9)
+		assert.Equal(t, []string{"east"}, clusters[1].StoreIds)
+	}
+}
+
+func TestGridRepresentatives(t *testing.T) {
+	/* Arrange */
+	stores := []*model.Store{
+		newStore("far1", "35.60", "139.60"),
+		newStore("near1", "35.65", "139.65"),
+		newStore("center", "35.66", "139.66"),
+		newStore("near2", "35.67", "139.67"),
+		newStore("far2", "35.72", "139.72"),
+		newStore("invalid", "", ""),
+	}
+
+	/* Act */
+	clusters := Grid(stores, 3)
+
+	/* Assert */
+	// 位置の不正な店舗は含めず、代表は中心に近い3店舗とすること
+	if assert.Len(t, clusters, 1) {
+		assert.Equal(t, 5, clusters[0].Count)
+		assert.Equal(t, "center", clusters[0].StoreIds[0])
+		assert.ElementsMatch(t, []string{"center", "near1", "near2"}, clusters[0].StoreIds)
+	}
+}
@@ -0,0 +1,44 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	port "clean-storemap-api/src/usecase/port"
+)
+
+type CheckinInteractor struct {
+	checkinRepository port.CheckinRepository
+	checkinOutputPort port.CheckinOutputPort
+}
+
+func NewCheckinInputPort(checkinRepository port.CheckinRepository, checkinOutputPort port.CheckinOutputPort) port.CheckinInputPort {
+	return &CheckinInteractor{
+		checkinRepository: checkinRepository,
+		checkinOutputPort: checkinOutputPort,
+	}
+}
+
+func (ci *CheckinInteractor) GetCheckins(userId string, page *model.PageRequest) error {
+	checkins, next, err := ci.checkinRepository.GetCheckins(userId, page)
+	if err != nil {
+		return err
+	}
+	return ci.checkinOutputPort.OutputCheckins(checkins, next)
+}
+
+// 営業時間が分かっている店舗は営業時間外の訪問を受け付けない
+func (ci *CheckinInteractor) CreateCheckin(checkin *model.Checkin) error {
+	store, err := ci.checkinRepository.FindStore(checkin.StoreId)
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		return ci.checkinOutputPort.OutputStoreNotFound()
+	}
+	if !checkin.IsDuringOpeningHours(store) {
+		return ci.checkinOutputPort.OutputStoreClosed()
+	}
+	if err := ci.checkinRepository.CreateCheckin(store, checkin); err != nil {
+		return err
+	}
+	return ci.checkinOutputPort.OutputCreateCheckinResult()
+}
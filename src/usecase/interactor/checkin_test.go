@@ -0,0 +1,148 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCheckinRepository struct {
+	mock.Mock
+}
+type MockCheckinOutputPort struct {
+	mock.Mock
+}
+
+func (m *MockCheckinRepository) GetCheckins(userId string, page *model.PageRequest) ([]*model.Checkin, *model.Cursor, error) {
+	args := m.Called(userId, page)
+	return args.Get(0).([]*model.Checkin), args.Get(1).(*model.Cursor), args.Error(2)
+}
+
+func (m *MockCheckinRepository) FindStore(storeId string) (*model.Store, error) {
+	args := m.Called(storeId)
+	return args.Get(0).(*model.Store), args.Error(1)
+}
+
+func (m *MockCheckinRepository) CreateCheckin(store *model.Store, checkin *model.Checkin) error {
+	args := m.Called(store, checkin)
+	return args.Error(0)
+}
+
+func (m *MockCheckinOutputPort) OutputCheckins(checkins []*model.Checkin, next *model.Cursor) error {
+	args := m.Called(checkins, next)
+	return args.Error(0)
+}
+
+func (m *MockCheckinOutputPort) OutputCreateCheckinResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockCheckinOutputPort) OutputStoreNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockCheckinOutputPort) OutputStoreClosed() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// 平日の11:00~14:00に営業する日本の店舗
+func makeDummyLunchStore() *model.Store {
+	periods := make([]model.OpeningPeriod, 0)
+	for day := time.Monday; day <= time.Friday; day++ {
+		periods = append(periods, model.OpeningPeriod{
+			Open:  model.DayTime{Day: day, Hour: 11},
+			Close: &model.DayTime{Day: day, Hour: 14},
+		})
+	}
+	openingHours, _ := model.NewOpeningHours(periods, 540)
+	return &model.Store{Id: "Id001", Name: "UEC cafe", OpeningHours: openingHours}
+}
+
+func TestCreateCheckin(t *testing.T) {
+	/* Arrange */
+	store := makeDummyLunchStore()
+	// 2024/5/1(水) 12:30 JST
+	checkin := &model.Checkin{StoreId: "Id001", UserId: "user_1", VisitedAt: time.Date(2024, 5, 1, 3, 30, 0, 0, time.UTC), PartySize: 2}
+	mockCheckinRepository := new(MockCheckinRepository)
+	mockCheckinRepository.On("FindStore", "Id001").Return(store, nil)
+	mockCheckinRepository.On("CreateCheckin", store, checkin).Return(nil)
+	mockCheckinOutputPort := new(MockCheckinOutputPort)
+	mockCheckinOutputPort.On("OutputCreateCheckinResult").Return(nil)
+
+	ci := &CheckinInteractor{checkinRepository: mockCheckinRepository, checkinOutputPort: mockCheckinOutputPort}
+
+	/* Act */
+	actual := ci.CreateCheckin(checkin)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockCheckinRepository.AssertCalled(t, "CreateCheckin", store, checkin)
+	mockCheckinOutputPort.AssertNumberOfCalls(t, "OutputCreateCheckinResult", 1)
+}
+
+func TestCreateCheckinOutsideOpeningHours(t *testing.T) {
+	/* Arrange */
+	store := makeDummyLunchStore()
+	// 2024/5/1(水) 20:00 JST
+	checkin := &model.Checkin{StoreId: "Id001", UserId: "user_1", VisitedAt: time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)}
+	mockCheckinRepository := new(MockCheckinRepository)
+	mockCheckinRepository.On("FindStore", "Id001").Return(store, nil)
+	mockCheckinOutputPort := new(MockCheckinOutputPort)
+	mockCheckinOutputPort.On("OutputStoreClosed").Return(nil)
+
+	ci := &CheckinInteractor{checkinRepository: mockCheckinRepository, checkinOutputPort: mockCheckinOutputPort}
+
+	/* Act */
+	actual := ci.CreateCheckin(checkin)
+
+	/* Assert */
+	// 営業時間外の訪問は記録しないこと
+	assert.NoError(t, actual)
+	mockCheckinRepository.AssertNotCalled(t, "CreateCheckin", mock.Anything, mock.Anything)
+	mockCheckinOutputPort.AssertNumberOfCalls(t, "OutputStoreClosed", 1)
+}
+
+func TestCreateCheckinWithUnknownOpeningHours(t *testing.T) {
+	/* Arrange */
+	store := &model.Store{Id: "Id001", Name: "UEC cafe"}
+	checkin := &model.Checkin{StoreId: "Id001", UserId: "user_1", VisitedAt: time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)}
+	mockCheckinRepository := new(MockCheckinRepository)
+	mockCheckinRepository.On("FindStore", "Id001").Return(store, nil)
+	mockCheckinRepository.On("CreateCheckin", store, checkin).Return(nil)
+	mockCheckinOutputPort := new(MockCheckinOutputPort)
+	mockCheckinOutputPort.On("OutputCreateCheckinResult").Return(nil)
+
+	ci := &CheckinInteractor{checkinRepository: mockCheckinRepository, checkinOutputPort: mockCheckinOutputPort}
+
+	/* Act */
+	actual := ci.CreateCheckin(checkin)
+
+	/* Assert */
+	// 営業時間が分からない店舗はいつでも記録できること
+	assert.NoError(t, actual)
+	mockCheckinOutputPort.AssertNumberOfCalls(t, "OutputCreateCheckinResult", 1)
+}
+
+func TestCreateCheckinStoreNotFound(t *testing.T) {
+	/* Arrange */
+	checkin := &model.Checkin{StoreId: "Id999", UserId: "user_1", VisitedAt: time.Now()}
+	mockCheckinRepository := new(MockCheckinRepository)
+	mockCheckinRepository.On("FindStore", "Id999").Return((*model.Store)(nil), nil)
+	mockCheckinOutputPort := new(MockCheckinOutputPort)
+	mockCheckinOutputPort.On("OutputStoreNotFound").Return(nil)
+
+	ci := &CheckinInteractor{checkinRepository: mockCheckinRepository, checkinOutputPort: mockCheckinOutputPort}
+
+	/* Act */
+	actual := ci.CreateCheckin(checkin)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockCheckinOutputPort.AssertNumberOfCalls(t, "OutputStoreNotFound", 1)
+}
@@ -0,0 +1,139 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	port "clean-storemap-api/src/usecase/port"
+)
+
+type FavoriteListInteractor struct {
+	favoriteListRepository port.FavoriteListRepository
+	favoriteListOutputPort port.FavoriteListOutputPort
+}
+
+func NewFavoriteListInputPort(favoriteListRepository port.FavoriteListRepository, favoriteListOutputPort port.FavoriteListOutputPort) port.FavoriteListInputPort {
+	return &FavoriteListInteractor{
+		favoriteListRepository: favoriteListRepository,
+		favoriteListOutputPort: favoriteListOutputPort,
+	}
+}
+
+func (fi *FavoriteListInteractor) GetLists(userId string) error {
+	lists, err := fi.favoriteListRepository.GetLists(userId)
+	if err != nil {
+		return err
+	}
+	return fi.favoriteListOutputPort.OutputLists(lists)
+}
+
+func (fi *FavoriteListInteractor) GetList(listId string, userId string) error {
+	list, err := fi.favoriteListRepository.FindList(listId, userId)
+	if err != nil {
+		return err
+	}
+	if list == nil {
+		return fi.favoriteListOutputPort.OutputListNotFound()
+	}
+	return fi.favoriteListOutputPort.OutputList(list)
+}
+
+func (fi *FavoriteListInteractor) CreateList(list *model.FavoriteList, userId string) error {
+	if err := fi.favoriteListRepository.CreateList(list, userId); err != nil {
+		return err
+	}
+	return fi.favoriteListOutputPort.OutputList(list)
+}
+
+func (fi *FavoriteListInteractor) UpdateList(listId string, userId string, change *model.FavoriteListChange) error {
+	lists, err := fi.favoriteListRepository.GetLists(userId)
+	if err != nil {
+		return err
+	}
+	var list *model.FavoriteList
+	for _, l := range lists {
+		if l.Id == listId {
+			list = l
+		}
+	}
+	if list == nil {
+		return fi.favoriteListOutputPort.OutputListNotFound()
+	}
+	list.Apply(change)
+	if err := fi.favoriteListRepository.UpdateList(list, userId); err != nil {
+		return err
+	}
+	if change.Position != nil {
+		reordered, err := model.MoveFavoriteList(lists, listId, *change.Position)
+		if err != nil {
+			return err
+		}
+		if err := fi.favoriteListRepository.ReorderLists(userId, reordered); err != nil {
+			return err
+		}
+	}
+	return fi.favoriteListOutputPort.OutputUpdateListResult()
+}
+
+func (fi *FavoriteListInteractor) DeleteList(listId string, userId string) error {
+	lists, err := fi.favoriteListRepository.GetLists(userId)
+	if err != nil {
+		return err
+	}
+	remaining := make([]*model.FavoriteList, 0, len(lists))
+	found := false
+	for _, l := range lists {
+		if l.Id == listId {
+			found = true
+			continue
+		}
+		remaining = append(remaining, l)
+	}
+	if !found {
+		return fi.favoriteListOutputPort.OutputListNotFound()
+	}
+	if err := fi.favoriteListRepository.DeleteList(listId, userId); err != nil {
+		return err
+	}
+	// 削除したリストの後ろにあったリストを詰める
+	for i, l := range remaining {
+		l.Position = i
+	}
+	if err := fi.favoriteListRepository.ReorderLists(userId, remaining); err != nil {
+		return err
+	}
+	return fi.favoriteListOutputPort.OutputUpdateListResult()
+}
+
+func (fi *FavoriteListInteractor) AddStore(listId string, userId string, store *model.Store) error {
+	list, err := fi.favoriteListRepository.FindList(listId, userId)
+	if err != nil {
+		return err
+	}
+	if list == nil {
+		return fi.favoriteListOutputPort.OutputListNotFound()
+	}
+	if list.HasStore(store.Id) {
+		return fi.favoriteListOutputPort.OutputAlreadyExistListStore()
+	}
+	if err := fi.favoriteListRepository.AddStore(listId, store); err != nil {
+		return err
+	}
+	return fi.favoriteListOutputPort.OutputUpdateListResult()
+}
+
+func (fi *FavoriteListInteractor) RemoveStore(listId string, userId string, storeId string) error {
+	list, err := fi.favoriteListRepository.FindList(listId, userId)
+	if err != nil {
+		return err
+	}
+	if list == nil {
+		return fi.favoriteListOutputPort.OutputListNotFound()
+	}
+	removed, err := fi.favoriteListRepository.RemoveStore(listId, storeId)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fi.favoriteListOutputPort.OutputListStoreNotFound()
+	}
+	return fi.favoriteListOutputPort.OutputUpdateListResult()
+}
@@ -0,0 +1,230 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFavoriteListRepository struct {
+	mock.Mock
+}
+type MockFavoriteListOutputPort struct {
+	mock.Mock
+}
+
+func (m *MockFavoriteListRepository) GetLists(userId string) ([]*model.FavoriteList, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*model.FavoriteList), args.Error(1)
+}
+
+func (m *MockFavoriteListRepository) FindList(listId string, userId string) (*model.FavoriteList, error) {
+	args := m.Called(listId, userId)
+	return args.Get(0).(*model.FavoriteList), args.Error(1)
+}
+
+func (m *MockFavoriteListRepository) CreateList(list *model.FavoriteList, userId string) error {
+	args := m.Called(list, userId)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListRepository) UpdateList(list *model.FavoriteList, userId string) error {
+	args := m.Called(list, userId)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListRepository) ReorderLists(userId string, lists []*model.FavoriteList) error {
+	args := m.Called(userId, lists)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListRepository) DeleteList(listId string, userId string) error {
+	args := m.Called(listId, userId)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListRepository) AddStore(listId string, store *model.Store) error {
+	args := m.Called(listId, store)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListRepository) RemoveStore(listId string, storeId string) (bool, error) {
+	args := m.Called(listId, storeId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFavoriteListOutputPort) OutputLists(lists []*model.FavoriteList) error {
+	args := m.Called(lists)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListOutputPort) OutputList(list *model.FavoriteList) error {
+	args := m.Called(list)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListOutputPort) OutputUpdateListResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListOutputPort) OutputListNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListOutputPort) OutputAlreadyExistListStore() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockFavoriteListOutputPort) OutputListStoreNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func makeDummyFavoriteLists() []*model.FavoriteList {
+	return []*model.FavoriteList{
+		{Id: "list_1", Name: "Work lunch", Position: 0, Stores: []*model.Store{{Id: "Id001", Name: "UEC cafe"}}},
+		{Id: "list_2", Name: "Date night", Position: 1, Stores: []*model.Store{}},
+		{Id: "list_3", Name: "Want to try", Position: 2, Stores: []*model.Store{}},
+	}
+}
+
+func TestCreateList(t *testing.T) {
+	/* Arrange */
+	list := &model.FavoriteList{Name: "Work lunch"}
+	mockFavoriteListRepository := new(MockFavoriteListRepository)
+	mockFavoriteListRepository.On("CreateList", list, "user_1").Return(nil)
+	mockFavoriteListOutputPort := new(MockFavoriteListOutputPort)
+	mockFavoriteListOutputPort.On("OutputList", list).Return(nil)
+
+	fi := &FavoriteListInteractor{favoriteListRepository: mockFavoriteListRepository, favoriteListOutputPort: mockFavoriteListOutputPort}
+
+	/* Act */
+	actual := fi.CreateList(list, "user_1")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFavoriteListRepository.AssertCalled(t, "CreateList", list, "user_1")
+	mockFavoriteListOutputPort.AssertCalled(t, "OutputList", list)
+}
+
+func TestGetListNotFound(t *testing.T) {
+	/* Arrange */
+	mockFavoriteListRepository := new(MockFavoriteListRepository)
+	mockFavoriteListRepository.On("FindList", "list_9", "user_1").Return((*model.FavoriteList)(nil), nil)
+	mockFavoriteListOutputPort := new(MockFavoriteListOutputPort)
+	mockFavoriteListOutputPort.On("OutputListNotFound").Return(nil)
+
+	fi := &FavoriteListInteractor{favoriteListRepository: mockFavoriteListRepository, favoriteListOutputPort: mockFavoriteListOutputPort}
+
+	/* Act */
+	actual := fi.GetList("list_9", "user_1")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFavoriteListOutputPort.AssertNumberOfCalls(t, "OutputListNotFound", 1)
+}
+
+func TestUpdateListWithPosition(t *testing.T) {
+	/* Arrange */
+	lists := makeDummyFavoriteLists()
+	name := "Lunch"
+	position := 0
+	change := &model.FavoriteListChange{Name: &name, Position: &position}
+
+	mockFavoriteListRepository := new(MockFavoriteListRepository)
+	mockFavoriteListRepository.On("GetLists", "user_1").Return(lists, nil)
+	mockFavoriteListRepository.On("UpdateList", mock.Anything, "user_1").Return(nil)
+	mockFavoriteListRepository.On("ReorderLists", "user_1", mock.Anything).Return(nil)
+	mockFavoriteListOutputPort := new(MockFavoriteListOutputPort)
+	mockFavoriteListOutputPort.On("OutputUpdateListResult").Return(nil)
+
+	fi := &FavoriteListInteractor{favoriteListRepository: mockFavoriteListRepository, favoriteListOutputPort: mockFavoriteListOutputPort}
+
+	/* Act */
+	actual := fi.UpdateList("list_3", "user_1", change)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// 名前が変更されること
+	mockFavoriteListRepository.AssertCalled(t, "UpdateList", mock.MatchedBy(func(l *model.FavoriteList) bool {
+		return l.Id == "list_3" && l.Name == "Lunch"
+	}), "user_1")
+	// 指定した位置に移動し、他のリストが後ろにずれること
+	mockFavoriteListRepository.AssertCalled(t, "ReorderLists", "user_1", mock.MatchedBy(func(reordered []*model.FavoriteList) bool {
+		ids := make([]string, 0)
+		for i, l := range reordered {
+			if l.Position != i {
+				return false
+			}
+			ids = append(ids, l.Id)
+		}
+		return assert.ObjectsAreEqual([]string{"list_3", "list_1", "list_2"}, ids)
+	}))
+	mockFavoriteListOutputPort.AssertNumberOfCalls(t, "OutputUpdateListResult", 1)
+}
+
+func TestDeleteList(t *testing.T) {
+	/* Arrange */
+	mockFavoriteListRepository := new(MockFavoriteListRepository)
+	mockFavoriteListRepository.On("GetLists", "user_1").Return(makeDummyFavoriteLists(), nil)
+	mockFavoriteListRepository.On("DeleteList", "list_1", "user_1").Return(nil)
+	mockFavoriteListRepository.On("ReorderLists", "user_1", mock.Anything).Return(nil)
+	mockFavoriteListOutputPort := new(MockFavoriteListOutputPort)
+	mockFavoriteListOutputPort.On("OutputUpdateListResult").Return(nil)
+
+	fi := &FavoriteListInteractor{favoriteListRepository: mockFavoriteListRepository, favoriteListOutputPort: mockFavoriteListOutputPort}
+
+	/* Act */
+	actual := fi.DeleteList("list_1", "user_1")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFavoriteListRepository.AssertCalled(t, "DeleteList", "list_1", "user_1")
+	// 残りのリストが先頭から詰められること
+	mockFavoriteListRepository.AssertCalled(t, "ReorderLists", "user_1", mock.MatchedBy(func(remaining []*model.FavoriteList) bool {
+		return len(remaining) == 2 && remaining[0].Id == "list_2" && remaining[0].Position == 0 && remaining[1].Position == 1
+	}))
+}
+
+func TestAddStoreAlreadyExist(t *testing.T) {
+	/* Arrange */
+	store := &model.Store{Id: "Id001", Name: "UEC cafe"}
+	mockFavoriteListRepository := new(MockFavoriteListRepository)
+	mockFavoriteListRepository.On("FindList", "list_1", "user_1").Return(makeDummyFavoriteLists()[0], nil)
+	mockFavoriteListOutputPort := new(MockFavoriteListOutputPort)
+	mockFavoriteListOutputPort.On("OutputAlreadyExistListStore").Return(nil)
+
+	fi := &FavoriteListInteractor{favoriteListRepository: mockFavoriteListRepository, favoriteListOutputPort: mockFavoriteListOutputPort}
+
+	/* Act */
+	actual := fi.AddStore("list_1", "user_1", store)
+
+	/* Assert */
+	// 既にリストにある店舗は追加しないこと
+	assert.NoError(t, actual)
+	mockFavoriteListRepository.AssertNotCalled(t, "AddStore", mock.Anything, mock.Anything)
+	mockFavoriteListOutputPort.AssertNumberOfCalls(t, "OutputAlreadyExistListStore", 1)
+}
+
+func TestRemoveStoreNotInList(t *testing.T) {
+	/* Arrange */
+	mockFavoriteListRepository := new(MockFavoriteListRepository)
+	mockFavoriteListRepository.On("FindList", "list_2", "user_1").Return(makeDummyFavoriteLists()[1], nil)
+	mockFavoriteListRepository.On("RemoveStore", "list_2", "Id001").Return(false, nil)
+	mockFavoriteListOutputPort := new(MockFavoriteListOutputPort)
+	mockFavoriteListOutputPort.On("OutputListStoreNotFound").Return(nil)
+
+	fi := &FavoriteListInteractor{favoriteListRepository: mockFavoriteListRepository, favoriteListOutputPort: mockFavoriteListOutputPort}
+
+	/* Act */
+	actual := fi.RemoveStore("list_2", "user_1", "Id001")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFavoriteListOutputPort.AssertNumberOfCalls(t, "OutputListStoreNotFound", 1)
+}
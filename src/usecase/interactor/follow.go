@@ -0,0 +1,75 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	port "clean-storemap-api/src/usecase/port"
+)
+
+type FollowInteractor struct {
+	followRepository port.FollowRepository
+	followOutputPort port.FollowOutputPort
+}
+
+func NewFollowInputPort(followRepository port.FollowRepository, followOutputPort port.FollowOutputPort) port.FollowInputPort {
+	return &FollowInteractor{
+		followRepository: followRepository,
+		followOutputPort: followOutputPort,
+	}
+}
+
+func (fi *FollowInteractor) Follow(userId string, followeeId string) error {
+	exist, err := fi.followRepository.ExistUser(followeeId)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return fi.followOutputPort.OutputUserNotFound()
+	}
+	following, err := fi.followRepository.ExistFollow(userId, followeeId)
+	if err != nil {
+		return err
+	}
+	if following {
+		return fi.followOutputPort.OutputAlreadyFollowing()
+	}
+	if err := fi.followRepository.Follow(userId, followeeId); err != nil {
+		return err
+	}
+	return fi.followOutputPort.OutputFollowResult()
+}
+
+func (fi *FollowInteractor) Unfollow(userId string, followeeId string) error {
+	deleted, err := fi.followRepository.Unfollow(userId, followeeId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fi.followOutputPort.OutputFollowNotFound()
+	}
+	return fi.followOutputPort.OutputUnfollowResult()
+}
+
+func (fi *FollowInteractor) GetFollowing(userId string) error {
+	users, err := fi.followRepository.GetFollowing(userId)
+	if err != nil {
+		return err
+	}
+	return fi.followOutputPort.OutputUsers(users)
+}
+
+func (fi *FollowInteractor) GetFollowers(userId string) error {
+	users, err := fi.followRepository.GetFollowers(userId)
+	if err != nil {
+		return err
+	}
+	return fi.followOutputPort.OutputUsers(users)
+}
+
+// フォローしているユーザーのうち、フォロワーにお気に入りを公開しているユーザーの登録のみを返す
+func (fi *FollowInteractor) GetFeed(userId string, page *model.FeedPageRequest) error {
+	items, next, err := fi.followRepository.GetFeed(userId, page)
+	if err != nil {
+		return err
+	}
+	return fi.followOutputPort.OutputFeed(items, next)
+}
@@ -0,0 +1,183 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFollowRepository struct {
+	mock.Mock
+}
+type MockFollowOutputPort struct {
+	mock.Mock
+}
+
+func (m *MockFollowRepository) ExistUser(userId string) (bool, error) {
+	args := m.Called(userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowRepository) ExistFollow(userId string, followeeId string) (bool, error) {
+	args := m.Called(userId, followeeId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowRepository) Follow(userId string, followeeId string) error {
+	args := m.Called(userId, followeeId)
+	return args.Error(0)
+}
+
+func (m *MockFollowRepository) Unfollow(userId string, followeeId string) (bool, error) {
+	args := m.Called(userId, followeeId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowing(userId string) ([]*model.User, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*model.User), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowers(userId string) ([]*model.User, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*model.User), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFeed(userId string, page *model.FeedPageRequest) ([]*model.FeedItem, *model.FeedCursor, error) {
+	args := m.Called(userId, page)
+	return args.Get(0).([]*model.FeedItem), args.Get(1).(*model.FeedCursor), args.Error(2)
+}
+
+func (m *MockFollowOutputPort) OutputFollowResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockFollowOutputPort) OutputAlreadyFollowing() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockFollowOutputPort) OutputUnfollowResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockFollowOutputPort) OutputFollowNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockFollowOutputPort) OutputUserNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockFollowOutputPort) OutputUsers(users []*model.User) error {
+	args := m.Called(users)
+	return args.Error(0)
+}
+
+func (m *MockFollowOutputPort) OutputFeed(items []*model.FeedItem, next *model.FeedCursor) error {
+	args := m.Called(items, next)
+	return args.Error(0)
+}
+
+func TestFollow(t *testing.T) {
+	/* Arrange */
+	mockFollowRepository := new(MockFollowRepository)
+	mockFollowRepository.On("ExistUser", "user_2").Return(true, nil)
+	mockFollowRepository.On("ExistFollow", "user_1", "user_2").Return(false, nil)
+	mockFollowRepository.On("Follow", "user_1", "user_2").Return(nil)
+	mockFollowOutputPort := new(MockFollowOutputPort)
+	mockFollowOutputPort.On("OutputFollowResult").Return(nil)
+
+	fi := &FollowInteractor{followRepository: mockFollowRepository, followOutputPort: mockFollowOutputPort}
+
+	/* Act */
+	actual := fi.Follow("user_1", "user_2")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFollowRepository.AssertCalled(t, "Follow", "user_1", "user_2")
+	mockFollowOutputPort.AssertCalled(t, "OutputFollowResult")
+}
+
+func TestFollowNotExistUser(t *testing.T) {
+	/* Arrange */
+	mockFollowRepository := new(MockFollowRepository)
+	mockFollowRepository.On("ExistUser", "user_2").Return(false, nil)
+	mockFollowOutputPort := new(MockFollowOutputPort)
+	mockFollowOutputPort.On("OutputUserNotFound").Return(nil)
+
+	fi := &FollowInteractor{followRepository: mockFollowRepository, followOutputPort: mockFollowOutputPort}
+
+	/* Act */
+	actual := fi.Follow("user_1", "user_2")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFollowOutputPort.AssertCalled(t, "OutputUserNotFound")
+	mockFollowRepository.AssertNotCalled(t, "Follow", mock.Anything, mock.Anything)
+}
+
+func TestFollowAlreadyFollowing(t *testing.T) {
+	/* Arrange */
+	mockFollowRepository := new(MockFollowRepository)
+	mockFollowRepository.On("ExistUser", "user_2").Return(true, nil)
+	mockFollowRepository.On("ExistFollow", "user_1", "user_2").Return(true, nil)
+	mockFollowOutputPort := new(MockFollowOutputPort)
+	mockFollowOutputPort.On("OutputAlreadyFollowing").Return(nil)
+
+	fi := &FollowInteractor{followRepository: mockFollowRepository, followOutputPort: mockFollowOutputPort}
+
+	/* Act */
+	actual := fi.Follow("user_1", "user_2")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFollowOutputPort.AssertCalled(t, "OutputAlreadyFollowing")
+	mockFollowRepository.AssertNotCalled(t, "Follow", mock.Anything, mock.Anything)
+}
+
+func TestUnfollowNotFollowing(t *testing.T) {
+	/* Arrange */
+	mockFollowRepository := new(MockFollowRepository)
+	mockFollowRepository.On("Unfollow", "user_1", "user_2").Return(false, nil)
+	mockFollowOutputPort := new(MockFollowOutputPort)
+	mockFollowOutputPort.On("OutputFollowNotFound").Return(nil)
+
+	fi := &FollowInteractor{followRepository: mockFollowRepository, followOutputPort: mockFollowOutputPort}
+
+	/* Act */
+	actual := fi.Unfollow("user_1", "user_2")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFollowOutputPort.AssertCalled(t, "OutputFollowNotFound")
+	mockFollowOutputPort.AssertNotCalled(t, "OutputUnfollowResult")
+}
+
+func TestGetFeed(t *testing.T) {
+	/* Arrange */
+	items := []*model.FeedItem{
+		{User: model.User{Id: "user_2", Name: "Bob"}, Store: &model.Store{Id: "Id001"}},
+	}
+	next := &model.FeedCursor{UserId: "user_2", StoreId: "Id001"}
+	page := &model.FeedPageRequest{Limit: 1}
+	mockFollowRepository := new(MockFollowRepository)
+	mockFollowRepository.On("GetFeed", "user_1", page).Return(items, next, nil)
+	mockFollowOutputPort := new(MockFollowOutputPort)
+	mockFollowOutputPort.On("OutputFeed", items, next).Return(nil)
+
+	fi := &FollowInteractor{followRepository: mockFollowRepository, followOutputPort: mockFollowOutputPort}
+
+	/* Act */
+	actual := fi.GetFeed("user_1", page)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockFollowOutputPort.AssertCalled(t, "OutputFeed", items, next)
+}
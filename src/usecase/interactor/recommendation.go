@@ -0,0 +1,81 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	port "clean-storemap-api/src/usecase/port"
+)
+
+type RecommendationInteractor struct {
+	recommendationRepository port.RecommendationRepository
+	recommendationOutputPort port.RecommendationOutputPort
+}
+
+func NewRecommendationInputPort(recommendationRepository port.RecommendationRepository, recommendationOutputPort port.RecommendationOutputPort) port.RecommendationInputPort {
+	return &RecommendationInteractor{
+		recommendationRepository: recommendationRepository,
+		recommendationOutputPort: recommendationOutputPort,
+	}
+}
+
+// お気に入りの似たユーザーが登録している店舗をすすめ、足りない分は期間を限らない人気の店舗で補う。
+// お気に入りがまだないユーザーには人気の店舗のみを返す
+func (ri *RecommendationInteractor) GetRecommendations(userId string, options *model.RecommendationOptions) error {
+	favorites, err := ri.recommendationRepository.GetSimilarUserFavorites(userId)
+	if err != nil {
+		return err
+	}
+	target := &model.UserFavorites{User: model.User{Id: userId}}
+	others := make([]*model.UserFavorites, 0)
+	for _, f := range favorites {
+		if f.User.Id == userId {
+			target = f
+		} else {
+			others = append(others, f)
+		}
+	}
+
+	recommendations, err := ri.setStores(model.RecommendFromSimilarUsers(target, others, options))
+	if err != nil {
+		return err
+	}
+
+	if len(recommendations) < options.Limit {
+		excludeIds := append([]string{}, target.StoreIds...)
+		for _, r := range recommendations {
+			excludeIds = append(excludeIds, r.StoreId)
+		}
+		popular, err := ri.recommendationRepository.GetTopFavoriteStores(&model.Ranking{Limit: options.Limit + len(excludeIds)})
+		if err != nil {
+			return err
+		}
+		recommendations = append(recommendations, model.RecommendPopular(popular, excludeIds, options.Limit-len(recommendations))...)
+	}
+	return ri.recommendationOutputPort.OutputRecommendations(recommendations)
+}
+
+// 店舗の情報を設定する。情報を取得できなかった店舗は除く
+func (ri *RecommendationInteractor) setStores(recommendations []*model.Recommendation) ([]*model.Recommendation, error) {
+	if len(recommendations) == 0 {
+		return recommendations, nil
+	}
+	ids := make([]string, 0, len(recommendations))
+	for _, r := range recommendations {
+		ids = append(ids, r.StoreId)
+	}
+	stores, err := ri.recommendationRepository.GetStores(ids)
+	if err != nil {
+		return nil, err
+	}
+	storesById := make(map[string]*model.Store)
+	for _, s := range stores {
+		storesById[s.Id] = s
+	}
+	found := make([]*model.Recommendation, 0, len(recommendations))
+	for _, r := range recommendations {
+		if s, ok := storesById[r.StoreId]; ok {
+			r.Store = s
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
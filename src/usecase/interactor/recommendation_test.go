@@ -0,0 +1,163 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecommendationRepository struct {
+	mock.Mock
+}
+type MockRecommendationOutputPort struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationRepository) GetSimilarUserFavorites(userId string) ([]*model.UserFavorites, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*model.UserFavorites), args.Error(1)
+}
+
+func (m *MockRecommendationRepository) GetStores(storeIds []string) ([]*model.Store, error) {
+	args := m.Called(storeIds)
+	return args.Get(0).([]*model.Store), args.Error(1)
+}
+
+func (m *MockRecommendationRepository) GetTopFavoriteStores(ranking *model.Ranking) ([]*model.Store, error) {
+	args := m.Called(ranking)
+	return args.Get(0).([]*model.Store), args.Error(1)
+}
+
+func (m *MockRecommendationOutputPort) OutputRecommendations(recommendations []*model.Recommendation) error {
+	args := m.Called(recommendations)
+	return args.Error(0)
+}
+
+// 出力されたおすすめの店舗のidと理由を返す
+func recommendedIds(recommendations []*model.Recommendation) []string {
+	ids := make([]string, 0)
+	for _, r := range recommendations {
+		ids = append(ids, r.StoreId+":"+string(r.Reason))
+	}
+	return ids
+}
+
+func TestGetRecommendations(t *testing.T) {
+	/* Arrange */
+	favorites := []*model.UserFavorites{
+		{User: model.User{Id: "user_1"}, StoreIds: []string{"Id001", "Id002"}},
+		{User: model.User{Id: "user_2"}, StoreIds: []string{"Id001", "Id002", "Id003"}},
+		{User: model.User{Id: "user_3"}, StoreIds: []string{"Id001", "Id004"}},
+	}
+	mockRecommendationRepository := new(MockRecommendationRepository)
+	mockRecommendationRepository.On("GetSimilarUserFavorites", "user_1").Return(favorites, nil)
+	mockRecommendationRepository.On("GetStores", []string{"Id003", "Id004"}).Return([]*model.Store{
+		{Id: "Id003", Name: "UEC sushi"},
+		{Id: "Id004", Name: "UEC bar"},
+	}, nil)
+	mockRecommendationOutputPort := new(MockRecommendationOutputPort)
+	mockRecommendationOutputPort.On("OutputRecommendations", mock.Anything).Return(nil)
+
+	ri := &RecommendationInteractor{recommendationRepository: mockRecommendationRepository, recommendationOutputPort: mockRecommendationOutputPort}
+
+	/* Act */
+	actual := ri.GetRecommendations("user_1", &model.RecommendationOptions{Limit: 2})
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// 自分とお気に入りがより似ているuser_2の店舗が先になり、登録済みの店舗は含まないこと
+	mockRecommendationOutputPort.AssertCalled(t, "OutputRecommendations", mock.MatchedBy(func(recommendations []*model.Recommendation) bool {
+		return assert.ObjectsAreEqual([]string{"Id003:similar_users", "Id004:similar_users"}, recommendedIds(recommendations)) &&
+			recommendations[0].Score > recommendations[1].Score &&
+			recommendations[0].Store.Name == "UEC sushi"
+	}))
+	// 件数が足りている場合は人気の店舗を取得しないこと
+	mockRecommendationRepository.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything)
+}
+
+func TestGetRecommendationsWithDemographic(t *testing.T) {
+	/* Arrange */
+	// お気に入りの重なりは同じだが、user_3の方が年代と性別が近い
+	favorites := []*model.UserFavorites{
+		{User: model.User{Id: "user_1", Age: 20, Sex: -1, Gender: -1}, StoreIds: []string{"Id001"}},
+		{User: model.User{Id: "user_2", Age: 60, Sex: 1, Gender: 1}, StoreIds: []string{"Id001", "Id002"}},
+		{User: model.User{Id: "user_3", Age: 20, Sex: -1, Gender: -0.5}, StoreIds: []string{"Id001", "Id003"}},
+	}
+	mockRecommendationRepository := new(MockRecommendationRepository)
+	mockRecommendationRepository.On("GetSimilarUserFavorites", "user_1").Return(favorites, nil)
+	mockRecommendationRepository.On("GetStores", mock.Anything).Return([]*model.Store{{Id: "Id002"}, {Id: "Id003"}}, nil)
+	mockRecommendationOutputPort := new(MockRecommendationOutputPort)
+	mockRecommendationOutputPort.On("OutputRecommendations", mock.Anything).Return(nil)
+
+	ri := &RecommendationInteractor{recommendationRepository: mockRecommendationRepository, recommendationOutputPort: mockRecommendationOutputPort}
+
+	/* Act */
+	actual := ri.GetRecommendations("user_1", &model.RecommendationOptions{Limit: 2, Demographic: true})
+
+	/* Assert */
+	// 属性の近いユーザーの店舗が先になること
+	assert.NoError(t, actual)
+	mockRecommendationRepository.AssertCalled(t, "GetStores", []string{"Id003", "Id002"})
+}
+
+func TestGetRecommendationsColdStart(t *testing.T) {
+	/* Arrange */
+	first := 8
+	second := 2
+	popular := []*model.Store{
+		{Id: "Id001", Name: "UEC cafe", FavoriteCount: &first},
+		{Id: "Id002", Name: "UEC restaurant", FavoriteCount: &second},
+	}
+	mockRecommendationRepository := new(MockRecommendationRepository)
+	mockRecommendationRepository.On("GetSimilarUserFavorites", "user_1").Return([]*model.UserFavorites{}, nil)
+	mockRecommendationRepository.On("GetTopFavoriteStores", &model.Ranking{Limit: 10}).Return(popular, nil)
+	mockRecommendationOutputPort := new(MockRecommendationOutputPort)
+	mockRecommendationOutputPort.On("OutputRecommendations", mock.Anything).Return(nil)
+
+	ri := &RecommendationInteractor{recommendationRepository: mockRecommendationRepository, recommendationOutputPort: mockRecommendationOutputPort}
+
+	/* Act */
+	actual := ri.GetRecommendations("user_1", &model.RecommendationOptions{Limit: 10})
+
+	/* Assert */
+	// お気に入りがないユーザーには期間を限らない人気の店舗を、最も人気の店舗を1としたスコアで返すこと
+	assert.NoError(t, actual)
+	mockRecommendationRepository.AssertNotCalled(t, "GetStores", mock.Anything)
+	mockRecommendationOutputPort.AssertCalled(t, "OutputRecommendations", []*model.Recommendation{
+		{StoreId: "Id001", Store: popular[0], Score: 1, Reason: model.ReasonPopular},
+		{StoreId: "Id002", Store: popular[1], Score: 0.25, Reason: model.ReasonPopular},
+	})
+}
+
+func TestGetRecommendationsFillWithPopular(t *testing.T) {
+	/* Arrange */
+	favorites := []*model.UserFavorites{
+		{User: model.User{Id: "user_1"}, StoreIds: []string{"Id001"}},
+		{User: model.User{Id: "user_2"}, StoreIds: []string{"Id001", "Id002"}},
+	}
+	count := 5
+	mockRecommendationRepository := new(MockRecommendationRepository)
+	mockRecommendationRepository.On("GetSimilarUserFavorites", "user_1").Return(favorites, nil)
+	mockRecommendationRepository.On("GetStores", []string{"Id002"}).Return([]*model.Store{{Id: "Id002"}}, nil)
+	mockRecommendationRepository.On("GetTopFavoriteStores", mock.Anything).Return([]*model.Store{
+		{Id: "Id001", FavoriteCount: &count},
+		{Id: "Id002", FavoriteCount: &count},
+		{Id: "Id003", FavoriteCount: &count},
+	}, nil)
+	mockRecommendationOutputPort := new(MockRecommendationOutputPort)
+	mockRecommendationOutputPort.On("OutputRecommendations", mock.Anything).Return(nil)
+
+	ri := &RecommendationInteractor{recommendationRepository: mockRecommendationRepository, recommendationOutputPort: mockRecommendationOutputPort}
+
+	/* Act */
+	actual := ri.GetRecommendations("user_1", &model.RecommendationOptions{Limit: 3})
+
+	/* Assert */
+	// 足りない分を、登録済みとすでにすすめた店舗を除いた人気の店舗で補うこと
+	assert.NoError(t, actual)
+	mockRecommendationOutputPort.AssertCalled(t, "OutputRecommendations", mock.MatchedBy(func(recommendations []*model.Recommendation) bool {
+		return assert.ObjectsAreEqual([]string{"Id002:similar_users", "Id003:popular"}, recommendedIds(recommendations))
+	}))
+}
@@ -0,0 +1,82 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	port "clean-storemap-api/src/usecase/port"
+)
+
+type ReviewInteractor struct {
+	reviewRepository port.ReviewRepository
+	reviewOutputPort port.ReviewOutputPort
+}
+
+func NewReviewInputPort(reviewRepository port.ReviewRepository, reviewOutputPort port.ReviewOutputPort) port.ReviewInputPort {
+	return &ReviewInteractor{
+		reviewRepository: reviewRepository,
+		reviewOutputPort: reviewOutputPort,
+	}
+}
+
+// 店舗のレビューを平均と件数と合わせて返す
+func (ri *ReviewInteractor) GetStoreReviews(storeId string, page *model.PageRequest) error {
+	reviews, next, err := ri.reviewRepository.GetStoreReviews(storeId, page)
+	if err != nil {
+		return err
+	}
+	summary, err := ri.reviewRepository.GetReviewSummary(storeId)
+	if err != nil {
+		return err
+	}
+	return ri.reviewOutputPort.OutputReviews(reviews, summary, next)
+}
+
+func (ri *ReviewInteractor) GetUserReviews(userId string, page *model.PageRequest) error {
+	reviews, next, err := ri.reviewRepository.GetUserReviews(userId, page)
+	if err != nil {
+		return err
+	}
+	return ri.reviewOutputPort.OutputReviews(reviews, nil, next)
+}
+
+func (ri *ReviewInteractor) CreateReview(review *model.Review) error {
+	exist, err := ri.reviewRepository.ExistReview(review.StoreId, review.UserId)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return ri.reviewOutputPort.OutputAlreadyExistReview()
+	}
+	found, err := ri.reviewRepository.SaveStore(review.StoreId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ri.reviewOutputPort.OutputStoreNotFound()
+	}
+	if err := ri.reviewRepository.CreateReview(review); err != nil {
+		return err
+	}
+	return ri.reviewOutputPort.OutputSaveReviewResult()
+}
+
+func (ri *ReviewInteractor) UpdateReview(review *model.Review) error {
+	updated, err := ri.reviewRepository.UpdateReview(review)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ri.reviewOutputPort.OutputReviewNotFound()
+	}
+	return ri.reviewOutputPort.OutputSaveReviewResult()
+}
+
+func (ri *ReviewInteractor) DeleteReview(storeId string, userId string) error {
+	deleted, err := ri.reviewRepository.DeleteReview(storeId, userId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ri.reviewOutputPort.OutputReviewNotFound()
+	}
+	return ri.reviewOutputPort.OutputSaveReviewResult()
+}
@@ -0,0 +1,182 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewRepository struct {
+	mock.Mock
+}
+type MockReviewOutputPort struct {
+	mock.Mock
+}
+
+func (m *MockReviewRepository) GetStoreReviews(storeId string, page *model.PageRequest) ([]*model.Review, *model.Cursor, error) {
+	args := m.Called(storeId, page)
+	return args.Get(0).([]*model.Review), args.Get(1).(*model.Cursor), args.Error(2)
+}
+
+func (m *MockReviewRepository) GetUserReviews(userId string, page *model.PageRequest) ([]*model.Review, *model.Cursor, error) {
+	args := m.Called(userId, page)
+	return args.Get(0).([]*model.Review), args.Get(1).(*model.Cursor), args.Error(2)
+}
+
+func (m *MockReviewRepository) GetReviewSummary(storeId string) (*model.ReviewSummary, error) {
+	args := m.Called(storeId)
+	return args.Get(0).(*model.ReviewSummary), args.Error(1)
+}
+
+func (m *MockReviewRepository) ExistReview(storeId string, userId string) (bool, error) {
+	args := m.Called(storeId, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReviewRepository) SaveStore(storeId string) (bool, error) {
+	args := m.Called(storeId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReviewRepository) CreateReview(review *model.Review) error {
+	args := m.Called(review)
+	return args.Error(0)
+}
+
+func (m *MockReviewRepository) UpdateReview(review *model.Review) (bool, error) {
+	args := m.Called(review)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReviewRepository) DeleteReview(storeId string, userId string) (bool, error) {
+	args := m.Called(storeId, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReviewOutputPort) OutputReviews(reviews []*model.Review, summary *model.ReviewSummary, next *model.Cursor) error {
+	args := m.Called(reviews, summary, next)
+	return args.Error(0)
+}
+
+func (m *MockReviewOutputPort) OutputSaveReviewResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockReviewOutputPort) OutputAlreadyExistReview() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockReviewOutputPort) OutputReviewNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockReviewOutputPort) OutputStoreNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func TestCreateReview(t *testing.T) {
+	/* Arrange */
+	review := &model.Review{StoreId: "Id001", UserId: "user_1", Rating: 4, Text: "おいしい"}
+	mockReviewRepository := new(MockReviewRepository)
+	mockReviewRepository.On("ExistReview", "Id001", "user_1").Return(false, nil)
+	mockReviewRepository.On("SaveStore", "Id001").Return(true, nil)
+	mockReviewRepository.On("CreateReview", review).Return(nil)
+	mockReviewOutputPort := new(MockReviewOutputPort)
+	mockReviewOutputPort.On("OutputSaveReviewResult").Return(nil)
+
+	ri := &ReviewInteractor{reviewRepository: mockReviewRepository, reviewOutputPort: mockReviewOutputPort}
+
+	/* Act */
+	actual := ri.CreateReview(review)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockReviewRepository.AssertCalled(t, "CreateReview", review)
+	mockReviewOutputPort.AssertNumberOfCalls(t, "OutputSaveReviewResult", 1)
+}
+
+func TestCreateReviewAlreadyExist(t *testing.T) {
+	/* Arrange */
+	review := &model.Review{StoreId: "Id001", UserId: "user_1", Rating: 4}
+	mockReviewRepository := new(MockReviewRepository)
+	mockReviewRepository.On("ExistReview", "Id001", "user_1").Return(true, nil)
+	mockReviewOutputPort := new(MockReviewOutputPort)
+	mockReviewOutputPort.On("OutputAlreadyExistReview").Return(nil)
+
+	ri := &ReviewInteractor{reviewRepository: mockReviewRepository, reviewOutputPort: mockReviewOutputPort}
+
+	/* Act */
+	actual := ri.CreateReview(review)
+
+	/* Assert */
+	// 同じ店舗に2件目のレビューは書けないこと
+	assert.NoError(t, actual)
+	mockReviewRepository.AssertNotCalled(t, "CreateReview", review)
+	mockReviewOutputPort.AssertNumberOfCalls(t, "OutputAlreadyExistReview", 1)
+}
+
+func TestCreateReviewStoreNotFound(t *testing.T) {
+	/* Arrange */
+	review := &model.Review{StoreId: "Id999", UserId: "user_1", Rating: 4}
+	mockReviewRepository := new(MockReviewRepository)
+	mockReviewRepository.On("ExistReview", "Id999", "user_1").Return(false, nil)
+	mockReviewRepository.On("SaveStore", "Id999").Return(false, nil)
+	mockReviewOutputPort := new(MockReviewOutputPort)
+	mockReviewOutputPort.On("OutputStoreNotFound").Return(nil)
+
+	ri := &ReviewInteractor{reviewRepository: mockReviewRepository, reviewOutputPort: mockReviewOutputPort}
+
+	/* Act */
+	actual := ri.CreateReview(review)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockReviewRepository.AssertNotCalled(t, "CreateReview", review)
+	mockReviewOutputPort.AssertNumberOfCalls(t, "OutputStoreNotFound", 1)
+}
+
+func TestGetStoreReviews(t *testing.T) {
+	/* Arrange */
+	reviews := []*model.Review{{StoreId: "Id001", UserId: "user_1", UserName: "太郎", Rating: 5}}
+	summary := &model.ReviewSummary{Average: 4.5, Count: 12}
+	next := &model.Cursor{Offset: 10}
+	page := &model.PageRequest{Limit: 10}
+	mockReviewRepository := new(MockReviewRepository)
+	mockReviewRepository.On("GetStoreReviews", "Id001", page).Return(reviews, next, nil)
+	mockReviewRepository.On("GetReviewSummary", "Id001").Return(summary, nil)
+	mockReviewOutputPort := new(MockReviewOutputPort)
+	mockReviewOutputPort.On("OutputReviews", reviews, summary, next).Return(nil)
+
+	ri := &ReviewInteractor{reviewRepository: mockReviewRepository, reviewOutputPort: mockReviewOutputPort}
+
+	/* Act */
+	actual := ri.GetStoreReviews("Id001", page)
+
+	/* Assert */
+	// レビューの一覧が全件の集計と合わせて出力されること
+	assert.NoError(t, actual)
+	mockReviewOutputPort.AssertCalled(t, "OutputReviews", reviews, summary, next)
+}
+
+func TestDeleteReviewNotFound(t *testing.T) {
+	/* Arrange */
+	mockReviewRepository := new(MockReviewRepository)
+	mockReviewRepository.On("DeleteReview", "Id001", "user_1").Return(false, nil)
+	mockReviewOutputPort := new(MockReviewOutputPort)
+	mockReviewOutputPort.On("OutputReviewNotFound").Return(nil)
+
+	ri := &ReviewInteractor{reviewRepository: mockReviewRepository, reviewOutputPort: mockReviewOutputPort}
+
+	/* Act */
+	actual := ri.DeleteReview("Id001", "user_1")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockReviewOutputPort.AssertNumberOfCalls(t, "OutputReviewNotFound", 1)
+}
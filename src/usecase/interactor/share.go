@@ -0,0 +1,64 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	port "clean-storemap-api/src/usecase/port"
+)
+
+type ShareInteractor struct {
+	shareRepository port.ShareRepository
+	shareOutputPort port.ShareOutputPort
+}
+
+func NewShareInputPort(shareRepository port.ShareRepository, shareOutputPort port.ShareOutputPort) port.ShareInputPort {
+	return &ShareInteractor{
+		shareRepository: shareRepository,
+		shareOutputPort: shareOutputPort,
+	}
+}
+
+// トークンを返すのは作成時の一度のみ
+func (si *ShareInteractor) CreateShareLink(userId string) error {
+	link, err := model.NewShareLink()
+	if err != nil {
+		return err
+	}
+	if err := si.shareRepository.CreateShareLink(link, userId); err != nil {
+		return err
+	}
+	return si.shareOutputPort.OutputShareLink(link)
+}
+
+func (si *ShareInteractor) GetShareLinks(userId string) error {
+	links, err := si.shareRepository.GetShareLinks(userId)
+	if err != nil {
+		return err
+	}
+	return si.shareOutputPort.OutputShareLinks(links)
+}
+
+func (si *ShareInteractor) RevokeShareLink(id string, userId string) error {
+	deleted, err := si.shareRepository.DeleteShareLink(id, userId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return si.shareOutputPort.OutputShareLinkNotFound()
+	}
+	return si.shareOutputPort.OutputRevokeShareLinkResult()
+}
+
+func (si *ShareInteractor) GetSharedFavoriteStores(token string) error {
+	userId, err := si.shareRepository.FindSharedUserId(token)
+	if err != nil {
+		return err
+	}
+	if userId == "" {
+		return si.shareOutputPort.OutputShareLinkNotFound()
+	}
+	stores, err := si.shareRepository.GetFavoriteStores(userId)
+	if err != nil {
+		return err
+	}
+	return si.shareOutputPort.OutputSharedStores(stores)
+}
@@ -0,0 +1,179 @@
+package interactor
+
+import (
+	model "clean-storemap-api/src/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockShareRepository struct {
+	mock.Mock
+}
+type MockShareOutputPort struct {
+	mock.Mock
+}
+
+func (m *MockShareRepository) CreateShareLink(link *model.ShareLink, userId string) error {
+	args := m.Called(link, userId)
+	return args.Error(0)
+}
+
+func (m *MockShareRepository) GetShareLinks(userId string) ([]*model.ShareLink, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*model.ShareLink), args.Error(1)
+}
+
+func (m *MockShareRepository) DeleteShareLink(id string, userId string) (bool, error) {
+	args := m.Called(id, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockShareRepository) FindSharedUserId(token string) (string, error) {
+	args := m.Called(token)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockShareRepository) GetFavoriteStores(userId string) ([]*model.Store, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*model.Store), args.Error(1)
+}
+
+func (m *MockShareOutputPort) OutputShareLink(link *model.ShareLink) error {
+	args := m.Called(link)
+	return args.Error(0)
+}
+
+func (m *MockShareOutputPort) OutputShareLinks(links []*model.ShareLink) error {
+	args := m.Called(links)
+	return args.Error(0)
+}
+
+func (m *MockShareOutputPort) OutputRevokeShareLinkResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockShareOutputPort) OutputShareLinkNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockShareOutputPort) OutputSharedStores(stores []*model.Store) error {
+	args := m.Called(stores)
+	return args.Error(0)
+}
+
+func TestCreateShareLink(t *testing.T) {
+	/* Arrange */
+	mockShareRepository := new(MockShareRepository)
+	mockShareRepository.On("CreateShareLink", mock.Anything, "user_1").Return(nil)
+	mockShareOutputPort := new(MockShareOutputPort)
+	mockShareOutputPort.On("OutputShareLink", mock.Anything).Return(nil)
+
+	si := &ShareInteractor{shareRepository: mockShareRepository, shareOutputPort: mockShareOutputPort}
+
+	/* Act */
+	actual := si.CreateShareLink("user_1")
+
+	/* Assert */
+	// 推測できない長さのトークンを作成して出力すること
+	assert.NoError(t, actual)
+	mockShareOutputPort.AssertCalled(t, "OutputShareLink", mock.MatchedBy(func(link *model.ShareLink) bool {
+		return len(link.Token) == 43
+	}))
+}
+
+func TestCreateShareLinkWithUniqueToken(t *testing.T) {
+	/* Arrange */
+	mockShareRepository := new(MockShareRepository)
+	mockShareRepository.On("CreateShareLink", mock.Anything, "user_1").Return(nil)
+	mockShareOutputPort := new(MockShareOutputPort)
+	mockShareOutputPort.On("OutputShareLink", mock.Anything).Return(nil)
+
+	si := &ShareInteractor{shareRepository: mockShareRepository, shareOutputPort: mockShareOutputPort}
+
+	/* Act */
+	si.CreateShareLink("user_1")
+	si.CreateShareLink("user_1")
+
+	/* Assert */
+	// 作成するたびに異なるトークンになること
+	first := mockShareOutputPort.Calls[0].Arguments.Get(0).(*model.ShareLink)
+	second := mockShareOutputPort.Calls[1].Arguments.Get(0).(*model.ShareLink)
+	assert.NotEqual(t, first.Token, second.Token)
+}
+
+func TestRevokeShareLink(t *testing.T) {
+	/* Arrange */
+	mockShareRepository := new(MockShareRepository)
+	mockShareRepository.On("DeleteShareLink", "link_1", "user_1").Return(true, nil)
+	mockShareOutputPort := new(MockShareOutputPort)
+	mockShareOutputPort.On("OutputRevokeShareLinkResult").Return(nil)
+
+	si := &ShareInteractor{shareRepository: mockShareRepository, shareOutputPort: mockShareOutputPort}
+
+	/* Act */
+	actual := si.RevokeShareLink("link_1", "user_1")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockShareOutputPort.AssertCalled(t, "OutputRevokeShareLinkResult")
+}
+
+func TestRevokeShareLinkNotFound(t *testing.T) {
+	/* Arrange */
+	mockShareRepository := new(MockShareRepository)
+	mockShareRepository.On("DeleteShareLink", "link_1", "user_1").Return(false, nil)
+	mockShareOutputPort := new(MockShareOutputPort)
+	mockShareOutputPort.On("OutputShareLinkNotFound").Return(nil)
+
+	si := &ShareInteractor{shareRepository: mockShareRepository, shareOutputPort: mockShareOutputPort}
+
+	/* Act */
+	actual := si.RevokeShareLink("link_1", "user_1")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockShareOutputPort.AssertCalled(t, "OutputShareLinkNotFound")
+	mockShareOutputPort.AssertNotCalled(t, "OutputRevokeShareLinkResult")
+}
+
+func TestGetSharedFavoriteStores(t *testing.T) {
+	/* Arrange */
+	stores := []*model.Store{{Id: "Id001", Name: "UEC cafe"}}
+	mockShareRepository := new(MockShareRepository)
+	mockShareRepository.On("FindSharedUserId", "token_1").Return("user_1", nil)
+	mockShareRepository.On("GetFavoriteStores", "user_1").Return(stores, nil)
+	mockShareOutputPort := new(MockShareOutputPort)
+	mockShareOutputPort.On("OutputSharedStores", stores).Return(nil)
+
+	si := &ShareInteractor{shareRepository: mockShareRepository, shareOutputPort: mockShareOutputPort}
+
+	/* Act */
+	actual := si.GetSharedFavoriteStores("token_1")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockShareOutputPort.AssertCalled(t, "OutputSharedStores", stores)
+}
+
+func TestGetSharedFavoriteStoresWithRevokedToken(t *testing.T) {
+	/* Arrange */
+	mockShareRepository := new(MockShareRepository)
+	mockShareRepository.On("FindSharedUserId", "token_1").Return("", nil)
+	mockShareOutputPort := new(MockShareOutputPort)
+	mockShareOutputPort.On("OutputShareLinkNotFound").Return(nil)
+
+	si := &ShareInteractor{shareRepository: mockShareRepository, shareOutputPort: mockShareOutputPort}
+
+	/* Act */
+	actual := si.GetSharedFavoriteStores("token_1")
+
+	/* Assert */
+	// 取り消されたトークンではお気に入りを取得しないこと
+	assert.NoError(t, actual)
+	mockShareOutputPort.AssertCalled(t, "OutputShareLinkNotFound")
+	mockShareRepository.AssertNotCalled(t, "GetFavoriteStores", mock.Anything)
+}
@@ -2,9 +2,13 @@ package interactor
 
 import (
 	model "clean-storemap-api/src/entity"
+	"clean-storemap-api/src/usecase/cluster"
 	port "clean-storemap-api/src/usecase/port"
 )
 
+// タグの入力補完で返す最大の件数
+const maxTagSuggestions = 10
+
 type StoreInteractor struct {
 	storeRepository port.StoreRepository
 	storeOutputPort port.StoreOutputPort
@@ -22,23 +26,94 @@ func (si *StoreInteractor) GetStores() error {
 	if err != nil {
 		return err
 	}
-	return si.storeOutputPort.OutputAllStores(stores)
+	return si.storeOutputPort.OutputAllStores(stores, nil)
+}
+
+func (si *StoreInteractor) GetStoresInBoundingBox(box *model.BoundingBox) error {
+	stores, err := si.storeRepository.GetStoresInBoundingBox(box, model.MaxViewportStores)
+	if err != nil {
+		return err
+	}
+	if err := si.setReviewSummaries(stores); err != nil {
+		return err
+	}
+	return si.storeOutputPort.OutputAllStores(stores, nil)
+}
+
+// 範囲内の店舗をズームレベルに応じた格子でまとめて返す
+func (si *StoreInteractor) GetStoreClusters(box *model.BoundingBox, zoom int) error {
+	stores, err := si.storeRepository.GetStoresInBoundingBox(box, model.MaxClusterStores)
+	if err != nil {
+		return err
+	}
+	return si.storeOutputPort.OutputStoreClusters(cluster.Grid(stores, zoom))
+}
+
+func (si *StoreInteractor) GetNearStores(area *model.SearchArea, query *model.StoreQuery, page *model.PageRequest) error {
+	// 距離順の場合はページをまたいでも近い順になるよう、取得する時点で距離順にする
+	area.RankByDistance = query.SortsByDistance()
+	places, next, err := si.storeRepository.GetNearStores(area, page)
+	if err != nil {
+		return err
+	}
+	if err := si.setReviewSummaries(places); err != nil {
+		return err
+	}
+	// 絞り込みはページごとに行うため、返す件数がlimitより少なくても続きのページが存在することがある
+	return si.storeOutputPort.OutputAllStores(query.Apply(places), next)
+}
+
+func (si *StoreInteractor) SearchStores(search *model.StoreSearch, page *model.PageRequest) error {
+	stores, next, err := si.storeRepository.SearchStores(search, page)
+	if err != nil {
+		return err
+	}
+	if err := si.setReviewSummaries(stores); err != nil {
+		return err
+	}
+	return si.storeOutputPort.OutputAllStores(stores, next)
+}
+
+func (si *StoreInteractor) GetStore(id string) error {
+	// お気に入り登録された際の店舗の情報があれば、Places APIが使えない場合でも返せるようにしておく
+	snapshot, err := si.storeRepository.FindStoreSnapshot(id)
+	if err != nil {
+		return err
+	}
+	detail, err := si.storeRepository.GetStoreDetail(id)
+	if err != nil && snapshot == nil {
+		return err
+	}
+	if detail == nil && snapshot != nil {
+		detail = &model.StoreDetail{Store: *snapshot}
+	}
+	if detail == nil {
+		return si.storeOutputPort.OutputStoreNotFound()
+	}
+	if err := si.setReviewSummaries([]*model.Store{&detail.Store}); err != nil {
+		return err
+	}
+	return si.storeOutputPort.OutputStoreDetail(detail)
 }
 
-func (si *StoreInteractor) GetNearStores() error {
-	places, err := si.storeRepository.GetNearStores()
+func (si *StoreInteractor) GetFavoriteStores(userId string, query *model.StoreQuery) error {
+	stores, err := si.storeRepository.GetFavoriteStores(userId)
 	if err != nil {
 		return err
 	}
-	return si.storeOutputPort.OutputAllStores(places)
+	if err := si.setReviewSummaries(stores); err != nil {
+		return err
+	}
+	return si.storeOutputPort.OutputAllStores(query.Apply(stores), nil)
 }
 
-func (si *StoreInteractor) GetFavoriteStores(userId string) error {
+// 登録した順にすべてのお気に入りを書き出す
+func (si *StoreInteractor) ExportFavoriteStores(userId string, format model.ExportFormat) error {
 	stores, err := si.storeRepository.GetFavoriteStores(userId)
 	if err != nil {
 		return err
 	}
-	return si.storeOutputPort.OutputAllStores(stores)
+	return si.storeOutputPort.OutputFavoriteStoresExport(stores, format)
 }
 
 func (si *StoreInteractor) SaveFavoriteStore(store *model.Store, userId string) error {
@@ -58,10 +133,135 @@ func (si *StoreInteractor) SaveFavoriteStore(store *model.Store, userId string)
 	return nil
 }
 
-func (si *StoreInteractor) GetTopFavoriteStores() error {
-	stores, err := si.storeRepository.GetTopFavoriteStores()
+// 行ごとに取り込めるか判定し、取り込める店舗をまとめて登録してから行ごとの結果を返す
+func (si *StoreInteractor) ImportFavoriteStores(userId string, rows []*model.ImportRow) error {
+	results := make([]*model.ImportResult, 0, len(rows))
+	stores := make([]*model.Store, 0)
+	imported := make(map[string]bool)
+	for _, row := range rows {
+		if row.Store == nil {
+			results = append(results, row.Result("", model.ImportStatusInvalid, row.Invalid))
+			continue
+		}
+		store := row.Store
+		if store.Id == "" {
+			matched, err := si.findImportedStore(store)
+			if err != nil {
+				return err
+			}
+			if matched == nil {
+				results = append(results, row.Result("", model.ImportStatusInvalid, "store is not found near the saved location"))
+				continue
+			}
+			// 店舗の情報は見つけた店舗のもの、メモとタグは取り込んだ行のものとする
+			matched.Favorite = store.Favorite
+			store = matched
+		}
+		if imported[store.Id] {
+			results = append(results, row.Result(store.Id, model.ImportStatusDuplicate, ""))
+			continue
+		}
+		exist, err := si.storeRepository.ExistFavorite(store, userId)
+		if err != nil {
+			return err
+		}
+		if exist {
+			results = append(results, row.Result(store.Id, model.ImportStatusDuplicate, ""))
+			continue
+		}
+		imported[store.Id] = true
+		stores = append(stores, store)
+		results = append(results, row.Result(store.Id, model.ImportStatusImported, ""))
+	}
+	if len(stores) > 0 {
+		if err := si.storeRepository.SaveFavoriteStores(stores, userId); err != nil {
+			return err
+		}
+	}
+	return si.storeOutputPort.OutputImportResults(results)
+}
+
+// 店舗のidがわからない行は、保存された位置の周辺を店舗名で検索して対応する店舗を探す
+func (si *StoreInteractor) findImportedStore(store *model.Store) (*model.Store, error) {
+	search := &model.StoreSearch{
+		Query: store.Name,
+		Bias:  &model.SearchArea{Center: store.Location, Radius: model.ImportMatchRadius},
+	}
+	candidates, _, err := si.storeRepository.SearchStores(search, &model.PageRequest{Limit: model.DefaultPageSize})
+	if err != nil {
+		return nil, err
+	}
+	return model.MatchImportedStore(store, candidates), nil
+}
+
+func (si *StoreInteractor) UpdateFavoriteStore(storeId string, userId string, favorite *model.Favorite) error {
+	updated, err := si.storeRepository.UpdateFavoriteStore(storeId, userId, favorite)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return si.storeOutputPort.OutputFavoriteNotFound()
+	}
+	return si.storeOutputPort.OutputUpdateFavoriteStoreResult()
+}
+
+func (si *StoreInteractor) DeleteFavoriteStore(storeId string, userId string) error {
+	deleted, err := si.storeRepository.DeleteFavoriteStore(storeId, userId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return si.storeOutputPort.OutputFavoriteNotFound()
+	}
+	return si.storeOutputPort.OutputDeleteFavoriteStoreResult()
+}
+
+// 入力補完の候補はユーザー自身が付けたタグのみから選ぶ
+func (si *StoreInteractor) GetFavoriteTags(userId string, prefix string) error {
+	stores, err := si.storeRepository.GetFavoriteStores(userId)
+	if err != nil {
+		return err
+	}
+	return si.storeOutputPort.OutputFavoriteTags(model.SuggestTags(stores, prefix, maxTagSuggestions))
+}
+
+// sort=ratingの場合はお気に入り登録数ではなくレビューの平均でランキングを作る
+func (si *StoreInteractor) GetTopFavoriteStores(query *model.StoreQuery, ranking *model.Ranking) error {
+	var stores []*model.Store
+	var err error
+	if query.SortsByRating() {
+		stores, err = si.storeRepository.GetTopRatedStores(ranking)
+	} else {
+		stores, err = si.storeRepository.GetTopFavoriteStores(ranking)
+	}
+	if err != nil {
+		return err
+	}
+	if err := si.setReviewSummaries(stores); err != nil {
+		return err
+	}
+	return si.storeOutputPort.OutputAllStores(query.Apply(stores), nil)
+}
+
+// 店舗ごとのレビューの集計を設定する。レビューのない店舗は件数0とする
+func (si *StoreInteractor) setReviewSummaries(stores []*model.Store) error {
+	if len(stores) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(stores))
+	for _, s := range stores {
+		ids = append(ids, s.Id)
+	}
+	summaries, err := si.storeRepository.GetReviewSummaries(ids)
 	if err != nil {
 		return err
 	}
-	return si.storeOutputPort.OutputAllStores(stores)
+	for _, s := range stores {
+		if summary, ok := summaries[s.Id]; ok {
+			s.Reviews = summary
+		} else {
+			s.Reviews = &model.ReviewSummary{}
+		}
+	}
+	return nil
 }
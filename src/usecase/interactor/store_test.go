@@ -4,6 +4,7 @@ import (
 	model "clean-storemap-api/src/entity"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -21,11 +22,31 @@ func (m *MockStoreRepository) GetAll() ([]*model.Store, error) {
 	return args.Get(0).([]*model.Store), args.Error(1)
 }
 
-func (m *MockStoreRepository) GetNearStores() ([]*model.Store, error) {
-	args := m.Called()
+func (m *MockStoreRepository) GetStoresInBoundingBox(box *model.BoundingBox, limit int) ([]*model.Store, error) {
+	args := m.Called(box, limit)
 	return args.Get(0).([]*model.Store), args.Error(1)
 }
 
+func (m *MockStoreRepository) GetNearStores(area *model.SearchArea, page *model.PageRequest) ([]*model.Store, *model.Cursor, error) {
+	args := m.Called(area, page)
+	return args.Get(0).([]*model.Store), args.Get(1).(*model.Cursor), args.Error(2)
+}
+
+func (m *MockStoreRepository) SearchStores(search *model.StoreSearch, page *model.PageRequest) ([]*model.Store, *model.Cursor, error) {
+	args := m.Called(search, page)
+	return args.Get(0).([]*model.Store), args.Get(1).(*model.Cursor), args.Error(2)
+}
+
+func (m *MockStoreRepository) FindStoreSnapshot(id string) (*model.Store, error) {
+	args := m.Called(id)
+	return args.Get(0).(*model.Store), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetStoreDetail(id string) (*model.StoreDetail, error) {
+	args := m.Called(id)
+	return args.Get(0).(*model.StoreDetail), args.Error(1)
+}
+
 func (m *MockStoreRepository) ExistFavorite(store *model.Store, userId string) (bool, error) {
 	args := m.Called(store, userId)
 	return args.Bool(0), args.Error(1)
@@ -41,13 +62,63 @@ func (m *MockStoreRepository) SaveFavoriteStore(store *model.Store, userId strin
 	return args.Error(0)
 }
 
-func (m *MockStoreRepository) GetTopFavoriteStores() ([]*model.Store, error) {
-	args := m.Called()
+func (m *MockStoreRepository) SaveFavoriteStores(stores []*model.Store, userId string) error {
+	args := m.Called(stores, userId)
+	return args.Error(0)
+}
+
+func (m *MockStoreRepository) UpdateFavoriteStore(storeId string, userId string, favorite *model.Favorite) (bool, error) {
+	args := m.Called(storeId, userId, favorite)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreRepository) DeleteFavoriteStore(storeId string, userId string) (bool, error) {
+	args := m.Called(storeId, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetTopFavoriteStores(ranking *model.Ranking) ([]*model.Store, error) {
+	args := m.Called(ranking)
 	return args.Get(0).([]*model.Store), args.Error(1)
 }
 
-func (m *MockStoreOutputPort) OutputAllStores(stores []*model.Store) error {
-	args := m.Called(stores)
+func (m *MockStoreRepository) GetTopRatedStores(ranking *model.Ranking) ([]*model.Store, error) {
+	args := m.Called(ranking)
+	return args.Get(0).([]*model.Store), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetReviewSummaries(storeIds []string) (map[string]*model.ReviewSummary, error) {
+	args := m.Called(storeIds)
+	return args.Get(0).(map[string]*model.ReviewSummary), args.Error(1)
+}
+
+func (m *MockStoreOutputPort) OutputAllStores(stores []*model.Store, next *model.Cursor) error {
+	args := m.Called(stores, next)
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputPort) OutputStoreClusters(clusters []*model.StoreCluster) error {
+	args := m.Called(clusters)
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputPort) OutputFavoriteStoresExport(stores []*model.Store, format model.ExportFormat) error {
+	args := m.Called(stores, format)
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputPort) OutputImportResults(results []*model.ImportResult) error {
+	args := m.Called(results)
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputPort) OutputStoreDetail(detail *model.StoreDetail) error {
+	args := m.Called(detail)
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputPort) OutputStoreNotFound() error {
+	args := m.Called()
 	return args.Error(0)
 }
 
@@ -61,6 +132,26 @@ func (m *MockStoreOutputPort) OutputAlreadyExistFavorite() error {
 	return args.Error(0)
 }
 
+func (m *MockStoreOutputPort) OutputUpdateFavoriteStoreResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputPort) OutputFavoriteTags(tags []string) error {
+	args := m.Called(tags)
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputPort) OutputDeleteFavoriteStoreResult() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockStoreOutputPort) OutputFavoriteNotFound() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
 func TestGetStores(t *testing.T) {
 	/* Arrange */
 	expected := errors.New("")
@@ -79,7 +170,7 @@ func TestGetStores(t *testing.T) {
 	mockStoreRepository := new(MockStoreRepository)
 	mockStoreRepository.On("GetAll").Return(stores, nil)
 	mockStoreOutputPort := new(MockStoreOutputPort)
-	mockStoreOutputPort.On("OutputAllStores", stores).Return(expected)
+	mockStoreOutputPort.On("OutputAllStores", stores, (*model.Cursor)(nil)).Return(expected)
 
 	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
 
@@ -94,7 +185,58 @@ func TestGetStores(t *testing.T) {
 	// OutputPortのOutputAllStoresが1回呼ばれること
 	mockStoreOutputPort.AssertNumberOfCalls(t, "OutputAllStores", 1)
 	// OutputPortのOutputAllStoresがstoresを引数として呼ばれること
-	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", stores)
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", stores, (*model.Cursor)(nil))
+}
+
+func TestGetStoresInBoundingBox(t *testing.T) {
+	/* Arrange */
+	box := &model.BoundingBox{MinLng: 139.5, MinLat: 35.6, MaxLng: 139.9, MaxLat: 35.8}
+	stores := []*model.Store{
+		{Id: "Id001", Name: "UEC cafe", Location: model.Location{Lat: "35.7", Lng: "139.6"}},
+	}
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetStoresInBoundingBox", box, model.MaxViewportStores).Return(stores, nil)
+	mockStoreRepository.On("GetReviewSummaries", []string{"Id001"}).Return(map[string]*model.ReviewSummary{"Id001": {Average: 4, Count: 1}}, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputAllStores", mock.Anything, (*model.Cursor)(nil)).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetStoresInBoundingBox(box)
+
+	/* Assert */
+	// 範囲内の店舗をレビューの集計と合わせて返すこと
+	assert.NoError(t, actual)
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", mock.MatchedBy(func(stores []*model.Store) bool {
+		return len(stores) == 1 && stores[0].Reviews.Count == 1
+	}), (*model.Cursor)(nil))
+}
+
+func TestGetStoreClusters(t *testing.T) {
+	/* Arrange */
+	box := &model.BoundingBox{MinLng: 135, MinLat: 34, MaxLng: 140, MaxLat: 36}
+	stores := []*model.Store{
+		{Id: "Id001", Location: model.Location{Lat: "35.68", Lng: "139.76"}},
+		{Id: "Id002", Location: model.Location{Lat: "35.70", Lng: "139.70"}},
+	}
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetStoresInBoundingBox", box, model.MaxClusterStores).Return(stores, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputStoreClusters", mock.Anything).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetStoreClusters(box, 5)
+
+	/* Assert */
+	// クラスタリング用の件数で取得し、まとめた結果を返すこと
+	assert.NoError(t, actual)
+	mockStoreRepository.AssertNotCalled(t, "GetReviewSummaries", mock.Anything)
+	mockStoreOutputPort.AssertCalled(t, "OutputStoreClusters", mock.MatchedBy(func(clusters []*model.StoreCluster) bool {
+		return len(clusters) == 1 && clusters[0].Count == 2
+	}))
 }
 
 func TestGetNearStores(t *testing.T) {
@@ -112,21 +254,146 @@ func TestGetNearStores(t *testing.T) {
 		},
 	)
 
+	area := &model.SearchArea{Center: model.Location{Lat: "35.713", Lng: "139.762"}, Radius: 500.0}
+	page := &model.PageRequest{Limit: 10}
+	next := &model.Cursor{Offset: 10}
+
 	mockStoreRepository := new(MockStoreRepository)
-	mockStoreRepository.On("GetNearStores").Return(stores, nil)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
+	mockStoreRepository.On("GetNearStores", area, page).Return(stores, next, nil)
 	mockStoreOutputPort := new(MockStoreOutputPort)
-	mockStoreOutputPort.On("OutputAllStores", stores).Return(expected)
+	mockStoreOutputPort.On("OutputAllStores", stores, next).Return(expected)
 
 	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
 
 	/* Act */
-	actual := si.GetNearStores()
+	actual := si.GetNearStores(area, nil, page)
 
 	/* Assert */
 	assert.Equal(t, expected, actual)
 	mockStoreRepository.AssertNumberOfCalls(t, "GetNearStores", 1)
+	mockStoreRepository.AssertCalled(t, "GetNearStores", area, page)
 	mockStoreOutputPort.AssertNumberOfCalls(t, "OutputAllStores", 1)
-	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", stores)
+	// 次のページのカーソルがそのまま出力されること
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", stores, next)
+}
+
+func TestSearchStores(t *testing.T) {
+	/* Arrange */
+	stores := []*model.Store{
+		{
+			Id:                  "Id001",
+			Name:                "UEC cafe",
+			RegularOpeningHours: "Sat: 06:00 - 22:00, Sun: 06:00 - 22:00",
+			PriceLevel:          "PRICE_LEVEL_MODERATE",
+			Location:            model.Location{Lat: "35.713", Lng: "139.762"},
+		},
+	}
+	search := &model.StoreSearch{Query: "cafe", PriceLevels: []model.PriceLevel{"PRICE_LEVEL_MODERATE"}}
+	page := &model.PageRequest{Limit: 10}
+	next := &model.Cursor{PageToken: "token"}
+
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
+	mockStoreRepository.On("SearchStores", search, page).Return(stores, next, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputAllStores", stores, next).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.SearchStores(search, page)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockStoreRepository.AssertNumberOfCalls(t, "SearchStores", 1)
+	// 検索結果と次のページのカーソルが出力されること
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", stores, next)
+}
+
+func TestGetStore(t *testing.T) {
+	/* Arrange */
+	id := "Id001"
+	snapshot := &model.Store{
+		Id:       id,
+		Name:     "UEC cafe",
+		Location: model.Location{Lat: "35.713", Lng: "139.762"},
+	}
+	detail := &model.StoreDetail{
+		Store:           *snapshot,
+		Address:         "東京都調布市調布ヶ丘1-5-1",
+		Rating:          4.2,
+		UserRatingCount: 120,
+		BusinessStatus:  "OPERATIONAL",
+	}
+
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("FindStoreSnapshot", id).Return(snapshot, nil)
+	mockStoreRepository.On("GetStoreDetail", id).Return(detail, nil)
+	mockStoreRepository.On("GetReviewSummaries", []string{id}).Return(map[string]*model.ReviewSummary{id: {Average: 4.5, Count: 2}}, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputStoreDetail", detail).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetStore(id)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// Places Detailsの詳細がこのアプリでのレビューの集計と合わせて出力されること
+	mockStoreOutputPort.AssertCalled(t, "OutputStoreDetail", detail)
+	assert.Equal(t, &model.ReviewSummary{Average: 4.5, Count: 2}, detail.Reviews)
+}
+
+func TestGetStoreFallbackToSnapshot(t *testing.T) {
+	/* Arrange */
+	id := "Id001"
+	snapshot := &model.Store{
+		Id:       id,
+		Name:     "UEC cafe",
+		Location: model.Location{Lat: "35.713", Lng: "139.762"},
+	}
+
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
+	mockStoreRepository.On("FindStoreSnapshot", id).Return(snapshot, nil)
+	mockStoreRepository.On("GetStoreDetail", id).Return((*model.StoreDetail)(nil), errors.New("places api is unavailable"))
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	expected := &model.StoreDetail{Store: *snapshot}
+	expected.Reviews = &model.ReviewSummary{}
+	mockStoreOutputPort.On("OutputStoreDetail", expected).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetStore(id)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// Places APIが使えない場合はお気に入り登録時の情報が出力されること
+	mockStoreOutputPort.AssertCalled(t, "OutputStoreDetail", expected)
+}
+
+func TestGetStoreNotFound(t *testing.T) {
+	/* Arrange */
+	id := "Id999"
+
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("FindStoreSnapshot", id).Return((*model.Store)(nil), nil)
+	mockStoreRepository.On("GetStoreDetail", id).Return((*model.StoreDetail)(nil), nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputStoreNotFound").Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetStore(id)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockStoreOutputPort.AssertNumberOfCalls(t, "OutputStoreNotFound", 1)
+	mockStoreOutputPort.AssertNotCalled(t, "OutputStoreDetail", mock.Anything)
 }
 
 func TestGetFavoriteStores(t *testing.T) {
@@ -145,20 +412,21 @@ func TestGetFavoriteStores(t *testing.T) {
 	userId := "Id001"
 
 	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
 	mockStoreRepository.On("GetFavoriteStores", userId).Return(stores, nil)
 	mockStoreOutputPort := new(MockStoreOutputPort)
-	mockStoreOutputPort.On("OutputAllStores", stores).Return(nil)
+	mockStoreOutputPort.On("OutputAllStores", stores, (*model.Cursor)(nil)).Return(nil)
 
 	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
 
 	/* Act */
-	actual := si.GetFavoriteStores(userId)
+	actual := si.GetFavoriteStores(userId, nil)
 
 	/* Assert */
 	assert.Equal(t, nil, actual)
 	mockStoreRepository.AssertNumberOfCalls(t, "GetFavoriteStores", 1)
 	mockStoreOutputPort.AssertNumberOfCalls(t, "OutputAllStores", 1)
-	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", stores)
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", stores, (*model.Cursor)(nil))
 }
 
 func TestSaveFavoriteStore(t *testing.T) {
@@ -193,6 +461,224 @@ func TestSaveFavoriteStore(t *testing.T) {
 	mockStoreRepository.AssertCalled(t, "ExistFavorite", store, userId)
 }
 
+func TestGetFavoriteStoresWithTag(t *testing.T) {
+	/* Arrange */
+	cafe := &model.Store{Id: "Id001", Favorite: &model.Favorite{Tags: []string{"Cafe", "Wi-Fi"}}}
+	restaurant := &model.Store{Id: "Id002", Favorite: &model.Favorite{Tags: []string{"lunch"}}}
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
+	mockStoreRepository.On("GetFavoriteStores", "Id001").Return([]*model.Store{cafe, restaurant}, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputAllStores", mock.Anything, mock.Anything).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetFavoriteStores("Id001", &model.StoreQuery{Tag: "cafe"})
+
+	/* Assert */
+	// タグは大文字小文字を区別せずに絞り込むこと
+	assert.NoError(t, actual)
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", []*model.Store{cafe}, (*model.Cursor)(nil))
+}
+
+func TestGetFavoriteTags(t *testing.T) {
+	/* Arrange */
+	stores := []*model.Store{
+		{Id: "Id001", Favorite: &model.Favorite{Tags: []string{"cafe", "Wi-Fi"}}},
+		{Id: "Id002", Favorite: &model.Favorite{Tags: []string{"Cake", "Cafe"}}},
+		{Id: "Id003", Favorite: &model.Favorite{Tags: []string{"lunch"}}},
+	}
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetFavoriteStores", "Id001").Return(stores, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputFavoriteTags", mock.Anything).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetFavoriteTags("Id001", "CA")
+
+	/* Assert */
+	// 前方一致するタグを使った回数の多い順に返し、表記は最初に使われたものにまとめること
+	assert.NoError(t, actual)
+	mockStoreOutputPort.AssertCalled(t, "OutputFavoriteTags", []string{"cafe", "Cake"})
+}
+
+func TestExportFavoriteStores(t *testing.T) {
+	/* Arrange */
+	stores := []*model.Store{
+		{Id: "Id001", Name: "UEC cafe", Location: model.Location{Lat: "35.713", Lng: "139.762"}, Favorite: &model.Favorite{Note: "note"}},
+	}
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetFavoriteStores", "user_1").Return(stores, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputFavoriteStoresExport", stores, model.ExportFormatGpx).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.ExportFavoriteStores("user_1", model.ExportFormatGpx)
+
+	/* Assert */
+	// すべてのお気に入りを指定した形式で書き出すこと
+	assert.NoError(t, actual)
+	mockStoreOutputPort.AssertCalled(t, "OutputFavoriteStoresExport", stores, model.ExportFormatGpx)
+}
+
+func TestImportFavoriteStores(t *testing.T) {
+	/* Arrange */
+	cafe := &model.Store{Id: "Id001", Name: "UEC cafe", Location: model.Location{Lat: "35.713", Lng: "139.762"}, Favorite: &model.Favorite{}}
+	saved := &model.Store{Id: "Id002", Name: "UEC restaurant", Location: model.Location{Lat: "35.714", Lng: "139.763"}, Favorite: &model.Favorite{}}
+	rows := []*model.ImportRow{
+		{Row: 1, Name: "UEC cafe", Store: cafe},
+		{Row: 2, Name: "UEC restaurant", Store: saved},
+		{Row: 3, Name: "UEC cafe", Store: cafe},
+		{Row: 4, Name: "Somewhere", Invalid: "latitude is invalid"},
+	}
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("ExistFavorite", cafe, "user_1").Return(false, nil)
+	mockStoreRepository.On("ExistFavorite", saved, "user_1").Return(true, nil)
+	mockStoreRepository.On("SaveFavoriteStores", mock.Anything, "user_1").Return(nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputImportResults", mock.Anything).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.ImportFavoriteStores("user_1", rows)
+
+	/* Assert */
+	// 登録済みの店舗とファイル内で重複した店舗は重複、検証に失敗した行は無効とし、残りをまとめて登録すること
+	assert.NoError(t, actual)
+	mockStoreRepository.AssertNumberOfCalls(t, "SaveFavoriteStores", 1)
+	mockStoreRepository.AssertCalled(t, "SaveFavoriteStores", []*model.Store{cafe}, "user_1")
+	mockStoreOutputPort.AssertCalled(t, "OutputImportResults", []*model.ImportResult{
+		{Row: 1, Name: "UEC cafe", StoreId: "Id001", Status: model.ImportStatusImported},
+		{Row: 2, Name: "UEC restaurant", StoreId: "Id002", Status: model.ImportStatusDuplicate},
+		{Row: 3, Name: "UEC cafe", StoreId: "Id001", Status: model.ImportStatusDuplicate},
+		{Row: 4, Name: "Somewhere", Status: model.ImportStatusInvalid, Reason: "latitude is invalid"},
+	})
+}
+
+func TestImportFavoriteStoresWithoutStoreId(t *testing.T) {
+	/* Arrange */
+	savedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []*model.ImportRow{
+		{Row: 1, Name: "Hongo coffee", Store: &model.Store{Name: "Hongo coffee", Location: model.Location{Lat: "35.7110", Lng: "139.7610"}, Favorite: &model.Favorite{Note: "note", SavedAt: savedAt}}},
+		{Row: 2, Name: "UEC bar", Store: &model.Store{Name: "UEC bar", Location: model.Location{Lat: "35.6", Lng: "139.5"}, Favorite: &model.Favorite{}}},
+	}
+	mockStoreRepository := new(MockStoreRepository)
+	// 保存された位置から約30m離れた店舗と、約1km離れた同じ名前の店舗が見つかる
+	mockStoreRepository.On("SearchStores", mock.MatchedBy(func(search *model.StoreSearch) bool {
+		return search.Query == "Hongo coffee"
+	}), mock.Anything).Return([]*model.Store{
+		{Id: "Id010", Name: "Hongo coffee 2nd", Location: model.Location{Lat: "35.7200", Lng: "139.7610"}},
+		{Id: "Id011", Name: "Hongo coffee", Location: model.Location{Lat: "35.7113", Lng: "139.7610"}, PriceLevel: model.PriceLevelInexpensive},
+	}, (*model.Cursor)(nil), nil)
+	mockStoreRepository.On("SearchStores", mock.Anything, mock.Anything).Return([]*model.Store{}, (*model.Cursor)(nil), nil)
+	mockStoreRepository.On("ExistFavorite", mock.Anything, "user_1").Return(false, nil)
+	mockStoreRepository.On("SaveFavoriteStores", mock.Anything, "user_1").Return(nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputImportResults", mock.Anything).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.ImportFavoriteStores("user_1", rows)
+
+	/* Assert */
+	// 保存された位置の近くの店舗を見つけて、メモと登録日時は取り込んだ行のものとすること
+	assert.NoError(t, actual)
+	mockStoreRepository.AssertCalled(t, "SaveFavoriteStores", mock.MatchedBy(func(stores []*model.Store) bool {
+		return len(stores) == 1 &&
+			stores[0].Id == "Id011" &&
+			stores[0].PriceLevel == model.PriceLevelInexpensive &&
+			stores[0].Favorite.Note == "note" &&
+			stores[0].Favorite.SavedAt.Equal(savedAt)
+	}), "user_1")
+	mockStoreOutputPort.AssertCalled(t, "OutputImportResults", []*model.ImportResult{
+		{Row: 1, Name: "Hongo coffee", StoreId: "Id011", Status: model.ImportStatusImported},
+		{Row: 2, Name: "UEC bar", Status: model.ImportStatusInvalid, Reason: "store is not found near the saved location"},
+	})
+}
+
+func TestImportFavoriteStoresNothingToSave(t *testing.T) {
+	/* Arrange */
+	rows := []*model.ImportRow{
+		{Row: 1, Name: "", Invalid: "name is required"},
+	}
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputImportResults", mock.Anything).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.ImportFavoriteStores("user_1", rows)
+
+	/* Assert */
+	// 取り込める行がない場合は登録しないこと
+	assert.NoError(t, actual)
+	mockStoreRepository.AssertNotCalled(t, "SaveFavoriteStores", mock.Anything, mock.Anything)
+}
+
+func TestUpdateFavoriteStoreNotFound(t *testing.T) {
+	/* Arrange */
+	favorite := &model.Favorite{Note: "memo", Tags: []string{}}
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("UpdateFavoriteStore", "Id999", "Id001", favorite).Return(false, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputFavoriteNotFound").Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.UpdateFavoriteStore("Id999", "Id001", favorite)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockStoreOutputPort.AssertNumberOfCalls(t, "OutputFavoriteNotFound", 1)
+}
+
+func TestDeleteFavoriteStore(t *testing.T) {
+	/* Arrange */
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("DeleteFavoriteStore", "Id001", "Id001").Return(true, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputDeleteFavoriteStoreResult").Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.DeleteFavoriteStore("Id001", "Id001")
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockStoreRepository.AssertCalled(t, "DeleteFavoriteStore", "Id001", "Id001")
+	mockStoreOutputPort.AssertNumberOfCalls(t, "OutputDeleteFavoriteStoreResult", 1)
+	mockStoreOutputPort.AssertNotCalled(t, "OutputFavoriteNotFound")
+}
+
+func TestDeleteFavoriteStoreNotFound(t *testing.T) {
+	/* Arrange */
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("DeleteFavoriteStore", "Id999", "Id001").Return(false, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputFavoriteNotFound").Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.DeleteFavoriteStore("Id999", "Id001")
+
+	/* Assert */
+	// お気に入りが存在しない場合は404用の出力を呼ぶこと
+	assert.NoError(t, actual)
+	mockStoreOutputPort.AssertNumberOfCalls(t, "OutputFavoriteNotFound", 1)
+	mockStoreOutputPort.AssertNotCalled(t, "OutputDeleteFavoriteStoreResult")
+}
+
 func TestGetTopFavoriteStores(t *testing.T) {
 	/* Arrange */
 	expected := errors.New("")
@@ -209,18 +695,188 @@ func TestGetTopFavoriteStores(t *testing.T) {
 	)
 
 	mockStoreRepository := new(MockStoreRepository)
-	mockStoreRepository.On("GetTopFavoriteStores").Return(stores, nil)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
+	ranking := &model.Ranking{Limit: model.DefaultRankingLimit}
+	mockStoreRepository.On("GetTopFavoriteStores", ranking).Return(stores, nil)
 	mockStoreOutputPort := new(MockStoreOutputPort)
-	mockStoreOutputPort.On("OutputAllStores", stores).Return(expected)
+	mockStoreOutputPort.On("OutputAllStores", stores, (*model.Cursor)(nil)).Return(expected)
 
 	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
 
 	/* Act */
-	actual := si.GetTopFavoriteStores()
+	actual := si.GetTopFavoriteStores(nil, ranking)
 
 	/* Assert */
 	assert.Equal(t, expected, actual)
 	mockStoreRepository.AssertNumberOfCalls(t, "GetTopFavoriteStores", 1)
 	mockStoreOutputPort.AssertNumberOfCalls(t, "OutputAllStores", 1)
-	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", stores)
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", stores, (*model.Cursor)(nil))
+}
+
+func TestGetFavoriteStoresOpenAt(t *testing.T) {
+	/* Arrange */
+	jst := time.FixedZone("JST", 9*60*60)
+	// 土曜日の6:00から22:00まで営業している店舗
+	openStore := &model.Store{
+		Id:   "Id001",
+		Name: "UEC cafe",
+		OpeningHours: &model.OpeningHours{
+			Periods: []model.OpeningPeriod{
+				{Open: model.DayTime{Day: time.Saturday, Hour: 6}, Close: &model.DayTime{Day: time.Saturday, Hour: 22}},
+			},
+			UtcOffsetMinutes: 540,
+		},
+		Location: model.Location{Lat: "35.713", Lng: "139.762"},
+	}
+	// 金曜日の18:00から土曜日の2:00まで営業している店舗
+	closedStore := &model.Store{
+		Id:   "Id002",
+		Name: "UEC bar",
+		OpeningHours: &model.OpeningHours{
+			Periods: []model.OpeningPeriod{
+				{Open: model.DayTime{Day: time.Friday, Hour: 18}, Close: &model.DayTime{Day: time.Saturday, Hour: 2}},
+			},
+			UtcOffsetMinutes: 540,
+		},
+		Location: model.Location{Lat: "35.714", Lng: "139.763"},
+	}
+	// 営業時間が不明な店舗
+	unknownStore := &model.Store{
+		Id:       "Id003",
+		Name:     "UEC restaurant",
+		Location: model.Location{Lat: "35.715", Lng: "139.764"},
+	}
+	stores := []*model.Store{openStore, closedStore, unknownStore}
+	userId := "Id001"
+	openAt := time.Date(2024, 10, 5, 12, 0, 0, 0, jst) // 土曜日の12:00
+	query, _ := model.NewStoreQuery(&openAt, nil, "", nil)
+
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
+	mockStoreRepository.On("GetFavoriteStores", userId).Return(stores, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputAllStores", []*model.Store{openStore}, (*model.Cursor)(nil)).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetFavoriteStores(userId, query)
+
+	/* Assert */
+	assert.Equal(t, nil, actual)
+	// 指定した時刻に営業している店舗のみが出力されること
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", []*model.Store{openStore}, (*model.Cursor)(nil))
+}
+
+func TestGetNearStoresSortByDistance(t *testing.T) {
+	/* Arrange */
+	area := &model.SearchArea{Center: model.Location{Lat: "35.713", Lng: "139.762"}, Radius: 500.0}
+	page := &model.PageRequest{Limit: 10}
+	query, _ := model.NewStoreQuery(nil, &model.Coordinate{Lat: 35.713, Lng: 139.762}, "distance", nil)
+
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
+	mockStoreRepository.On("GetNearStores", area, page).Return([]*model.Store{}, (*model.Cursor)(nil), nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputAllStores", []*model.Store{}, (*model.Cursor)(nil)).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetNearStores(area, query, page)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// ページをまたいで距離順になるよう、距離順で取得すること
+	assert.True(t, area.RankByDistance)
+}
+
+func TestGetFavoriteStoresSortByDistance(t *testing.T) {
+	/* Arrange */
+	// 起点から約1.1km北の店舗
+	farStore := &model.Store{Id: "Id001", Name: "UEC cafe", Location: model.Location{Lat: "35.723", Lng: "139.762"}}
+	// 起点から約110m北の店舗
+	nearStore := &model.Store{Id: "Id002", Name: "UEC bar", Location: model.Location{Lat: "35.714", Lng: "139.762"}}
+	// 位置が不正な店舗
+	invalidStore := &model.Store{Id: "Id003", Name: "UEC restaurant", Location: model.Location{Lat: "", Lng: ""}}
+	userId := "Id001"
+	query, _ := model.NewStoreQuery(nil, &model.Coordinate{Lat: 35.713, Lng: 139.762}, "distance", nil)
+
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
+	mockStoreRepository.On("GetFavoriteStores", userId).Return([]*model.Store{invalidStore, farStore, nearStore}, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputAllStores", mock.Anything, (*model.Cursor)(nil)).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetFavoriteStores(userId, query)
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// 近い順に並び、距離がわからない店舗は最後になること
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", []*model.Store{nearStore, farStore, invalidStore}, (*model.Cursor)(nil))
+	assert.InDelta(t, 111.2, *nearStore.DistanceMeters, 0.5)
+	assert.InDelta(t, 1112.0, *farStore.DistanceMeters, 1)
+	assert.Nil(t, invalidStore.DistanceMeters)
+}
+
+func TestGetTopFavoriteStoresWithPriceFilter(t *testing.T) {
+	/* Arrange */
+	cheapStore := &model.Store{Id: "Id001", Name: "UEC cafe", PriceLevel: model.PriceLevelInexpensive}
+	moderateStore := &model.Store{Id: "Id002", Name: "UEC restaurant", PriceLevel: model.PriceLevelModerate}
+	expensiveStore := &model.Store{Id: "Id003", Name: "UEC sushi", PriceLevel: model.PriceLevelExpensive}
+	// 価格帯が不明な店舗
+	unknownStore := &model.Store{Id: "Id004", Name: "UEC bar"}
+	stores := []*model.Store{cheapStore, moderateStore, expensiveStore, unknownStore}
+	price, _ := model.NewPriceFilter("500", "2000", model.DefaultPriceRanges())
+	query, _ := model.NewStoreQuery(nil, nil, "", price)
+
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetReviewSummaries", mock.Anything).Return(map[string]*model.ReviewSummary{}, nil)
+	mockStoreRepository.On("GetTopFavoriteStores", mock.Anything).Return(stores, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputAllStores", mock.Anything, (*model.Cursor)(nil)).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetTopFavoriteStores(query, &model.Ranking{Limit: model.DefaultRankingLimit})
+
+	/* Assert */
+	assert.NoError(t, actual)
+	// 500円から2000円に重なる価格帯の店舗のみが順番を変えずに出力されること
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", []*model.Store{cheapStore, moderateStore}, (*model.Cursor)(nil))
+}
+
+func TestGetTopFavoriteStoresSortByRating(t *testing.T) {
+	/* Arrange */
+	goodStore := &model.Store{Id: "Id001", Name: "UEC cafe"}
+	bestStore := &model.Store{Id: "Id002", Name: "UEC restaurant"}
+	// レビューのない店舗
+	unratedStore := &model.Store{Id: "Id003", Name: "UEC sushi"}
+	stores := []*model.Store{unratedStore, goodStore, bestStore}
+	query, _ := model.NewStoreQuery(nil, nil, "rating", nil)
+
+	mockStoreRepository := new(MockStoreRepository)
+	mockStoreRepository.On("GetTopRatedStores", mock.Anything).Return(stores, nil)
+	mockStoreRepository.On("GetReviewSummaries", []string{"Id003", "Id001", "Id002"}).Return(map[string]*model.ReviewSummary{
+		"Id001": {Average: 4.0, Count: 3},
+		"Id002": {Average: 4.5, Count: 2},
+	}, nil)
+	mockStoreOutputPort := new(MockStoreOutputPort)
+	mockStoreOutputPort.On("OutputAllStores", mock.Anything, (*model.Cursor)(nil)).Return(nil)
+
+	si := &StoreInteractor{storeRepository: mockStoreRepository, storeOutputPort: mockStoreOutputPort}
+
+	/* Act */
+	actual := si.GetTopFavoriteStores(query, &model.Ranking{Limit: model.DefaultRankingLimit})
+
+	/* Assert */
+	assert.NoError(t, actual)
+	mockStoreRepository.AssertNotCalled(t, "GetTopFavoriteStores", mock.Anything)
+	// 平均評価の高い順に並び、レビューのない店舗は最後になること
+	mockStoreOutputPort.AssertCalled(t, "OutputAllStores", []*model.Store{bestStore, goodStore, unratedStore}, (*model.Cursor)(nil))
+	assert.Equal(t, &model.ReviewSummary{}, unratedStore.Reviews)
 }
@@ -0,0 +1,23 @@
+package port
+
+import (
+	model "clean-storemap-api/src/entity"
+)
+
+type CheckinInputPort interface {
+	GetCheckins(userId string, page *model.PageRequest) error
+	CreateCheckin(checkin *model.Checkin) error
+}
+
+type CheckinRepository interface {
+	GetCheckins(userId string, page *model.PageRequest) ([]*model.Checkin, *model.Cursor, error) // 訪問日時の新しい順に返す
+	FindStore(storeId string) (*model.Store, error)                                              // 店舗が存在しない場合はnilを返す
+	CreateCheckin(store *model.Store, checkin *model.Checkin) error                              // 店舗の情報と合わせて保存する
+}
+
+type CheckinOutputPort interface {
+	OutputCheckins(checkins []*model.Checkin, next *model.Cursor) error
+	OutputCreateCheckinResult() error
+	OutputStoreNotFound() error
+	OutputStoreClosed() error
+}
@@ -0,0 +1,35 @@
+package port
+
+import (
+	model "clean-storemap-api/src/entity"
+)
+
+type FavoriteListInputPort interface {
+	GetLists(userId string) error
+	GetList(listId string, userId string) error
+	CreateList(list *model.FavoriteList, userId string) error
+	UpdateList(listId string, userId string, change *model.FavoriteListChange) error
+	DeleteList(listId string, userId string) error
+	AddStore(listId string, userId string, store *model.Store) error
+	RemoveStore(listId string, userId string, storeId string) error
+}
+
+type FavoriteListRepository interface {
+	GetLists(userId string) ([]*model.FavoriteList, error)              // Positionの順に返す
+	FindList(listId string, userId string) (*model.FavoriteList, error) // 他のユーザーのリストや存在しない場合はnilを返す
+	CreateList(list *model.FavoriteList, userId string) error           // IdとPositionを設定して末尾に追加する
+	UpdateList(list *model.FavoriteList, userId string) error
+	ReorderLists(userId string, lists []*model.FavoriteList) error
+	DeleteList(listId string, userId string) error
+	AddStore(listId string, store *model.Store) error
+	RemoveStore(listId string, storeId string) (bool, error) // リストに店舗がなかった場合はfalseを返す
+}
+
+type FavoriteListOutputPort interface {
+	OutputLists([]*model.FavoriteList) error
+	OutputList(*model.FavoriteList) error
+	OutputUpdateListResult() error
+	OutputListNotFound() error
+	OutputAlreadyExistListStore() error
+	OutputListStoreNotFound() error
+}
@@ -0,0 +1,33 @@
+package port
+
+import (
+	model "clean-storemap-api/src/entity"
+)
+
+type FollowInputPort interface {
+	Follow(userId string, followeeId string) error
+	Unfollow(userId string, followeeId string) error
+	GetFollowing(userId string) error
+	GetFollowers(userId string) error
+	GetFeed(userId string, page *model.FeedPageRequest) error
+}
+
+type FollowRepository interface {
+	ExistUser(userId string) (bool, error)
+	ExistFollow(userId string, followeeId string) (bool, error)
+	Follow(userId string, followeeId string) error
+	Unfollow(userId string, followeeId string) (bool, error) // フォローしていなかった場合はfalseを返す
+	GetFollowing(userId string) ([]*model.User, error)
+	GetFollowers(userId string) ([]*model.User, error)
+	GetFeed(userId string, page *model.FeedPageRequest) ([]*model.FeedItem, *model.FeedCursor, error) // 新しい順に返す。続きがない場合はカーソルにnilを返す
+}
+
+type FollowOutputPort interface {
+	OutputFollowResult() error
+	OutputAlreadyFollowing() error
+	OutputUnfollowResult() error
+	OutputFollowNotFound() error
+	OutputUserNotFound() error
+	OutputUsers(users []*model.User) error
+	OutputFeed(items []*model.FeedItem, next *model.FeedCursor) error
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	model "clean-storemap-api/src/entity"
+)
+
+type RecommendationInputPort interface {
+	GetRecommendations(userId string, options *model.RecommendationOptions) error
+}
+
+type RecommendationRepository interface {
+	GetSimilarUserFavorites(userId string) ([]*model.UserFavorites, error) // ユーザー本人と、同じ店舗を1つ以上登録しているユーザーのお気に入りを返す
+	GetStores(storeIds []string) ([]*model.Store, error)                   // storeIdsの順に返す。存在しない店舗は含まない
+	GetTopFavoriteStores(ranking *model.Ranking) ([]*model.Store, error)
+}
+
+type RecommendationOutputPort interface {
+	OutputRecommendations(recommendations []*model.Recommendation) error
+}
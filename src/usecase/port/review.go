@@ -0,0 +1,32 @@
+package port
+
+import (
+	model "clean-storemap-api/src/entity"
+)
+
+type ReviewInputPort interface {
+	GetStoreReviews(storeId string, page *model.PageRequest) error
+	GetUserReviews(userId string, page *model.PageRequest) error
+	CreateReview(review *model.Review) error
+	UpdateReview(review *model.Review) error
+	DeleteReview(storeId string, userId string) error
+}
+
+type ReviewRepository interface {
+	GetStoreReviews(storeId string, page *model.PageRequest) ([]*model.Review, *model.Cursor, error) // 新しい順に返す
+	GetUserReviews(userId string, page *model.PageRequest) ([]*model.Review, *model.Cursor, error)   // 新しい順に返す
+	GetReviewSummary(storeId string) (*model.ReviewSummary, error)
+	ExistReview(storeId string, userId string) (bool, error)
+	SaveStore(storeId string) (bool, error) // レビューを書く店舗の情報を保存する。店舗が存在しない場合はfalseを返す
+	CreateReview(review *model.Review) error
+	UpdateReview(review *model.Review) (bool, error) // レビューが存在しなかった場合はfalseを返す
+	DeleteReview(storeId string, userId string) (bool, error)
+}
+
+type ReviewOutputPort interface {
+	OutputReviews(reviews []*model.Review, summary *model.ReviewSummary, next *model.Cursor) error
+	OutputSaveReviewResult() error
+	OutputAlreadyExistReview() error
+	OutputReviewNotFound() error
+	OutputStoreNotFound() error
+}
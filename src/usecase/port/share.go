@@ -0,0 +1,28 @@
+package port
+
+import (
+	model "clean-storemap-api/src/entity"
+)
+
+type ShareInputPort interface {
+	CreateShareLink(userId string) error
+	GetShareLinks(userId string) error
+	RevokeShareLink(id string, userId string) error
+	GetSharedFavoriteStores(token string) error
+}
+
+type ShareRepository interface {
+	CreateShareLink(link *model.ShareLink, userId string) error
+	GetShareLinks(userId string) ([]*model.ShareLink, error)
+	DeleteShareLink(id string, userId string) (bool, error)
+	FindSharedUserId(token string) (string, error)           // 取り消された、または存在しないトークンの場合は空文字を返す
+	GetFavoriteStores(userId string) ([]*model.Store, error) // メモやタグ、訪問の記録は本人向けの情報のため含めない
+}
+
+type ShareOutputPort interface {
+	OutputShareLink(link *model.ShareLink) error
+	OutputShareLinks(links []*model.ShareLink) error
+	OutputRevokeShareLinkResult() error
+	OutputShareLinkNotFound() error
+	OutputSharedStores(stores []*model.Store) error
+}
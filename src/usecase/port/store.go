@@ -6,23 +6,50 @@ import (
 
 type StoreInputPort interface {
 	GetStores() error
-	GetNearStores() error
-	GetFavoriteStores(userId string) error
+	GetStoresInBoundingBox(box *model.BoundingBox) error
+	GetStoreClusters(box *model.BoundingBox, zoom int) error
+	GetNearStores(area *model.SearchArea, query *model.StoreQuery, page *model.PageRequest) error
+	SearchStores(search *model.StoreSearch, page *model.PageRequest) error
+	GetStore(id string) error
+	GetFavoriteStores(userId string, query *model.StoreQuery) error
 	SaveFavoriteStore(store *model.Store, userId string) error
-	GetTopFavoriteStores() error
+	UpdateFavoriteStore(storeId string, userId string, favorite *model.Favorite) error
+	DeleteFavoriteStore(storeId string, userId string) error
+	GetFavoriteTags(userId string, prefix string) error
+	ExportFavoriteStores(userId string, format model.ExportFormat) error
+	ImportFavoriteStores(userId string, rows []*model.ImportRow) error
+	GetTopFavoriteStores(query *model.StoreQuery, ranking *model.Ranking) error
 }
 
 type StoreRepository interface {
 	GetAll() ([]*model.Store, error)
-	GetNearStores() ([]*model.Store, error)
+	GetStoresInBoundingBox(box *model.BoundingBox, limit int) ([]*model.Store, error) // 保存済みの店舗のうち範囲内のものを最大limit件返す
+	GetNearStores(area *model.SearchArea, page *model.PageRequest) ([]*model.Store, *model.Cursor, error)
+	SearchStores(search *model.StoreSearch, page *model.PageRequest) ([]*model.Store, *model.Cursor, error)
+	FindStoreSnapshot(id string) (*model.Store, error)
+	GetStoreDetail(id string) (*model.StoreDetail, error)
 	ExistFavorite(store *model.Store, userId string) (bool, error)
 	GetFavoriteStores(userId string) ([]*model.Store, error)
 	SaveFavoriteStore(store *model.Store, userId string) error
-	GetTopFavoriteStores() ([]*model.Store, error)
+	SaveFavoriteStores(stores []*model.Store, userId string) error                             // すべてを1つのトランザクションで登録する
+	UpdateFavoriteStore(storeId string, userId string, favorite *model.Favorite) (bool, error) // お気に入りが存在しなかった場合はfalseを返す
+	DeleteFavoriteStore(storeId string, userId string) (bool, error)                           // お気に入りが存在しなかった場合はfalseを返す
+	GetTopFavoriteStores(ranking *model.Ranking) ([]*model.Store, error)                       // 期間内のお気に入り登録数の多い順に返す
+	GetTopRatedStores(ranking *model.Ranking) ([]*model.Store, error)                          // 期間内のレビューの平均の高い順に返す
+	GetReviewSummaries(storeIds []string) (map[string]*model.ReviewSummary, error)             // レビューのない店舗は含まない
 }
 
 type StoreOutputPort interface {
-	OutputAllStores([]*model.Store) error
+	OutputAllStores(stores []*model.Store, next *model.Cursor) error
+	OutputStoreClusters(clusters []*model.StoreCluster) error
+	OutputStoreDetail(detail *model.StoreDetail) error
+	OutputStoreNotFound() error
 	OutputSaveFavoriteStoreResult() error
 	OutputAlreadyExistFavorite() error
+	OutputUpdateFavoriteStoreResult() error
+	OutputDeleteFavoriteStoreResult() error
+	OutputFavoriteNotFound() error
+	OutputFavoriteTags([]string) error
+	OutputFavoriteStoresExport(stores []*model.Store, format model.ExportFormat) error
+	OutputImportResults(results []*model.ImportResult) error
 }